@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -13,8 +14,10 @@ import (
 
 	_ "github.com/hyorimitsu/knowledge-hub/backend/docs/openapi"
 	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/persistence"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/interfaces/api"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/interfaces/worker"
 )
 
 // @title Knowledge Hub API
@@ -45,7 +48,7 @@ func main() {
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"http://localhost:3000"},
 		AllowMethods: []string{echo.GET, echo.PUT, echo.POST, echo.DELETE},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "X-Tenant"},
 		AllowCredentials: true,
 	}))
 	
@@ -68,10 +71,16 @@ func main() {
 	// Initialize repositories
 	repos := persistence.NewRepositories(db)
 
+	// Initialize mailer
+	mailer := mail.NewMailerFromEnv()
+
 	// Setup API routes
-	router := api.NewRouter(e, repos)
+	router := api.NewRouter(e, repos, mailer)
 	router.SetupRoutes()
 
+	// Purge the data of deleted tenants in the background
+	go worker.NewTenantPurgeWorker(repos, mailer, e.Logger).Run(context.Background())
+
 	// Test error handling
 	e.GET("/api/test-error/:type", func(c echo.Context) error {
 		errorType := c.Param("type")
@@ -0,0 +1,229 @@
+// Package anchor locates comment anchors in knowledge content.
+//
+// Content is split into blocks (headings and paragraphs). Headings are
+// identified by a slug of their text and paragraphs by their position in the
+// enclosing section, e.g. "installation-p2". Offsets are counted in Unicode
+// code points within the text of a block.
+package anchor
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidAnchor is returned when an anchor does not point at existing text
+var ErrInvalidAnchor = errors.New("invalid anchor")
+
+// Block is a heading or paragraph of knowledge content
+type Block struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// Anchor is a text range within a block
+type Anchor struct {
+	BlockID string
+	Start   int
+	End     int
+	Quote   string
+}
+
+// Blocks splits content into headings and paragraphs
+func Blocks(content string) []Block {
+	var blocks []Block
+	var slugs []string // Slug of each block, empty for paragraphs
+	var lines []string
+	inFence := false
+
+	flush := func() {
+		if len(lines) == 0 {
+			return
+		}
+		blocks = append(blocks, Block{Text: strings.Join(lines, "\n")})
+		slugs = append(slugs, "")
+		lines = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Keep fenced code blocks together
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			lines = append(lines, line)
+			if !inFence {
+				flush()
+			}
+			continue
+		}
+		if inFence {
+			lines = append(lines, line)
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			text := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			blocks = append(blocks, Block{Text: text})
+			slugs = append(slugs, slugify(text))
+		default:
+			lines = append(lines, line)
+		}
+	}
+	flush()
+
+	// Identify the blocks. Repeated headings get a numeric suffix that no
+	// other heading uses, e.g. a second "Foo" becomes "foo-2" when a heading
+	// "Foo 1" already is "foo-1".
+	taken := map[string]bool{}
+	for _, slug := range slugs {
+		taken[slug] = true
+	}
+	next := map[string]int{}
+	section := ""
+	paragraph := 0
+	for i, slug := range slugs {
+		if slug == "" {
+			paragraph++
+			blocks[i].ID = "p" + strconv.Itoa(paragraph)
+			if section != "" {
+				blocks[i].ID = section + "-" + blocks[i].ID
+			}
+			continue
+		}
+
+		id := slug
+		if n, seen := next[slug]; seen {
+			for taken[slug+"-"+strconv.Itoa(n)] {
+				n++
+			}
+			id = slug + "-" + strconv.Itoa(n)
+			taken[id] = true
+			next[slug] = n + 1
+		} else {
+			next[slug] = 1
+		}
+		blocks[i].ID = id
+		section = id
+		paragraph = 0
+	}
+
+	return blocks
+}
+
+// New creates an anchor for the given range of a block in content
+func New(content string, blockID string, start int, end int) (Anchor, error) {
+	block, ok := findBlock(Blocks(content), blockID)
+	if !ok {
+		return Anchor{}, ErrInvalidAnchor
+	}
+
+	text := []rune(block.Text)
+	if start < 0 || end > len(text) || start >= end {
+		return Anchor{}, ErrInvalidAnchor
+	}
+
+	return Anchor{
+		BlockID: blockID,
+		Start:   start,
+		End:     end,
+		Quote:   string(text[start:end]),
+	}, nil
+}
+
+// Relocate finds the anchored text in updated content. It prefers the
+// original block and the occurrence closest to the original offset, and
+// falls back to the first occurrence anywhere in the content. It returns
+// false when the anchored text no longer exists.
+func Relocate(a Anchor, content string) (Anchor, bool) {
+	quote := []rune(a.Quote)
+	if len(quote) == 0 {
+		return a, false
+	}
+
+	blocks := Blocks(content)
+
+	// Look in the original block first
+	if block, ok := findBlock(blocks, a.BlockID); ok {
+		if start, ok := nearest(indexes([]rune(block.Text), quote), a.Start); ok {
+			return Anchor{BlockID: block.ID, Start: start, End: start + len(quote), Quote: a.Quote}, true
+		}
+	}
+
+	// Fall back to any block containing the quote
+	for _, block := range blocks {
+		if start, ok := nearest(indexes([]rune(block.Text), quote), a.Start); ok {
+			return Anchor{BlockID: block.ID, Start: start, End: start + len(quote), Quote: a.Quote}, true
+		}
+	}
+
+	return a, false
+}
+
+func findBlock(blocks []Block, id string) (Block, bool) {
+	for _, block := range blocks {
+		if block.ID == id {
+			return block, true
+		}
+	}
+	return Block{}, false
+}
+
+// indexes returns the start offsets of every occurrence of sub in text
+func indexes(text []rune, sub []rune) []int {
+	var result []int
+	for i := 0; i+len(sub) <= len(text); i++ {
+		if slices.Equal(text[i:i+len(sub)], sub) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// nearest returns the offset closest to target
+func nearest(offsets []int, target int) (int, bool) {
+	if len(offsets) == 0 {
+		return 0, false
+	}
+	best := offsets[0]
+	for _, offset := range offsets[1:] {
+		if abs(offset-target) < abs(best-target) {
+			best = offset
+		}
+	}
+	return best, true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// slugify converts heading text to a lowercase, hyphen separated identifier
+func slugify(text string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			hyphen = false
+		case !hyphen && b.Len() > 0:
+			b.WriteRune('-')
+			hyphen = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if slug == "" {
+		slug = "section"
+	}
+	return slug
+}
@@ -0,0 +1,80 @@
+package anchor
+
+import (
+	"strings"
+	"testing"
+)
+
+func blockIDs(content string) string {
+	var ids []string
+	for _, block := range Blocks(content) {
+		ids = append(ids, block.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestBlocksIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "paragraphs in sections",
+			content: "Intro\n\n# Install\n\nFirst\n\nSecond",
+			want:    "p1 install install-p1 install-p2",
+		},
+		{
+			name:    "repeated headings",
+			content: "# Foo\n\n# Foo\n\n# Foo",
+			want:    "foo foo-1 foo-2",
+		},
+		{
+			name:    "repeated heading before a heading with its suffix",
+			content: "# Foo\n\n# Foo\n\n# Foo 1",
+			want:    "foo foo-2 foo-1",
+		},
+		{
+			name:    "repeated heading after a heading with its suffix",
+			content: "# Foo 1\n\n# Foo\n\n# Foo\n\nText",
+			want:    "foo-1 foo foo-2 foo-2-p1",
+		},
+		{
+			name:    "repeated suffixed headings",
+			content: "# Foo\n\n# Foo\n\n# Foo 1\n\n# Foo 1",
+			want:    "foo foo-2 foo-1 foo-1-1",
+		},
+		{
+			name:    "headings in code",
+			content: "```\n# Foo\n```\n\n# Foo",
+			want:    "p1 foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockIDs(tt.content); got != tt.want {
+				t.Errorf("Blocks() IDs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelocate(t *testing.T) {
+	a, err := New("# Foo\n\nhello world, hello", "foo-p1", 13, 18)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	got, ok := Relocate(a, "# Bar\n\nsay hello world, hello")
+	if !ok {
+		t.Fatal("Relocate didn't find the quote")
+	}
+	if got.BlockID != "bar-p1" || got.Start != 17 || got.End != 22 {
+		t.Errorf("Relocate() = %+v, want bar-p1 17-22", got)
+	}
+
+	if _, ok := Relocate(a, "# Foo\n\ngoodbye"); ok {
+		t.Error("Relocate found a removed quote")
+	}
+}
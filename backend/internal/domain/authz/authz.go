@@ -0,0 +1,255 @@
+// Package authz is the authorization policy for content in a tenant.
+//
+// Every user of a tenant can read. Other actions need a permission of the
+// user's role: a write permission to create content and change content they
+// own or that is shared with them, and a manage permission to change anyone's
+// content. Subjects never have access to resources of another tenant.
+//
+// Private spaces and knowledge are visible only to the users they are shared
+// with through access control lists, which the repositories enforce when
+// loading content for a viewer. Access entries also let users change content
+// their role alone wouldn't allow.
+package authz
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// ErrForbidden is returned when the subject may not perform the action
+var ErrForbidden = errors.New("forbidden")
+
+// Action is something a subject does to a resource
+type Action string
+
+// Actions
+const (
+	ActionRead       Action = "read"
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionDelete     Action = "delete"
+	ActionResolve    Action = "resolve"    // Resolving or reopening a review comment
+	ActionPublish    Action = "publish"    // Publishing knowledge rather than saving a draft
+	ActionShare      Action = "share"      // Changing the access control list of a space or knowledge
+	ActionContribute Action = "contribute" // Adding knowledge to a space
+)
+
+// ResourceType is the kind of content a resource is
+type ResourceType string
+
+// Resource types
+const (
+	ResourceKnowledge ResourceType = "knowledge"
+	ResourceComment   ResourceType = "comment"
+	ResourceTag       ResourceType = "tag"
+	ResourceSpace     ResourceType = "space"
+)
+
+// Subject is the user performing an action
+type Subject struct {
+	UserID      string
+	TenantID    string
+	Role        string
+	Permissions []string // Permissions of the role
+}
+
+// HasPermission reports whether the subject's role grants the permission
+func (s Subject) HasPermission(permission string) bool {
+	for _, p := range s.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Resource is the content an action is performed on. For ActionCreate only
+// Type and TenantID are set.
+type Resource struct {
+	Type       ResourceType
+	TenantID   string
+	OwnerID    string   // Author of the content, empty for tags and spaces, which belong to the tenant
+	SharedWith []string // Users who may change the content besides its owner
+	ManagedBy  []string // Users who may also delete and share the content
+	Private    bool     // Visible only to the users it is shared with
+}
+
+// NewResource returns a resource of the type that doesn't exist yet
+func NewResource(resourceType ResourceType, tenantID string) Resource {
+	return Resource{Type: resourceType, TenantID: tenantID}
+}
+
+// KnowledgeResource returns the resource of a knowledge
+func KnowledgeResource(knowledge *model.Knowledge) Resource {
+	return Resource{
+		Type:     ResourceKnowledge,
+		TenantID: knowledge.TenantID,
+		OwnerID:  knowledge.AuthorID,
+		Private:  knowledge.Private,
+	}
+}
+
+// CommentResource returns the resource of a comment. The author of the
+// knowledge it was posted on may resolve it too.
+func CommentResource(comment *model.Comment, knowledgeAuthorID string) Resource {
+	resource := Resource{
+		Type:     ResourceComment,
+		TenantID: comment.TenantID,
+		OwnerID:  comment.AuthorID,
+	}
+	if knowledgeAuthorID != "" {
+		resource.SharedWith = []string{knowledgeAuthorID}
+	}
+	return resource
+}
+
+// TagResource returns the resource of a tag
+func TagResource(tag *model.Tag) Resource {
+	return Resource{
+		Type:     ResourceTag,
+		TenantID: tag.TenantID,
+	}
+}
+
+// SpaceResource returns the resource of a space
+func SpaceResource(space *model.Space) Resource {
+	return Resource{
+		Type:     ResourceSpace,
+		TenantID: space.TenantID,
+		Private:  space.Private,
+	}
+}
+
+// WithAccess returns the resource shared with the user by the access
+// entries granted to them on it, or on the space of a knowledge
+func (r Resource) WithAccess(userID string, entries []*model.AccessEntry) Resource {
+	for _, entry := range entries {
+		if entry.Grants(model.AccessLevelWrite) {
+			r.SharedWith = append(r.SharedWith, userID)
+		}
+		if entry.Grants(model.AccessLevelAdmin) {
+			r.ManagedBy = append(r.ManagedBy, userID)
+		}
+	}
+	return r
+}
+
+// ViewerID returns the user ID to load content for the subject with.
+// Tenant managers see private content too, so that content shared only with
+// users who left can be recovered.
+func ViewerID(subject Subject) string {
+	if subject.HasPermission(model.PermissionTenantManage) {
+		return ""
+	}
+	return subject.UserID
+}
+
+// Viewer returns who to load content for the subject as: whose access
+// entries apply, as ViewerID, and whether comments hidden by moderators are
+// included, which only moderators see
+func Viewer(subject Subject) repository.Viewer {
+	return repository.Viewer{
+		UserID:         ViewerID(subject),
+		HiddenComments: subject.HasPermission(model.PermissionCommentsModerate),
+	}
+}
+
+// Can returns ErrForbidden unless the subject may perform the action on the resource
+func Can(subject Subject, action Action, resource Resource) error {
+	if subject.UserID == "" || subject.TenantID == "" || subject.TenantID != resource.TenantID {
+		return ErrForbidden
+	}
+	if action == ActionRead {
+		return nil
+	}
+
+	switch resource.Type {
+	case ResourceKnowledge:
+		switch action {
+		case ActionPublish:
+			return require(subject.HasPermission(model.PermissionKnowledgePublish))
+		case ActionShare:
+			return require(subject.HasPermission(model.PermissionKnowledgeManage) || resource.isManagedBy(subject.UserID))
+		case ActionDelete:
+			// Users the knowledge is shared with for writing may edit it but not delete it
+			if !subject.HasPermission(model.PermissionKnowledgeManage) && !resource.isManagedBy(subject.UserID) {
+				return ErrForbidden
+			}
+		}
+		return canChange(subject, action, resource, model.PermissionKnowledgeWrite, model.PermissionKnowledgeManage)
+	case ResourceComment:
+		return canChange(subject, action, resource, model.PermissionCommentsWrite, model.PermissionCommentsModerate)
+	case ResourceTag:
+		return canChange(subject, action, resource, model.PermissionTagsCreate, model.PermissionTagsManage)
+	case ResourceSpace:
+		if action == ActionContribute {
+			return require(subject.HasPermission(model.PermissionKnowledgeWrite) &&
+				(!resource.Private || resource.isEditableBy(subject.UserID)))
+		}
+		return require(subject.HasPermission(model.PermissionSpacesManage) || resource.isManagedBy(subject.UserID))
+	}
+	return ErrForbidden
+}
+
+// CanGrant returns ErrForbidden unless the subject may give the role to a
+// user. Subjects can't grant permissions they don't have themselves.
+func CanGrant(subject Subject, role *model.TenantRole) error {
+	if subject.UserID == "" || subject.TenantID != role.TenantID {
+		return ErrForbidden
+	}
+	for _, permission := range role.Permissions {
+		if !subject.HasPermission(permission) {
+			return ErrForbidden
+		}
+	}
+	return nil
+}
+
+// canChange allows creating with the write permission, changing own or shared
+// resources with the write permission, and changing any resource with the
+// manage permission
+func canChange(subject Subject, action Action, resource Resource, write string, manage string) error {
+	if subject.HasPermission(manage) {
+		return nil
+	}
+	if !subject.HasPermission(write) {
+		return ErrForbidden
+	}
+	return require(action == ActionCreate || resource.isEditableBy(subject.UserID))
+}
+
+// require returns ErrForbidden unless allowed
+func require(allowed bool) error {
+	if !allowed {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// isManagedBy reports whether the user owns the resource or manages it through an access entry
+func (r Resource) isManagedBy(userID string) bool {
+	if r.OwnerID != "" && r.OwnerID == userID {
+		return true
+	}
+	for _, id := range r.ManagedBy {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// isEditableBy reports whether the user owns the resource or it is shared with them
+func (r Resource) isEditableBy(userID string) bool {
+	if r.OwnerID == userID {
+		return true
+	}
+	for _, id := range r.SharedWith {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+const (
+	testTenantID = "tenant-1"
+	testUserID   = "user-1"
+	otherUserID  = "user-2"
+)
+
+// relation is how the subject is related to a resource
+type relation string
+
+const (
+	relationOwner       relation = "owner"        // The subject is the author
+	relationWriteAccess relation = "write access" // Shared with the subject for writing
+	relationAdminAccess relation = "admin access" // Shared with the subject for managing
+	relationReadAccess  relation = "read access"  // Shared with the subject for reading
+	relationNone        relation = "none"         // Someone else's, not shared with the subject
+)
+
+var relations = []relation{relationOwner, relationWriteAccess, relationAdminAccess, relationReadAccess, relationNone}
+
+// roleInitials identifies the default roles in the expectations below
+var roleInitials = map[model.Role]string{
+	model.RoleAdmin:  "A",
+	model.RoleEditor: "E",
+	model.RoleViewer: "V",
+}
+
+// subjectWithRole returns the subject of a user with a default role
+func subjectWithRole(role model.Role) Subject {
+	return Subject{
+		UserID:      testUserID,
+		TenantID:    testTenantID,
+		Role:        role.String(),
+		Permissions: model.DefaultRolePermissions()[role],
+	}
+}
+
+// resourceFor returns a resource of the type that is related to the subject.
+// Tags and spaces belong to the tenant, so they never have an owner.
+func resourceFor(resourceType ResourceType, rel relation, private bool) Resource {
+	resource := Resource{Type: resourceType, TenantID: testTenantID, OwnerID: otherUserID, Private: private}
+	if resourceType == ResourceTag || resourceType == ResourceSpace {
+		resource.OwnerID = ""
+	}
+
+	switch rel {
+	case relationOwner:
+		if resource.OwnerID != "" {
+			resource.OwnerID = testUserID
+		}
+	case relationWriteAccess:
+		resource = resource.WithAccess(testUserID, []*model.AccessEntry{{Level: model.AccessLevelWrite}})
+	case relationAdminAccess:
+		resource = resource.WithAccess(testUserID, []*model.AccessEntry{{Level: model.AccessLevelAdmin}})
+	case relationReadAccess:
+		resource = resource.WithAccess(testUserID, []*model.AccessEntry{{Level: model.AccessLevelRead}})
+	}
+	return resource
+}
+
+func TestCanRoleMatrix(t *testing.T) {
+	// Each expectation lists the initials of the default roles allowed for
+	// the relation: Admin, Editor and Viewer
+	tests := []struct {
+		resourceType ResourceType
+		action       Action
+		private      bool
+		allowed      map[relation]string
+	}{
+		{ResourceKnowledge, ActionRead, false, allRelations("AEV")},
+		{ResourceKnowledge, ActionRead, true, allRelations("AEV")}, // Private content is filtered by the repositories
+		{ResourceKnowledge, ActionCreate, false, allRelations("AE")},
+		{ResourceKnowledge, ActionUpdate, false, expect("AE", "AE", "AE", "A", "A")},
+		{ResourceKnowledge, ActionDelete, false, expect("AE", "A", "AE", "A", "A")},
+		{ResourceKnowledge, ActionPublish, false, allRelations("AE")},
+		{ResourceKnowledge, ActionShare, false, expect("AEV", "A", "AEV", "A", "A")},
+
+		{ResourceComment, ActionRead, false, allRelations("AEV")},
+		{ResourceComment, ActionCreate, false, allRelations("AE")},
+		{ResourceComment, ActionUpdate, false, expect("AE", "AE", "AE", "A", "A")},
+		{ResourceComment, ActionDelete, false, expect("AE", "AE", "AE", "A", "A")},
+		{ResourceComment, ActionResolve, false, expect("AE", "AE", "AE", "A", "A")},
+
+		{ResourceTag, ActionRead, false, allRelations("AEV")},
+		{ResourceTag, ActionCreate, false, allRelations("AE")},
+		{ResourceTag, ActionUpdate, false, expect("A", "AE", "AE", "A", "A")},
+		{ResourceTag, ActionDelete, false, expect("A", "AE", "AE", "A", "A")},
+
+		{ResourceSpace, ActionRead, false, allRelations("AEV")},
+		{ResourceSpace, ActionCreate, false, allRelations("A")},
+		{ResourceSpace, ActionUpdate, false, expect("A", "A", "AEV", "A", "A")},
+		{ResourceSpace, ActionDelete, false, expect("A", "A", "AEV", "A", "A")},
+		{ResourceSpace, ActionShare, false, expect("A", "A", "AEV", "A", "A")},
+		{ResourceSpace, ActionContribute, false, allRelations("AE")},
+		{ResourceSpace, ActionContribute, true, expect("", "AE", "AE", "", "")},
+	}
+
+	for _, tt := range tests {
+		for _, rel := range relations {
+			for role, initial := range roleInitials {
+				name := fmt.Sprintf("%s/%s/private=%v/%s/%s", tt.resourceType, tt.action, tt.private, rel, role)
+				t.Run(name, func(t *testing.T) {
+					resource := resourceFor(tt.resourceType, rel, tt.private)
+					if tt.action == ActionCreate {
+						// Content that doesn't exist yet has no owner or access entries
+						resource = NewResource(tt.resourceType, testTenantID)
+					}
+
+					err := Can(subjectWithRole(role), tt.action, resource)
+					want := strings.Contains(tt.allowed[rel], initial)
+					if want && err != nil {
+						t.Errorf("Can = %v, want allowed", err)
+					}
+					if !want && err != ErrForbidden {
+						t.Errorf("Can = %v, want ErrForbidden", err)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestCanSinglePermission(t *testing.T) {
+	checks := []struct {
+		resourceType ResourceType
+		action       Action
+	}{
+		{ResourceKnowledge, ActionCreate},
+		{ResourceKnowledge, ActionUpdate},
+		{ResourceKnowledge, ActionDelete},
+		{ResourceKnowledge, ActionPublish},
+		{ResourceKnowledge, ActionShare},
+		{ResourceComment, ActionCreate},
+		{ResourceComment, ActionUpdate},
+		{ResourceComment, ActionDelete},
+		{ResourceComment, ActionResolve},
+		{ResourceTag, ActionCreate},
+		{ResourceTag, ActionUpdate},
+		{ResourceTag, ActionDelete},
+		{ResourceSpace, ActionCreate},
+		{ResourceSpace, ActionUpdate},
+		{ResourceSpace, ActionDelete},
+		{ResourceSpace, ActionShare},
+		{ResourceSpace, ActionContribute},
+	}
+
+	// What a role with only the permission may do with someone else's
+	// content that isn't shared with the user
+	tests := map[string][]string{
+		model.PermissionKnowledgeWrite:   {"knowledge.create", "space.contribute"},
+		model.PermissionKnowledgePublish: {"knowledge.publish"},
+		model.PermissionKnowledgeManage:  {"knowledge.create", "knowledge.update", "knowledge.delete", "knowledge.share"},
+		model.PermissionCommentsWrite:    {"comment.create"},
+		model.PermissionCommentsModerate: {"comment.create", "comment.update", "comment.delete", "comment.resolve"},
+		model.PermissionTagsCreate:       {"tag.create"},
+		model.PermissionTagsManage:       {"tag.create", "tag.update", "tag.delete"},
+		model.PermissionSpacesManage:     {"space.create", "space.update", "space.delete", "space.share"},
+		model.PermissionUsersInvite:      nil,
+		model.PermissionUsersManage:      nil,
+		model.PermissionTenantManage:     nil,
+	}
+	if len(tests) != len(model.Permissions) {
+		t.Fatalf("%d permissions tested, want all %d", len(tests), len(model.Permissions))
+	}
+
+	for permission, allowed := range tests {
+		subject := Subject{UserID: testUserID, TenantID: testTenantID, Permissions: []string{permission}}
+		for _, check := range checks {
+			name := fmt.Sprintf("%s.%s", check.resourceType, check.action)
+			t.Run(permission+"/"+name, func(t *testing.T) {
+				err := Can(subject, check.action, resourceFor(check.resourceType, relationNone, false))
+				want := contains(allowed, name)
+				if want && err != nil {
+					t.Errorf("Can = %v, want allowed", err)
+				}
+				if !want && err != ErrForbidden {
+					t.Errorf("Can = %v, want ErrForbidden", err)
+				}
+			})
+		}
+	}
+}
+
+func TestCanOtherTenant(t *testing.T) {
+	actions := []Action{ActionRead, ActionCreate, ActionUpdate, ActionDelete, ActionResolve, ActionPublish, ActionShare, ActionContribute}
+	resourceTypes := []ResourceType{ResourceKnowledge, ResourceComment, ResourceTag, ResourceSpace}
+
+	for _, resourceType := range resourceTypes {
+		for _, action := range actions {
+			for _, rel := range relations {
+				resource := resourceFor(resourceType, rel, false)
+				resource.TenantID = "tenant-2"
+
+				if err := Can(subjectWithRole(model.RoleAdmin), action, resource); err != ErrForbidden {
+					t.Errorf("%s %s with %s in another tenant: Can = %v, want ErrForbidden", action, resourceType, rel, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCanWithoutUser(t *testing.T) {
+	tests := map[string]Subject{
+		"no user":   {TenantID: testTenantID, Permissions: model.Permissions},
+		"no tenant": {UserID: testUserID, Permissions: model.Permissions},
+	}
+
+	for name, subject := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource := Resource{Type: ResourceKnowledge, TenantID: subject.TenantID}
+			if err := Can(subject, ActionRead, resource); err != ErrForbidden {
+				t.Errorf("Can = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
+
+func TestCanGrant(t *testing.T) {
+	editorRole := &model.TenantRole{TenantID: testTenantID, Permissions: model.DefaultRolePermissions()[model.RoleEditor]}
+	adminRole := &model.TenantRole{TenantID: testTenantID, Permissions: model.DefaultRolePermissions()[model.RoleAdmin]}
+	otherTenantRole := &model.TenantRole{TenantID: "tenant-2"}
+
+	tests := []struct {
+		name    string
+		subject Subject
+		role    *model.TenantRole
+		want    error
+	}{
+		{"admin grants editor", subjectWithRole(model.RoleAdmin), editorRole, nil},
+		{"admin grants admin", subjectWithRole(model.RoleAdmin), adminRole, nil},
+		{"editor grants editor", subjectWithRole(model.RoleEditor), editorRole, nil},
+		{"editor grants admin", subjectWithRole(model.RoleEditor), adminRole, ErrForbidden},
+		{"viewer grants editor", subjectWithRole(model.RoleViewer), editorRole, ErrForbidden},
+		{"role of another tenant", subjectWithRole(model.RoleAdmin), otherTenantRole, ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CanGrant(tt.subject, tt.role); err != tt.want {
+				t.Errorf("CanGrant = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewerID(t *testing.T) {
+	if got := ViewerID(subjectWithRole(model.RoleAdmin)); got != "" {
+		t.Errorf("ViewerID of a tenant manager = %q, want all content", got)
+	}
+	if got := ViewerID(subjectWithRole(model.RoleEditor)); got != testUserID {
+		t.Errorf("ViewerID of an editor = %q, want %q", got, testUserID)
+	}
+}
+
+// allRelations expects the same roles for every relation
+func allRelations(roles string) map[relation]string {
+	return expect(roles, roles, roles, roles, roles)
+}
+
+// expect lists the allowed roles by relation, in the order of relations
+func expect(owner, writeAccess, adminAccess, readAccess, none string) map[relation]string {
+	return map[relation]string{
+		relationOwner:       owner,
+		relationWriteAccess: writeAccess,
+		relationAdminAccess: adminAccess,
+		relationReadAccess:  readAccess,
+		relationNone:        none,
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+// Package mention finds @handle mentions in knowledge content and comments.
+//
+// A mention is an @ followed by a handle of lowercase words separated by
+// single hyphens, matched case-insensitively. An @ preceded by a letter,
+// digit or dot, as in email addresses, doesn't start a mention.
+package mention
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches a mention and the character before it
+var mentionPattern = regexp.MustCompile(`(^|[^A-Za-z0-9._@])@([A-Za-z0-9]+(?:-[A-Za-z0-9]+)*)`)
+
+// Handles returns the handles mentioned in the text, lowercased, in order of
+// first appearance and without duplicates
+func Handles(text string) []string {
+	var handles []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		handle := strings.ToLower(match[2])
+		if !seen[handle] {
+			seen[handle] = true
+			handles = append(handles, handle)
+		}
+	}
+	return handles
+}
+
+// Added returns the handles mentioned in the text that weren't mentioned in
+// the previous version of it
+func Added(previous string, text string) []string {
+	before := map[string]bool{}
+	for _, handle := range Handles(previous) {
+		before[handle] = true
+	}
+	var added []string
+	for _, handle := range Handles(text) {
+		if !before[handle] {
+			added = append(added, handle)
+		}
+	}
+	return added
+}
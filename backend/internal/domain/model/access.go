@@ -0,0 +1,73 @@
+package model
+
+import "time"
+
+// Access levels of an access control list entry. Each level includes the
+// ones before it.
+const (
+	AccessLevelRead  = "read"  // See the resource
+	AccessLevelWrite = "write" // Edit the resource, or add knowledge to a space
+	AccessLevelAdmin = "admin" // Edit, delete and share the resource
+)
+
+// Types of resources access can be granted on
+const (
+	AccessResourceSpace     = "space"
+	AccessResourceKnowledge = "knowledge"
+)
+
+// Types of principals access can be granted to
+const (
+	PrincipalUser  = "user"
+	PrincipalGroup = "group"
+)
+
+// AccessEntry grants a user or a group access to a space or a knowledge.
+// Entries on a space apply to its knowledge too.
+type AccessEntry struct {
+	ID            string    `json:"id" gorm:"primaryKey"`
+	TenantID      string    `json:"tenant_id"`
+	ResourceType  string    `json:"resource_type"`
+	ResourceID    string    `json:"resource_id"`
+	PrincipalType string    `json:"principal_type"`
+	PrincipalID   string    `json:"principal_id"`
+	Level         string    `json:"level"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for AccessEntry
+func (AccessEntry) TableName() string {
+	return "access_entries"
+}
+
+// Grants reports whether the entry gives at least the access level
+func (e *AccessEntry) Grants(level string) bool {
+	return accessLevelRank(e.Level) >= accessLevelRank(level)
+}
+
+// IsValidAccessLevel reports whether the level is known
+func IsValidAccessLevel(level string) bool {
+	return accessLevelRank(level) > 0
+}
+
+// accessLevelRank orders the access levels, 0 for unknown levels
+func accessLevelRank(level string) int {
+	switch level {
+	case AccessLevelRead:
+		return 1
+	case AccessLevelWrite:
+		return 2
+	case AccessLevelAdmin:
+		return 3
+	}
+	return 0
+}
+
+// AccessList is the access control of a space or a knowledge
+type AccessList struct {
+	ResourceType string         `json:"resource_type"`
+	ResourceID   string         `json:"resource_id"`
+	Private      bool           `json:"private"`
+	Entries      []*AccessEntry `json:"entries"`
+	Inherited    []*AccessEntry `json:"inherited"` // Entries of the knowledge's space
+}
@@ -0,0 +1,92 @@
+package model
+
+import "time"
+
+// API token kind constants
+const (
+	APITokenKindPersonal = "personal" // Created by a user for their own scripts
+	APITokenKindService  = "service"  // Created by an admin for the tenant's automation, acting as that admin
+	APITokenKindSCIM     = "scim"     // Created by an admin for the identity provider provisioning users
+)
+
+// API token scope constants
+const (
+	ScopeKnowledgeRead  = "knowledge:read"
+	ScopeKnowledgeWrite = "knowledge:write"
+	ScopeTagsRead       = "tags:read"
+	ScopeTagsWrite      = "tags:write"
+	ScopeCommentsRead   = "comments:read"
+	ScopeCommentsWrite  = "comments:write"
+
+	// ScopeSCIM is implied by SCIM tokens, which can only be used for SCIM provisioning
+	ScopeSCIM = "scim"
+)
+
+// APITokenScopes lists the scopes an API token can be granted
+var APITokenScopes = []string{
+	ScopeKnowledgeRead,
+	ScopeKnowledgeWrite,
+	ScopeTagsRead,
+	ScopeTagsWrite,
+	ScopeCommentsRead,
+	ScopeCommentsWrite,
+}
+
+// APITokenLastUsedInterval limits how often a token's last-used time is recorded
+const APITokenLastUsedInterval = time.Minute
+
+// APIToken is a long-lived credential for scripts and CI jobs. It acts as
+// its user, limited to its scopes. Service keys have no principal of their
+// own: they act as the admin who created them, and stop working when that
+// admin is deactivated or no longer has tenant.manage. Only a hash of the
+// token is stored.
+type APIToken struct {
+	ID         string     `json:"id" gorm:"primaryKey"`
+	TenantID   string     `json:"tenant_id"`
+	UserID     string     `json:"user_id"` // The owner of a personal token, or the admin who created a service key
+	Kind       string     `json:"kind"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // Start of the token, to recognise it in lists
+	TokenHash  string     `json:"-"`
+	Scopes     []string   `json:"scopes" gorm:"serializer:json"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // Never expires when empty
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for APIToken
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+// IsActive reports whether the token has neither been revoked nor expired
+func (t *APIToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+// HasScope reports whether the token was granted the scope
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsTouch reports whether the last-used time is stale enough to be recorded again
+func (t *APIToken) NeedsTouch(now time.Time) bool {
+	return t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= APITokenLastUsedInterval
+}
+
+// IsValidScope reports whether the scope can be granted to an API token
+func IsValidScope(scope string) bool {
+	for _, s := range APITokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// Group is a named set of users in a tenant. Access can be granted to a
+// group as a whole, and members are notified when the group is mentioned.
+type Group struct {
+	ID         string    `json:"id" gorm:"primaryKey"`
+	TenantID   string    `json:"tenant_id"`
+	Name       string    `json:"name"`
+	Handle     string    `json:"handle"`                // Unique in the tenant, mentions the group as @handle
+	Role       string    `json:"role,omitempty"`        // Role whose permissions members get in addition to their own, empty for none
+	ExternalID string    `json:"external_id,omitempty"` // ID in the identity provider provisioning the group through SCIM
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Group
+func (Group) TableName() string {
+	return "groups"
+}
+
+// GroupMember links a user to a group
+type GroupMember struct {
+	GroupID   string    `json:"group_id" gorm:"primaryKey"`
+	UserID    string    `json:"user_id" gorm:"primaryKey"`
+	TenantID  string    `json:"tenant_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GroupMember
+func (GroupMember) TableName() string {
+	return "group_members"
+}
+
+// GroupHandle derives a mention handle from a group name, e.g.
+// "platform-team" from "Platform team". Names without ASCII letters and
+// digits fall back to "group".
+func GroupHandle(name string) string {
+	return slugify(name, "group")
+}
+
+// IsValidHandle reports whether the handle can be mentioned, i.e. it is
+// lowercase words separated by single hyphens
+func IsValidHandle(handle string) bool {
+	return IsValidSlug(handle)
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Invitation status constants
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// Invitation represents an invitation to join a tenant
+type Invitation struct {
+	ID         string     `json:"id" gorm:"primaryKey"`
+	TenantID   string     `json:"tenant_id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	TokenHash  string     `json:"-"`
+	InvitedBy  string     `json:"invited_by"`
+	Status     string     `json:"status"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for Invitation
+func (Invitation) TableName() string {
+	return "invitations"
+}
+
+// IsExpired reports whether the invitation can no longer be accepted
+func (i *Invitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
@@ -10,16 +10,20 @@ const (
 )
 
 type Knowledge struct {
-	ID        string    `json:"id" gorm:"primaryKey"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	AuthorID  string    `json:"author_id"`
-	TenantID  string    `json:"tenant_id"`
-	Status    string    `json:"status"`
-	Tags      []Tag     `json:"tags" gorm:"many2many:knowledge_tags;"`
-	Comments  []Comment `json:"comments" gorm:"foreignKey:KnowledgeID"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                     string    `json:"id" gorm:"primaryKey"`
+	Title                  string    `json:"title"`
+	Content                string    `json:"content"`
+	AuthorID               string    `json:"author_id"`
+	TenantID               string    `json:"tenant_id"`
+	SpaceID                *string   `json:"space_id"` // Nil for knowledge outside of any space
+	Private                bool      `json:"private"`  // Visible only to its author and the users it is shared with
+	Status                 string    `json:"status"`
+	DiscussionLocked       bool      `json:"discussion_locked"` // Prevents new comments from being posted
+	Tags                   []Tag     `json:"tags" gorm:"many2many:knowledge_tags;"`
+	Comments               []Comment `json:"comments" gorm:"foreignKey:KnowledgeID"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+	UnresolvedCommentCount int       `json:"unresolved_comment_count" gorm:"-"` // Computed from the loaded comments
 }
 
 // TableName specifies the table name for Knowledge
@@ -27,6 +31,17 @@ func (Knowledge) TableName() string {
 	return "knowledge"
 }
 
+// CountUnresolvedComments sets UnresolvedCommentCount from the loaded comments
+func (k *Knowledge) CountUnresolvedComments() {
+	count := 0
+	for _, comment := range k.Comments {
+		if !comment.Resolved && !comment.Hidden {
+			count++
+		}
+	}
+	k.UnresolvedCommentCount = count
+}
+
 type Tag struct {
 	ID        string    `json:"id" gorm:"primaryKey"`
 	Name      string    `json:"name"`
@@ -41,16 +56,30 @@ func (Tag) TableName() string {
 }
 
 type Comment struct {
-	ID          string    `json:"id" gorm:"primaryKey"`
-	Content     string    `json:"content"`
-	AuthorID    string    `json:"author_id"`
-	KnowledgeID string    `json:"knowledge_id"`
-	TenantID    string    `json:"tenant_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id" gorm:"primaryKey"`
+	Content     string     `json:"content"`
+	AuthorID    string     `json:"author_id"`
+	KnowledgeID string     `json:"knowledge_id"`
+	TenantID    string     `json:"tenant_id"`
+	Hidden      bool       `json:"hidden"`
+	Resolved    bool       `json:"resolved"`
+	ResolvedBy  *string    `json:"resolved_by,omitempty"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	AnchorBlock string     `json:"anchor_block,omitempty"` // Heading or paragraph the comment is anchored to
+	AnchorStart *int       `json:"anchor_start,omitempty"`
+	AnchorEnd   *int       `json:"anchor_end,omitempty"`
+	AnchorQuote string     `json:"anchor_quote,omitempty"` // Anchored text at the time of commenting
+	Outdated    bool       `json:"outdated"`               // Anchored text was removed from the content
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 // TableName specifies the table name for Comment
 func (Comment) TableName() string {
 	return "comments"
 }
+
+// IsInline reports whether the comment is anchored to a text range
+func (c *Comment) IsInline() bool {
+	return c.AnchorBlock != ""
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// Login throttle kind constants
+const (
+	LoginThrottleKindAccount   = "account"
+	LoginThrottleKindIP        = "ip"
+	LoginThrottleKindChallenge = "challenge"
+)
+
+// LoginThrottle tracks recent failed logins for an email address or an IP
+// address within a tenant, and how long further attempts are blocked
+type LoginThrottle struct {
+	ID           string     `json:"id" gorm:"primaryKey"`
+	TenantID     string     `json:"tenant_id"`
+	Kind         string     `json:"kind"`
+	Key          string     `json:"key"` // Lowercase email, IP address or login challenge ID
+	Failures     int        `json:"failures"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	BlockedUntil *time.Time `json:"blocked_until,omitempty"` // Backoff between attempts
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`  // Account lockout after too many failures
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for LoginThrottle
+func (LoginThrottle) TableName() string {
+	return "login_throttles"
+}
+
+// RetryAfter returns how long login attempts are still blocked, or zero if they are allowed
+func (t *LoginThrottle) RetryAfter(now time.Time) time.Duration {
+	var retryAfter time.Duration
+	for _, until := range []*time.Time{t.BlockedUntil, t.LockedUntil} {
+		if until != nil && until.Sub(now) > retryAfter {
+			retryAfter = until.Sub(now)
+		}
+	}
+	return retryAfter
+}
+
+// IsLocked reports whether the account is locked out
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Membership links the accounts a user has in the tenants they belong to.
+// A user has an account of their own in every tenant, holding their role
+// there, so that content and permissions stay scoped to a tenant. The
+// memberships of one user share an account ID, which lets them switch between
+// their accounts without signing in again.
+type Membership struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	AccountID string    `json:"account_id"` // ID of the account the user signed up with, shared by all their memberships
+	TenantID  string    `json:"tenant_id"`
+	UserID    string    `json:"user_id"` // Account of the user in the tenant
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for Membership
+func (Membership) TableName() string {
+	return "memberships"
+}
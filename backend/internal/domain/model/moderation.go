@@ -0,0 +1,76 @@
+package model
+
+import "time"
+
+// Comment report status constants
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// Moderation action constants
+const (
+	ModerationActionHideComment      = "hide_comment"
+	ModerationActionUnhideComment    = "unhide_comment"
+	ModerationActionLockDiscussion   = "lock_discussion"
+	ModerationActionUnlockDiscussion = "unlock_discussion"
+	ModerationActionDismissReport    = "dismiss_report"
+)
+
+// Moderation target type constants
+const (
+	ModerationTargetComment   = "comment"
+	ModerationTargetKnowledge = "knowledge"
+	ModerationTargetReport    = "report"
+)
+
+// CommentRevision keeps a previous version of a comment's content
+type CommentRevision struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	CommentID string    `json:"comment_id"`
+	TenantID  string    `json:"tenant_id"`
+	Content   string    `json:"content"`
+	EditorID  string    `json:"editor_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for CommentRevision
+func (CommentRevision) TableName() string {
+	return "comment_revisions"
+}
+
+// CommentReport is a user's report of an inappropriate comment
+type CommentReport struct {
+	ID         string    `json:"id" gorm:"primaryKey"`
+	CommentID  string    `json:"comment_id"`
+	Comment    *Comment  `json:"comment,omitempty" gorm:"foreignKey:CommentID"`
+	TenantID   string    `json:"tenant_id"`
+	ReporterID string    `json:"reporter_id"`
+	Reason     string    `json:"reason"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for CommentReport
+func (CommentReport) TableName() string {
+	return "comment_reports"
+}
+
+// ModerationAction records an action taken by a moderator
+type ModerationAction struct {
+	ID         string    `json:"id" gorm:"primaryKey"`
+	TenantID   string    `json:"tenant_id"`
+	ActorID    string    `json:"actor_id"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for ModerationAction
+func (ModerationAction) TableName() string {
+	return "moderation_actions"
+}
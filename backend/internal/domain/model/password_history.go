@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// PasswordHistory is a previous password of a user, kept to prevent its reuse
+type PasswordHistory struct {
+	ID           string    `json:"id" gorm:"primaryKey"`
+	UserID       string    `json:"user_id"`
+	TenantID     string    `json:"tenant_id"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for PasswordHistory
+func (PasswordHistory) TableName() string {
+	return "password_histories"
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the
+// user's authenticator is unavailable
+type RecoveryCode struct {
+	ID        string     `json:"id" gorm:"primaryKey"`
+	UserID    string     `json:"user_id"`
+	TenantID  string     `json:"tenant_id"`
+	CodeHash  string     `json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for RecoveryCode
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
@@ -0,0 +1,118 @@
+package model
+
+import "time"
+
+// Permissions a tenant role can grant. Reading is allowed to every user of
+// the tenant and needs no permission.
+const (
+	PermissionKnowledgeWrite   = "knowledge.write"   // Create knowledge and edit own or shared knowledge
+	PermissionKnowledgePublish = "knowledge.publish" // Publish knowledge rather than only save drafts
+	PermissionKnowledgeManage  = "knowledge.manage"  // Edit and delete anyone's knowledge
+	PermissionCommentsWrite    = "comments.write"    // Post comments and edit own comments
+	PermissionCommentsModerate = "comments.moderate" // Hide comments, lock discussions, handle reports and edit anyone's comments
+	PermissionTagsCreate       = "tags.create"
+	PermissionTagsManage       = "tags.manage"   // Rename and delete tags
+	PermissionSpacesManage     = "spaces.manage" // Create, edit and delete spaces
+	PermissionUsersInvite      = "users.invite"
+	PermissionUsersManage      = "users.manage"  // Change roles, deactivate and delete users, manage groups
+	PermissionTenantManage     = "tenant.manage" // Tenant settings, SSO, API keys and roles
+)
+
+// Permissions lists the permissions a tenant role can grant
+var Permissions = []string{
+	PermissionKnowledgeWrite,
+	PermissionKnowledgePublish,
+	PermissionKnowledgeManage,
+	PermissionCommentsWrite,
+	PermissionCommentsModerate,
+	PermissionTagsCreate,
+	PermissionTagsManage,
+	PermissionSpacesManage,
+	PermissionUsersInvite,
+	PermissionUsersManage,
+	PermissionTenantManage,
+}
+
+// IsValidPermission reports whether the permission can be granted to a role
+func IsValidPermission(permission string) bool {
+	for _, p := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantRole is a named set of permissions. Users reference their role by
+// name, so the name of a role can't change.
+type TenantRole struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	TenantID    string    `json:"tenant_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions" gorm:"serializer:json"`
+	System      bool      `json:"system"` // Seeded default role, which can't be deleted
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for TenantRole
+func (TenantRole) TableName() string {
+	return "roles"
+}
+
+// HasPermission reports whether the role grants the permission
+func (r *TenantRole) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEditable reports whether the role's permissions can be changed. The admin
+// role always has every permission so that a tenant can't lock itself out.
+func (r *TenantRole) IsEditable() bool {
+	return !(r.System && r.Name == RoleAdmin.String())
+}
+
+// DefaultRolePermissions returns the permissions of the default roles seeded
+// for every tenant
+func DefaultRolePermissions() map[Role][]string {
+	return map[Role][]string{
+		RoleAdmin: append([]string(nil), Permissions...),
+		RoleEditor: {
+			PermissionKnowledgeWrite,
+			PermissionKnowledgePublish,
+			PermissionCommentsWrite,
+			PermissionTagsCreate,
+		},
+		RoleViewer: {},
+	}
+}
+
+// DefaultRoles returns the default roles of a new tenant. IDs are assigned
+// by the caller.
+func DefaultRoles(tenantID string) []*TenantRole {
+	descriptions := map[Role]string{
+		RoleAdmin:  "Full access to the tenant",
+		RoleEditor: "Writes and publishes knowledge and comments",
+		RoleViewer: "Reads knowledge",
+	}
+
+	now := time.Now()
+	roles := make([]*TenantRole, 0, len(descriptions))
+	for _, role := range []Role{RoleAdmin, RoleEditor, RoleViewer} {
+		roles = append(roles, &TenantRole{
+			TenantID:    tenantID,
+			Name:        role.String(),
+			Description: descriptions[role],
+			Permissions: DefaultRolePermissions()[role],
+			System:      true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+	return roles
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// Session is a signed-in device of a user. Access tokens carry the session ID
+// as their jti so that revoking the session revokes them as well.
+type Session struct {
+	ID         string     `json:"id" gorm:"primaryKey"`
+	UserID     string     `json:"user_id"`
+	TenantID   string     `json:"tenant_id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for Session
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// IsActive reports whether the session has neither been revoked nor expired
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// SessionLastSeenInterval limits how often a session's last-seen time is recorded
+const SessionLastSeenInterval = time.Minute
+
+// NeedsTouch reports whether the last-seen time is stale enough to be recorded again
+func (s *Session) NeedsTouch(now time.Time) bool {
+	return now.Sub(s.LastSeenAt) >= SessionLastSeenInterval
+}
+
+// RefreshToken is a single-use token to obtain a new access token for a session.
+// Each refresh rotates it; a rotated token is kept to detect its reuse.
+type RefreshToken struct {
+	ID        string     `json:"id" gorm:"primaryKey"`
+	SessionID string     `json:"session_id"`
+	TenantID  string     `json:"tenant_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for RefreshToken
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Space groups the knowledge of a tenant, typically of one team
+type Space struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	TenantID    string    `json:"tenant_id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"` // Unique in the tenant, used in URLs
+	Description string    `json:"description"`
+	Icon        string    `json:"icon"`                                              // Emoji or icon name
+	Private     bool      `json:"private"`                                           // Visible, with its knowledge, only to the users it is shared with
+	DefaultTags []Tag     `json:"default_tags" gorm:"many2many:space_default_tags;"` // Added to knowledge created in or moved to the space
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Space
+func (Space) TableName() string {
+	return "spaces"
+}
+
+// MaxSlugLength is the longest slug of a space
+const MaxSlugLength = 100
+
+// slugPattern matches lowercase words separated by single hyphens
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// IsValidSlug reports whether the slug can identify a space in URLs
+func IsValidSlug(slug string) bool {
+	return len(slug) <= MaxSlugLength && slugPattern.MatchString(slug)
+}
+
+// Slugify derives a slug from a space name. Characters other than ASCII
+// letters and digits separate words; names without any fall back to "space".
+func Slugify(name string) string {
+	return slugify(name, "space")
+}
+
+// slugify derives a slug from a name, or returns the fallback for names
+// without ASCII letters and digits
+func slugify(name string, fallback string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			hyphen = false
+		case !hyphen && b.Len() > 0:
+			b.WriteRune('-')
+			hyphen = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if len(slug) > MaxSlugLength {
+		slug = strings.TrimSuffix(slug[:MaxSlugLength], "-")
+	}
+	if slug == "" {
+		slug = fallback
+	}
+	return slug
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// SSOState tracks a single SSO sign-in between the redirect to the identity
+// provider and its callback
+type SSOState struct {
+	ID           string    `json:"id" gorm:"primaryKey"`
+	TenantID     string    `json:"tenant_id"`
+	StateHash    string    `json:"-"` // Hash of the OIDC state or of the SAML request ID
+	Nonce        string    `json:"-"` // OIDC only
+	CodeVerifier string    `json:"-"` // PKCE verifier sent with the authorization code, OIDC only
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for SSOState
+func (SSOState) TableName() string {
+	return "sso_states"
+}
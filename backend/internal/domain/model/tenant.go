@@ -1,14 +1,25 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// Registration mode constants
+const (
+	RegistrationModeClosed     = "closed"
+	RegistrationModeInviteOnly = "invite_only"
+	RegistrationModeOpen       = "open"
+)
 
 type Tenant struct {
-	ID        string    `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name"`
-	Domain    string    `json:"domain" gorm:"unique"`
-	Settings  Settings  `json:"settings" gorm:"embedded"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         string     `json:"id" gorm:"primaryKey"`
+	Name       string     `json:"name"`
+	Domain     string     `json:"domain" gorm:"unique"`
+	Settings   Settings   `json:"settings" gorm:"embedded"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"` // Set while the tenant is scheduled for deletion
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
 }
 
 // TableName specifies the table name for Tenant
@@ -16,9 +27,24 @@ func (Tenant) TableName() string {
 	return "tenants"
 }
 
+// IsDisabled reports whether users can no longer sign in to the tenant
+func (t *Tenant) IsDisabled() bool {
+	return t.DisabledAt != nil
+}
+
 type Settings struct {
-	Theme    Theme    `json:"theme" gorm:"embedded"`
-	Features Features `json:"features" gorm:"embedded"`
+	Theme          Theme          `json:"theme" gorm:"embedded"`
+	Features       Features       `json:"features" gorm:"embedded"`
+	Registration   Registration   `json:"registration" gorm:"embedded;embeddedPrefix:registration_"`
+	Security       Security       `json:"security" gorm:"embedded;embeddedPrefix:security_"`
+	PasswordPolicy PasswordPolicy `json:"password_policy" gorm:"embedded;embeddedPrefix:password_"`
+	SSO            SSO            `json:"sso" gorm:"embedded;embeddedPrefix:sso_"`
+	SAML           SAML           `json:"saml" gorm:"embedded;embeddedPrefix:saml_"`
+}
+
+// PasswordLoginDisabled reports whether users must sign in through OIDC or SAML
+func (s Settings) PasswordLoginDisabled() bool {
+	return s.SSO.PasswordLoginDisabled() || s.SAML.PasswordLoginDisabled()
 }
 
 type Theme struct {
@@ -31,3 +57,138 @@ type Features struct {
 	Tags     bool `json:"tags"`
 	Ratings  bool `json:"ratings"`
 }
+
+// Registration controls who may create an account in a tenant
+type Registration struct {
+	Mode           string   `json:"mode"`
+	AllowedDomains []string `json:"allowed_domains" gorm:"serializer:json"` // Email domains allowed in open mode; empty allows any
+}
+
+// Default account lockout policy, used when a tenant does not set its own
+const (
+	DefaultMaxFailedLogins = 5
+	DefaultLockoutMinutes  = 15
+)
+
+// Security contains the authentication requirements of a tenant
+type Security struct {
+	RequireTwoFactor bool `json:"require_two_factor"` // Users must enroll in 2FA before they can sign in
+	MaxFailedLogins  int  `json:"max_failed_logins"`  // Failed logins before the account is locked; 0 uses the default
+	LockoutMinutes   int  `json:"lockout_minutes"`    // How long a locked account stays locked; 0 uses the default
+}
+
+// FailedLoginLimit returns the number of failed logins that locks an account
+func (s Security) FailedLoginLimit() int {
+	if s.MaxFailedLogins <= 0 {
+		return DefaultMaxFailedLogins
+	}
+	return s.MaxFailedLogins
+}
+
+// LockoutDuration returns how long a locked account stays locked
+func (s Security) LockoutDuration() time.Duration {
+	if s.LockoutMinutes <= 0 {
+		return DefaultLockoutMinutes * time.Minute
+	}
+	return time.Duration(s.LockoutMinutes) * time.Minute
+}
+
+// DefaultPasswordMinLength is the minimum password length when a tenant does not set its own
+const DefaultPasswordMinLength = 8
+
+// PasswordPolicy contains the rules new passwords must follow
+type PasswordPolicy struct {
+	MinLength            int  `json:"min_length"` // 0 uses the default
+	RequireUppercase     bool `json:"require_uppercase"`
+	RequireLowercase     bool `json:"require_lowercase"`
+	RequireDigit         bool `json:"require_digit"`
+	RequireSymbol        bool `json:"require_symbol"`
+	DisallowPersonalInfo bool `json:"disallow_personal_info"` // Reject passwords containing the user's email or name
+	DisallowCommon       bool `json:"disallow_common"`        // Reject commonly used and breached passwords
+	HistorySize          int  `json:"history_size"`           // Previous passwords that can't be reused; 0 only rejects the current one
+}
+
+// DefaultPasswordPolicy returns the policy of new tenants
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:            DefaultPasswordMinLength,
+		DisallowPersonalInfo: true,
+		DisallowCommon:       true,
+	}
+}
+
+// RequiredLength returns the minimum password length
+func (p PasswordPolicy) RequiredLength() int {
+	if p.MinLength <= 0 {
+		return DefaultPasswordMinLength
+	}
+	return p.MinLength
+}
+
+// SSO configures signing in with the tenant's OpenID Connect provider
+type SSO struct {
+	Enabled              bool              `json:"enabled"`
+	Issuer               string            `json:"issuer"`
+	ClientID             string            `json:"client_id"`
+	ClientSecret         string            `json:"-"`
+	AllowedDomains       []string          `json:"allowed_domains" gorm:"serializer:json"` // Email domains allowed to sign in; empty allows any
+	RoleClaim            string            `json:"role_claim"`                             // ID token claim holding the user's groups or roles
+	RoleMapping          map[string]string `json:"role_mapping" gorm:"serializer:json"`    // Claim value to role
+	DefaultRole          string            `json:"default_role"`                           // Role of provisioned users matching no mapping; empty uses viewer
+	DisablePasswordLogin bool              `json:"disable_password_login"`                 // Only allow signing in through SSO
+}
+
+// PasswordLoginDisabled reports whether users must sign in through SSO
+func (s SSO) PasswordLoginDisabled() bool {
+	return s.Enabled && s.DisablePasswordLogin
+}
+
+// AllowsEmail reports whether the email's domain may sign in through SSO
+func (s SSO) AllowsEmail(email string) bool {
+	return Registration{AllowedDomains: s.AllowedDomains}.AllowsEmail(email)
+}
+
+// SAML configures signing in with the tenant's SAML 2.0 identity provider
+type SAML struct {
+	Enabled              bool              `json:"enabled"`
+	IdPEntityID          string            `json:"idp_entity_id"`
+	IdPSSOURL            string            `json:"idp_sso_url"`                            // Single sign-on service with the HTTP-Redirect binding
+	IdPCertificate       string            `json:"idp_certificate"`                        // PEM certificate signing the identity provider's responses
+	EmailAttribute       string            `json:"email_attribute"`                        // Attribute holding the email; empty uses the NameID
+	NameAttribute        string            `json:"name_attribute"`                         // Attribute holding the display name
+	AllowedDomains       []string          `json:"allowed_domains" gorm:"serializer:json"` // Email domains allowed to sign in; empty allows any
+	RoleAttribute        string            `json:"role_attribute"`                         // Attribute holding the user's groups or roles
+	RoleMapping          map[string]string `json:"role_mapping" gorm:"serializer:json"`    // Attribute value to role
+	DefaultRole          string            `json:"default_role"`                           // Role of provisioned users matching no mapping; empty uses viewer
+	DisablePasswordLogin bool              `json:"disable_password_login"`                 // Only allow signing in through SSO
+}
+
+// PasswordLoginDisabled reports whether users must sign in through SAML
+func (s SAML) PasswordLoginDisabled() bool {
+	return s.Enabled && s.DisablePasswordLogin
+}
+
+// AllowsEmail reports whether the email's domain may sign in through SAML
+func (s SAML) AllowsEmail(email string) bool {
+	return Registration{AllowedDomains: s.AllowedDomains}.AllowsEmail(email)
+}
+
+// AllowsEmail reports whether the email's domain is on the allowlist
+func (r Registration) AllowsEmail(email string) bool {
+	if len(r.AllowedDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+
+	for _, allowed := range r.AllowedDomains {
+		if strings.ToLower(strings.TrimPrefix(allowed, "@")) == domain {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// Tenant deletion status constants
+const (
+	TenantDeletionStatusScheduled = "scheduled"
+	TenantDeletionStatusCanceled  = "canceled"
+	TenantDeletionStatusPurging   = "purging"
+	TenantDeletionStatusCompleted = "completed"
+	TenantDeletionStatusFailed    = "failed"
+)
+
+// TenantDeletion tracks the deletion of a tenant. Deleting a tenant disables
+// it right away and schedules its data to be purged once the grace period
+// has passed, until when the deletion can be canceled. The record outlives
+// the tenant so that the report of what was purged stays available.
+type TenantDeletion struct {
+	ID          string           `json:"id" gorm:"primaryKey"`
+	TenantID    string           `json:"tenant_id"`
+	TenantName  string           `json:"tenant_name"`
+	RequestedBy string           `json:"requested_by"`
+	NotifyEmail string           `json:"-"` // Where the final report is sent
+	Status      string           `json:"status"`
+	PurgeAfter  time.Time        `json:"purge_after"`
+	LockedUntil *time.Time       `json:"-"` // Lease of the worker purging the tenant
+	StartedAt   *time.Time       `json:"started_at,omitempty"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	CanceledAt  *time.Time       `json:"canceled_at,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	Report      map[string]int64 `json:"report,omitempty" gorm:"serializer:json"` // Number of rows purged per table
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// TableName specifies the table name for TenantDeletion
+func (TenantDeletion) TableName() string {
+	return "tenant_deletions"
+}
+
+// CanCancel reports whether the deletion can still be canceled
+func (d *TenantDeletion) CanCancel(now time.Time) bool {
+	return d.Status == TenantDeletionStatusScheduled && now.Before(d.PurgeAfter)
+}
+
+// IsPending reports whether the tenant's data is still to be purged
+func (d *TenantDeletion) IsPending() bool {
+	switch d.Status {
+	case TenantDeletionStatusScheduled, TenantDeletionStatusPurging, TenantDeletionStatusFailed:
+		return true
+	}
+	return false
+}
@@ -3,15 +3,21 @@ package model
 import "time"
 
 type User struct {
-	ID        string    `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email" gorm:"unique"`
-	Password  string    `json:"-" gorm:"column:password_hash"` // Password is not exposed in JSON
-	Avatar    string    `json:"avatar,omitempty" gorm:"-"` // Skip this field when inserting into the database
-	TenantID  string    `json:"tenant_id"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                string     `json:"id" gorm:"primaryKey"`
+	Name              string     `json:"name"`
+	Email             string     `json:"email"` // Unique in the tenant, a user may have accounts in several tenants
+	Password          string     `json:"-" gorm:"column:password_hash"` // Password is not exposed in JSON
+	Avatar            string     `json:"avatar,omitempty" gorm:"-"`     // Skip this field when inserting into the database
+	TenantID          string     `json:"tenant_id"`
+	Role              string     `json:"role"`
+	DeactivatedAt     *time.Time `json:"deactivated_at,omitempty"` // Deactivated users cannot log in
+	PasswordChangedAt *time.Time `json:"-"`                        // Time of the last password change
+	TwoFactorEnabled  bool       `json:"two_factor_enabled"`
+	TwoFactorSecret   string     `json:"-"` // Set on enrollment, used once enabled
+	TwoFactorLastStep int64      `json:"-"` // Last accepted TOTP time step, to prevent code reuse
+	ExternalID        string     `json:"external_id,omitempty"` // ID in the identity provider provisioning the user through SCIM
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
 
 // TableName specifies the table name for User
@@ -19,6 +25,13 @@ func (User) TableName() string {
 	return "users"
 }
 
+// IsActive reports whether the user is allowed to log in
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+// Role is the name of a tenant role. The default roles are seeded for every
+// tenant; tenants may define more.
 type Role string
 
 const (
@@ -30,12 +43,3 @@ const (
 func (r Role) String() string {
 	return string(r)
 }
-
-func (r Role) IsValid() bool {
-	switch r {
-	case RoleAdmin, RoleEditor, RoleViewer:
-		return true
-	default:
-		return false
-	}
-}
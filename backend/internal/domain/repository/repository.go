@@ -1,6 +1,16 @@
 package repository
 
-import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// Viewer is who content is loaded for
+type Viewer struct {
+	UserID         string // Whose access entries apply, empty to load content regardless of them
+	HiddenComments bool   // Whether comments hidden by moderators are loaded
+}
 
 type TenantRepository interface {
 	Create(tenant *model.Tenant) error
@@ -8,13 +18,15 @@ type TenantRepository interface {
 	FindByDomain(domain string) (*model.Tenant, error)
 	Update(tenant *model.Tenant) error
 	Delete(id string) error
+	PurgeBatch(id string, limit int) (table string, deleted int64, err error)
 }
 
 type KnowledgeRepository interface {
 	Create(knowledge *model.Knowledge) error
-	FindByID(id string, tenantID string) (*model.Knowledge, error)
-	FindAll(tenantID string) ([]*model.Knowledge, error)
-	Search(query string, tenantID string, tagIDs []string, authorID string) ([]*model.Knowledge, error)
+	FindByID(id string, tenantID string, viewer Viewer) (*model.Knowledge, error)
+	FindAll(tenantID string, viewer Viewer) ([]*model.Knowledge, error)
+	Search(query string, tenantID string, tagIDs []string, authorID string, spaceID string, viewer Viewer) ([]*model.Knowledge, error)
+	CountBySpace(spaceID string, tenantID string) (int64, error)
 	Update(knowledge *model.Knowledge) error
 	Delete(id string, tenantID string) error
 }
@@ -30,15 +42,156 @@ type TagRepository interface {
 type CommentRepository interface {
 	Create(comment *model.Comment) error
 	FindByID(id string, tenantID string) (*model.Comment, error)
-	FindByKnowledgeID(knowledgeID string, tenantID string) ([]*model.Comment, error)
+	FindByKnowledgeID(knowledgeID string, tenantID string, resolved *bool, viewer Viewer) ([]*model.Comment, error)
 	Update(comment *model.Comment) error
 	Delete(id string, tenantID string) error
+	CreateRevision(revision *model.CommentRevision) error
+	FindRevisions(commentID string, tenantID string) ([]*model.CommentRevision, error)
+}
+
+type ModerationRepository interface {
+	CreateReport(report *model.CommentReport) error
+	FindReportByID(id string, tenantID string) (*model.CommentReport, error)
+	FindOpenReports(tenantID string) ([]*model.CommentReport, error)
+	UpdateReport(report *model.CommentReport) error
+	ResolveReportsForComment(commentID string, tenantID string) error
+	CreateAction(action *model.ModerationAction) error
+	FindActions(tenantID string) ([]*model.ModerationAction, error)
 }
 
 type UserRepository interface {
 	Create(user *model.User) error
+	CreateInvited(user *model.User, invitation *model.Invitation) (bool, error)
 	FindByID(id string, tenantID string) (*model.User, error)
 	FindByEmail(email string, tenantID string) (*model.User, error)
+	FindByExternalID(externalID string, tenantID string) (*model.User, error)
+	Search(query string, tenantID string, offset int, limit int) ([]*model.User, int64, error)
+	Count(tenantID string) (int64, error)
 	Update(user *model.User) error
+	UseTwoFactorStep(user *model.User, step int64) (bool, error)
+	Delete(id string, tenantID string) error
+}
+
+type InvitationRepository interface {
+	Create(invitation *model.Invitation) error
+	FindByID(id string, tenantID string) (*model.Invitation, error)
+	FindByTokenHash(tokenHash string) (*model.Invitation, error)
+	FindByTenantID(tenantID string, status string) ([]*model.Invitation, error)
+	Update(invitation *model.Invitation) error
+	RevokePending(email string, tenantID string) error
+}
+
+type PasswordResetRepository interface {
+	Create(token *model.PasswordResetToken) error
+	FindByTokenHash(tokenHash string) (*model.PasswordResetToken, error)
+	InvalidateForUser(userID string, tenantID string) error
+}
+
+type SessionRepository interface {
+	Create(session *model.Session) error
+	FindByID(id string, tenantID string) (*model.Session, error)
+	FindActiveByUserID(userID string, tenantID string) ([]*model.Session, error)
+	Update(session *model.Session) error
+	Touch(session *model.Session, ipAddress string) error
+	Extend(session *model.Session, expiresAt time.Time, ipAddress string) (bool, error)
+	Revoke(session *model.Session) error
+	RevokeAllForUser(userID string, tenantID string) error
+	RevokeAllForTenant(tenantID string, exceptSessionID string) error
+	CreateRefreshToken(token *model.RefreshToken) error
+	FindRefreshTokenByHash(tokenHash string) (*model.RefreshToken, error)
+	MarkRefreshTokenUsed(token *model.RefreshToken) (bool, error)
+}
+
+type RecoveryCodeRepository interface {
+	ReplaceForUser(userID string, tenantID string, codes []*model.RecoveryCode) error
+	FindByHash(userID string, tenantID string, codeHash string) (*model.RecoveryCode, error)
+	MarkUsed(code *model.RecoveryCode) (bool, error)
+	CountUnused(userID string, tenantID string) (int64, error)
+	DeleteForUser(userID string, tenantID string) error
+}
+
+type LoginThrottleRepository interface {
+	Find(tenantID string, kind string, key string) (*model.LoginThrottle, error)
+	Increment(throttle *model.LoginThrottle, window time.Duration) error
+	Block(throttle *model.LoginThrottle) error
+	Delete(tenantID string, kind string, key string) error
+}
+
+type PasswordHistoryRepository interface {
+	Create(entry *model.PasswordHistory) error
+	FindRecentByUserID(userID string, tenantID string, limit int) ([]*model.PasswordHistory, error)
+	Prune(userID string, tenantID string, keep int) error
+}
+
+type APITokenRepository interface {
+	Create(token *model.APIToken) error
+	FindByID(id string, tenantID string) (*model.APIToken, error)
+	FindByTokenHash(tokenHash string) (*model.APIToken, error)
+	FindByTenantID(tenantID string, kind string, userID string) ([]*model.APIToken, error)
+	Update(token *model.APIToken) error
+	Touch(token *model.APIToken) error
+}
+
+type SSOStateRepository interface {
+	Create(state *model.SSOState) error
+	Consume(stateHash string) (*model.SSOState, error)
+}
+
+type GroupRepository interface {
+	Create(group *model.Group) error
+	FindByID(id string, tenantID string) (*model.Group, error)
+	FindByName(name string, tenantID string) (*model.Group, error)
+	FindByHandle(handle string, tenantID string) (*model.Group, error)
+	FindByExternalID(externalID string, tenantID string) (*model.Group, error)
+	FindByUserID(userID string, tenantID string) ([]*model.Group, error)
+	Search(query string, tenantID string, offset int, limit int) ([]*model.Group, int64, error)
+	Update(group *model.Group) error
+	Delete(id string, tenantID string) error
+	FindMembers(groupID string, tenantID string) ([]*model.User, error)
+	AddMembers(groupID string, tenantID string, userIDs []string) error
+	RemoveMembers(groupID string, tenantID string, userIDs []string) error
+	ReplaceMembers(groupID string, tenantID string, userIDs []string) error
+}
+
+type RoleRepository interface {
+	Create(role *model.TenantRole) error
+	FindByID(id string, tenantID string) (*model.TenantRole, error)
+	FindByName(name string, tenantID string) (*model.TenantRole, error)
+	FindAll(tenantID string) ([]*model.TenantRole, error)
+	Update(role *model.TenantRole) error
 	Delete(id string, tenantID string) error
+	CountUsers(name string, tenantID string) (int64, error)
+	CountGroups(name string, tenantID string) (int64, error)
+}
+
+type SpaceRepository interface {
+	Create(space *model.Space) error
+	FindByID(id string, tenantID string, viewerID string) (*model.Space, error)
+	FindBySlug(slug string, tenantID string, viewerID string) (*model.Space, error)
+	FindAll(tenantID string, viewerID string) ([]*model.Space, error)
+	Update(space *model.Space) error
+	Delete(id string, tenantID string) error
+}
+
+type AccessRepository interface {
+	FindByResource(resourceType string, resourceID string, tenantID string) ([]*model.AccessEntry, error)
+	FindByUser(userID string, tenantID string, resourceIDs []string) ([]*model.AccessEntry, error)
+	Replace(resourceType string, resourceID string, tenantID string, entries []*model.AccessEntry) error
+}
+
+type MembershipRepository interface {
+	Create(membership *model.Membership) error
+	FindByUserID(userID string, tenantID string) (*model.Membership, error)
+	FindByAccountID(accountID string) ([]*model.Membership, error)
+	Join(account *model.User, memberships []*model.Membership, invitation *model.Invitation) (bool, error)
+}
+
+type TenantDeletionRepository interface {
+	Create(deletion *model.TenantDeletion) error
+	FindByID(id string) (*model.TenantDeletion, error)
+	FindLatestByTenantID(tenantID string) (*model.TenantDeletion, error)
+	FindDue(now time.Time) ([]*model.TenantDeletion, error)
+	Claim(deletion *model.TenantDeletion, lockedUntil time.Time) (bool, error)
+	Cancel(deletion *model.TenantDeletion) (bool, error)
+	Update(deletion *model.TenantDeletion) error
 }
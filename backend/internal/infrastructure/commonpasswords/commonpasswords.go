@@ -0,0 +1,32 @@
+// Package commonpasswords provides an offline list of passwords that are too
+// common to be allowed, so that policy checks don't depend on an external service.
+package commonpasswords
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed passwords.txt
+var list string
+
+// passwords holds the lowercase entries of the embedded list
+var passwords = parse(list)
+
+func parse(list string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		set[strings.ToLower(line)] = struct{}{}
+	}
+	return set
+}
+
+// Contains reports whether the password is on the list, ignoring case
+func Contains(password string) bool {
+	_, ok := passwords[strings.ToLower(password)]
+	return ok
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type logMailer struct {
+	from string
+	dir  string
+}
+
+// NewLogMailer creates a mailer for local development. Messages are written as
+// .eml files to dir. When dir is empty, only their recipients and subjects are
+// written to the standard logger, as bodies carry tokens such as invitation
+// and password reset links.
+func NewLogMailer(from, dir string) Mailer {
+	return &logMailer{
+		from: from,
+		dir:  dir,
+	}
+}
+
+// Send writes the message
+func (m *logMailer) Send(msg Message) error {
+	if m.dir == "" {
+		log.Printf("mail: message to %s with subject %q (body redacted, set MAIL_DIR to keep it)", msg.To, msg.Subject)
+		return nil
+	}
+
+	now := time.Now()
+	content := format(m.from, msg, now)
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%d.eml", now.Format("20060102T150405"), now.UnixNano())
+	return os.WriteFile(filepath.Join(m.dir, name), content, 0o644)
+}
@@ -0,0 +1,129 @@
+package persistence
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// Access control of private spaces and knowledge is enforced in the queries
+// loading them rather than by filtering the results. Repositories take the ID
+// of the user the content is loaded for; an empty viewer ID loads everything,
+// for system tasks and tenant managers.
+
+// grantedToViewer matches access entries, aliased "access_entries", granted to
+// the viewer directly or through one of their groups
+const grantedToViewer = `((access_entries.principal_type = @user AND access_entries.principal_id = @viewer)
+	OR (access_entries.principal_type = @group AND access_entries.principal_id IN (SELECT group_id FROM group_members WHERE user_id = @viewer)))`
+
+// visibleKnowledge restricts a knowledge query to the knowledge the viewer
+// can see: their own, knowledge that is neither private nor in a private
+// space, and knowledge shared with them directly or through its space
+func visibleKnowledge(db *gorm.DB, viewerID string) *gorm.DB {
+	if viewerID == "" {
+		return db
+	}
+	return db.Where(`knowledge.author_id = @viewer
+		OR (NOT knowledge.private AND (knowledge.space_id IS NULL OR knowledge.space_id IN (SELECT id FROM spaces WHERE NOT spaces.private)))
+		OR EXISTS (SELECT 1 FROM access_entries WHERE access_entries.resource_type = @knowledge AND access_entries.resource_id = knowledge.id AND `+grantedToViewer+`)
+		OR EXISTS (SELECT 1 FROM access_entries WHERE access_entries.resource_type = @space AND access_entries.resource_id = knowledge.space_id AND `+grantedToViewer+`)`,
+		accessArgs(viewerID))
+}
+
+// visibleSpaces restricts a space query to the spaces the viewer can see
+func visibleSpaces(db *gorm.DB, viewerID string) *gorm.DB {
+	if viewerID == "" {
+		return db
+	}
+	return db.Where(`NOT spaces.private
+		OR EXISTS (SELECT 1 FROM access_entries WHERE access_entries.resource_type = @space AND access_entries.resource_id = spaces.id AND `+grantedToViewer+`)`,
+		accessArgs(viewerID))
+}
+
+// visibleComments restricts a comment query, or the preload of the comments
+// of knowledge, to the comments the viewer can see. Comments hidden by
+// moderators are left out unless the viewer may see them.
+func visibleComments(viewer repository.Viewer) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewer.HiddenComments {
+			return db
+		}
+		return db.Where("comments.hidden = ?", false)
+	}
+}
+
+// accessArgs returns the named arguments of the access control conditions
+func accessArgs(viewerID string) map[string]interface{} {
+	return map[string]interface{}{
+		"viewer":    viewerID,
+		"user":      model.PrincipalUser,
+		"group":     model.PrincipalGroup,
+		"knowledge": model.AccessResourceKnowledge,
+		"space":     model.AccessResourceSpace,
+	}
+}
+
+// deleteAccessOfResource deletes the access entries of a space or a knowledge
+func deleteAccessOfResource(tx *gorm.DB, resourceType string, resourceID string, tenantID string) error {
+	return tx.Where("resource_type = ? AND resource_id = ? AND tenant_id = ?", resourceType, resourceID, tenantID).
+		Delete(&model.AccessEntry{}).Error
+}
+
+// deleteAccessOfPrincipal deletes the access entries granted to a user or a group
+func deleteAccessOfPrincipal(tx *gorm.DB, principalType string, principalID string, tenantID string) error {
+	return tx.Where("principal_type = ? AND principal_id = ? AND tenant_id = ?", principalType, principalID, tenantID).
+		Delete(&model.AccessEntry{}).Error
+}
+
+type accessRepository struct {
+	db *Database
+}
+
+func NewAccessRepository(db *Database) repository.AccessRepository {
+	return &accessRepository{db}
+}
+
+func (r *accessRepository) FindByResource(resourceType string, resourceID string, tenantID string) ([]*model.AccessEntry, error) {
+	var entries []*model.AccessEntry
+	err := r.db.
+		Where("resource_type = ? AND resource_id = ? AND tenant_id = ?", resourceType, resourceID, tenantID).
+		Order("created_at ASC").
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *accessRepository) FindByUser(userID string, tenantID string, resourceIDs []string) ([]*model.AccessEntry, error) {
+	var entries []*model.AccessEntry
+	if len(resourceIDs) == 0 {
+		return entries, nil
+	}
+	err := r.db.
+		Where("resource_id IN ? AND tenant_id = ?", resourceIDs, tenantID).
+		Where(grantedToViewer, accessArgs(userID)).
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *accessRepository) Replace(resourceType string, resourceID string, tenantID string, entries []*model.AccessEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Delete previous entries
+		if err := deleteAccessOfResource(tx, resourceType, resourceID, tenantID); err != nil {
+			return err
+		}
+
+		// Create entries
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.Create(entries).Error
+	})
+}
@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type apiTokenRepository struct {
+	db *Database
+}
+
+func NewAPITokenRepository(db *Database) repository.APITokenRepository {
+	return &apiTokenRepository{db}
+}
+
+func (r *apiTokenRepository) Create(token *model.APIToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *apiTokenRepository) FindByID(id string, tenantID string) (*model.APIToken, error) {
+	var token model.APIToken
+	err := r.db.First(&token, "id = ? AND tenant_id = ?", id, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *apiTokenRepository) FindByTokenHash(tokenHash string) (*model.APIToken, error) {
+	var token model.APIToken
+	err := r.db.First(&token, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByTenantID returns the tenant's unrevoked tokens of a kind, optionally only those of a user
+func (r *apiTokenRepository) FindByTenantID(tenantID string, kind string, userID string) ([]*model.APIToken, error) {
+	var tokens []*model.APIToken
+	query := r.db.Where("tenant_id = ? AND kind = ? AND revoked_at IS NULL", tenantID, kind)
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	err := query.Order("created_at DESC").Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *apiTokenRepository) Update(token *model.APIToken) error {
+	return r.db.Save(token).Error
+}
+
+// Touch records that the token was just used, without rewriting the rest of the row
+func (r *apiTokenRepository) Touch(token *model.APIToken) error {
+	now := time.Now()
+	err := r.db.Model(&model.APIToken{}).
+		Where("id = ?", token.ID).
+		Update("last_used_at", now).
+		Error
+	if err != nil {
+		return err
+	}
+	token.LastUsedAt = &now
+	return nil
+}
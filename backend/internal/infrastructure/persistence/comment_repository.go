@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"gorm.io/gorm"
+
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
@@ -26,10 +28,17 @@ func (r *commentRepository) FindByID(id string, tenantID string) (*model.Comment
 	return &comment, nil
 }
 
-func (r *commentRepository) FindByKnowledgeID(knowledgeID string, tenantID string) ([]*model.Comment, error) {
+func (r *commentRepository) FindByKnowledgeID(knowledgeID string, tenantID string, resolved *bool, viewer repository.Viewer) ([]*model.Comment, error) {
+	db := r.db.DB.Where("knowledge_id = ? AND tenant_id = ?", knowledgeID, tenantID).
+		Scopes(visibleComments(viewer))
+
+	// Filter by resolved state if provided
+	if resolved != nil {
+		db = db.Where("resolved = ?", *resolved)
+	}
+
 	var comments []*model.Comment
-	err := r.db.
-		Where("knowledge_id = ? AND tenant_id = ?", knowledgeID, tenantID).
+	err := db.
 		Order("created_at DESC").
 		Find(&comments).
 		Error
@@ -44,5 +53,33 @@ func (r *commentRepository) Update(comment *model.Comment) error {
 }
 
 func (r *commentRepository) Delete(id string, tenantID string) error {
-	return r.db.Delete(&model.Comment{}, "id = ? AND tenant_id = ?", id, tenantID).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Delete edit history and reports of the comment
+		if err := tx.Where("comment_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.CommentRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.CommentReport{}).Error; err != nil {
+			return err
+		}
+
+		// Delete comment
+		return tx.Delete(&model.Comment{}, "id = ? AND tenant_id = ?", id, tenantID).Error
+	})
+}
+
+func (r *commentRepository) CreateRevision(revision *model.CommentRevision) error {
+	return r.db.Create(revision).Error
+}
+
+func (r *commentRepository) FindRevisions(commentID string, tenantID string) ([]*model.CommentRevision, error) {
+	var revisions []*model.CommentRevision
+	err := r.db.
+		Where("comment_id = ? AND tenant_id = ?", commentID, tenantID).
+		Order("created_at DESC").
+		Find(&revisions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
 }
@@ -39,6 +39,25 @@ func NewDatabase() *Database {
 		&model.Tag{},
 		&model.Comment{},
 		&model.User{},
+		&model.CommentRevision{},
+		&model.CommentReport{},
+		&model.ModerationAction{},
+		&model.Invitation{},
+		&model.PasswordResetToken{},
+		&model.Session{},
+		&model.RefreshToken{},
+		&model.RecoveryCode{},
+		&model.LoginThrottle{},
+		&model.PasswordHistory{},
+		&model.APIToken{},
+		&model.SSOState{},
+		&model.Group{},
+		&model.GroupMember{},
+		&model.TenantRole{},
+		&model.Space{},
+		&model.AccessEntry{},
+		&model.Membership{},
+		&model.TenantDeletion{},
 	)
 	if err != nil {
 		log.Fatalf("failed to migrate database: %v", err)
@@ -0,0 +1,197 @@
+package persistence
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type groupRepository struct {
+	db *Database
+}
+
+func NewGroupRepository(db *Database) repository.GroupRepository {
+	return &groupRepository{db}
+}
+
+func (r *groupRepository) Create(group *model.Group) error {
+	return r.db.Create(group).Error
+}
+
+func (r *groupRepository) FindByID(id string, tenantID string) (*model.Group, error) {
+	var group model.Group
+	err := r.db.First(&group, "id = ? AND tenant_id = ?", id, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) FindByName(name string, tenantID string) (*model.Group, error) {
+	var group model.Group
+	err := r.db.First(&group, "LOWER(name) = ? AND tenant_id = ?", strings.ToLower(name), tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) FindByHandle(handle string, tenantID string) (*model.Group, error) {
+	var group model.Group
+	err := r.db.First(&group, "handle = ? AND tenant_id = ?", handle, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) FindByExternalID(externalID string, tenantID string) (*model.Group, error) {
+	var group model.Group
+	err := r.db.First(&group, "external_id = ? AND tenant_id = ?", externalID, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) FindByUserID(userID string, tenantID string) ([]*model.Group, error) {
+	var groups []*model.Group
+	err := r.db.
+		Joins("JOIN group_members ON group_members.group_id = groups.id").
+		Where("group_members.user_id = ? AND groups.tenant_id = ?", userID, tenantID).
+		Order("groups.name ASC").
+		Find(&groups).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *groupRepository) Search(query string, tenantID string, offset int, limit int) ([]*model.Group, int64, error) {
+	db := r.db.DB.Model(&model.Group{}).Where("tenant_id = ?", tenantID)
+
+	// Add search conditions
+	if query != "" {
+		searchQuery := "%" + strings.ToLower(query) + "%"
+		db = db.Where("LOWER(name) LIKE ? OR handle LIKE ?", searchQuery, searchQuery)
+	}
+
+	// Count all matches before paginating
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var groups []*model.Group
+	err := db.
+		Order("name ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&groups).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+func (r *groupRepository) Update(group *model.Group) error {
+	return r.db.Save(group).Error
+}
+
+func (r *groupRepository) Delete(id string, tenantID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Delete memberships
+		if err := tx.Where("group_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.GroupMember{}).Error; err != nil {
+			return err
+		}
+
+		// Delete access granted to the group
+		if err := deleteAccessOfPrincipal(tx, model.PrincipalGroup, id, tenantID); err != nil {
+			return err
+		}
+
+		// Delete group
+		return tx.Delete(&model.Group{}, "id = ? AND tenant_id = ?", id, tenantID).Error
+	})
+}
+
+func (r *groupRepository) FindMembers(groupID string, tenantID string) ([]*model.User, error) {
+	var users []*model.User
+	err := r.db.
+		Joins("JOIN group_members ON group_members.user_id = users.id").
+		Where("group_members.group_id = ? AND users.tenant_id = ?", groupID, tenantID).
+		Order("users.name ASC").
+		Find(&users).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *groupRepository) AddMembers(groupID string, tenantID string, userIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return addGroupMembers(tx, groupID, tenantID, userIDs)
+	})
+}
+
+func (r *groupRepository) RemoveMembers(groupID string, tenantID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return r.db.
+		Where("group_id = ? AND tenant_id = ? AND user_id IN ?", groupID, tenantID, userIDs).
+		Delete(&model.GroupMember{}).
+		Error
+}
+
+func (r *groupRepository) ReplaceMembers(groupID string, tenantID string, userIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ? AND tenant_id = ?", groupID, tenantID).Delete(&model.GroupMember{}).Error; err != nil {
+			return err
+		}
+		return addGroupMembers(tx, groupID, tenantID, userIDs)
+	})
+}
+
+// addGroupMembers inserts the memberships, skipping users already in the group
+func addGroupMembers(tx *gorm.DB, groupID string, tenantID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	var existing []string
+	err := tx.Model(&model.GroupMember{}).
+		Where("group_id = ? AND tenant_id = ? AND user_id IN ?", groupID, tenantID, userIDs).
+		Pluck("user_id", &existing).
+		Error
+	if err != nil {
+		return err
+	}
+	skip := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		skip[id] = true
+	}
+
+	members := make([]*model.GroupMember, 0, len(userIDs))
+	for _, id := range userIDs {
+		if skip[id] {
+			continue
+		}
+		skip[id] = true
+		members = append(members, &model.GroupMember{
+			GroupID:  groupID,
+			UserID:   id,
+			TenantID: tenantID,
+		})
+	}
+	if len(members) == 0 {
+		return nil
+	}
+	return tx.Create(&members).Error
+}
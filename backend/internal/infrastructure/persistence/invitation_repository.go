@@ -0,0 +1,68 @@
+package persistence
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type invitationRepository struct {
+	db *Database
+}
+
+func NewInvitationRepository(db *Database) repository.InvitationRepository {
+	return &invitationRepository{db}
+}
+
+func (r *invitationRepository) Create(invitation *model.Invitation) error {
+	return r.db.Create(invitation).Error
+}
+
+func (r *invitationRepository) FindByID(id string, tenantID string) (*model.Invitation, error) {
+	var invitation model.Invitation
+	err := r.db.First(&invitation, "id = ? AND tenant_id = ?", id, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) FindByTokenHash(tokenHash string) (*model.Invitation, error) {
+	var invitation model.Invitation
+	err := r.db.First(&invitation, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) FindByTenantID(tenantID string, status string) ([]*model.Invitation, error) {
+	var invitations []*model.Invitation
+	query := r.db.Where("tenant_id = ?", tenantID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.
+		Order("created_at DESC").
+		Find(&invitations).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *invitationRepository) Update(invitation *model.Invitation) error {
+	return r.db.Save(invitation).Error
+}
+
+func (r *invitationRepository) RevokePending(email string, tenantID string) error {
+	return r.db.Model(&model.Invitation{}).
+		Where("LOWER(email) = ? AND tenant_id = ? AND status = ?", strings.ToLower(email), tenantID, model.InvitationStatusPending).
+		Updates(map[string]interface{}{
+			"status":     model.InvitationStatusRevoked,
+			"updated_at": time.Now(),
+		}).Error
+}
@@ -21,30 +21,34 @@ func (r *knowledgeRepository) Create(knowledge *model.Knowledge) error {
 	return r.db.Create(knowledge).Error
 }
 
-func (r *knowledgeRepository) FindByID(id string, tenantID string) (*model.Knowledge, error) {
+func (r *knowledgeRepository) FindByID(id string, tenantID string, viewer repository.Viewer) (*model.Knowledge, error) {
 	var knowledge model.Knowledge
-	err := r.db.
+	err := visibleKnowledge(r.db.DB, viewer.UserID).
 		Preload("Tags").
-		Preload("Comments").
+		Preload("Comments", visibleComments(viewer)).
 		First(&knowledge, "id = ? AND tenant_id = ?", id, tenantID).
 		Error
 	if err != nil {
 		return nil, err
 	}
+	knowledge.CountUnresolvedComments()
 	return &knowledge, nil
 }
 
-func (r *knowledgeRepository) FindAll(tenantID string) ([]*model.Knowledge, error) {
+func (r *knowledgeRepository) FindAll(tenantID string, viewer repository.Viewer) ([]*model.Knowledge, error) {
 	var knowledges []*model.Knowledge
-	err := r.db.
+	err := visibleKnowledge(r.db.DB, viewer.UserID).
 		Preload("Tags").
-		Preload("Comments").
+		Preload("Comments", visibleComments(viewer)).
 		Where("tenant_id = ?", tenantID).
 		Find(&knowledges).
 		Error
 	if err != nil {
 		return nil, err
 	}
+	for _, knowledge := range knowledges {
+		knowledge.CountUnresolvedComments()
+	}
 	return knowledges, nil
 }
 
@@ -60,12 +64,15 @@ func (r *knowledgeRepository) Update(knowledge *model.Knowledge) error {
 	})
 }
 
-func (r *knowledgeRepository) Search(query string, tenantID string, tagIDs []string, authorID string) ([]*model.Knowledge, error) {
+func (r *knowledgeRepository) Search(query string, tenantID string, tagIDs []string, authorID string, spaceID string, viewer repository.Viewer) ([]*model.Knowledge, error) {
 	db := r.db.DB.Model(&model.Knowledge{}).
 		Preload("Tags").
-		Preload("Comments").
+		Preload("Comments", visibleComments(viewer)).
 		Where("tenant_id = ?", tenantID)
 
+	// Hide knowledge the viewer has no access to
+	db = visibleKnowledge(db, viewer.UserID)
+
 	// Add search conditions
 	if query != "" {
 		searchQuery := "%" + strings.ToLower(query) + "%"
@@ -77,6 +84,11 @@ func (r *knowledgeRepository) Search(query string, tenantID string, tagIDs []str
 		db = db.Where("knowledge.author_id = ?", authorID)
 	}
 
+	// Filter by space if provided
+	if spaceID != "" {
+		db = db.Where("knowledge.space_id = ?", spaceID)
+	}
+
 	// Filter by tags if provided
 	if len(tagIDs) > 0 {
 		db = db.Joins("JOIN knowledge_tags ON knowledge_tags.knowledge_id = knowledge.id").
@@ -89,20 +101,46 @@ func (r *knowledgeRepository) Search(query string, tenantID string, tagIDs []str
 	if err := db.Find(&results).Error; err != nil {
 		return nil, err
 	}
+	for _, knowledge := range results {
+		knowledge.CountUnresolvedComments()
+	}
 
 	return results, nil
 }
 
+func (r *knowledgeRepository) CountBySpace(spaceID string, tenantID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Knowledge{}).Where("space_id = ? AND tenant_id = ?", spaceID, tenantID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (r *knowledgeRepository) Delete(id string, tenantID string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Delete edit history and reports of related comments
+		commentIDs := tx.Model(&model.Comment{}).Select("id").Where("knowledge_id = ? AND tenant_id = ?", id, tenantID)
+		if err := tx.Where("comment_id IN (?) AND tenant_id = ?", commentIDs, tenantID).Delete(&model.CommentRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id IN (?) AND tenant_id = ?", commentIDs, tenantID).Delete(&model.CommentReport{}).Error; err != nil {
+			return err
+		}
+
 		// Delete related comments
 		if err := tx.Where("knowledge_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.Comment{}).Error; err != nil {
 			return err
 		}
 
-		// Delete knowledge_tags associations
-		knowledge := &model.Knowledge{ID: id}
-		if err := tx.Model(knowledge).Association("Tags").Clear(); err != nil {
+		// Delete access entries
+		if err := deleteAccessOfResource(tx, model.AccessResourceKnowledge, id, tenantID); err != nil {
+			return err
+		}
+
+		// Delete knowledge_tags associations, only of the tenant's knowledge
+		knowledgeIDs := tx.Model(&model.Knowledge{}).Select("id").Where("id = ? AND tenant_id = ?", id, tenantID)
+		if err := tx.Exec("DELETE FROM knowledge_tags WHERE knowledge_id IN (?)", knowledgeIDs).Error; err != nil {
 			return err
 		}
 
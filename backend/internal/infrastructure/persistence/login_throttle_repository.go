@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type loginThrottleRepository struct {
+	db *Database
+}
+
+func NewLoginThrottleRepository(db *Database) repository.LoginThrottleRepository {
+	return &loginThrottleRepository{db}
+}
+
+// Find returns the throttle for the key, or nil if there were no recent failures
+func (r *loginThrottleRepository) Find(tenantID string, kind string, key string) (*model.LoginThrottle, error) {
+	var throttle model.LoginThrottle
+	err := r.db.First(&throttle, "tenant_id = ? AND kind = ? AND key = ?", tenantID, kind, key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// Increment counts a failure at throttle.LastFailedAt, creating the throttle
+// if needed, and sets throttle.Failures to the resulting count. Failures
+// older than the window are forgotten first. The count is updated in a single
+// statement so that concurrent failures are all counted.
+func (r *loginThrottleRepository) Increment(throttle *model.LoginThrottle, window time.Duration) error {
+	now := throttle.LastFailedAt
+	return r.db.Raw(`
+		INSERT INTO login_throttles (id, tenant_id, kind, key, failures, last_failed_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 1, ?, ?, ?)
+		ON CONFLICT (tenant_id, kind, key) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failed_at < ? THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failed_at = EXCLUDED.last_failed_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING failures`,
+		throttle.ID, throttle.TenantID, throttle.Kind, throttle.Key, now, now, now,
+		now.Add(-window),
+	).Scan(&throttle.Failures).Error
+}
+
+// Block sets the backoff and lockout of the throttle that are not nil. An
+// earlier block that lasts longer is kept.
+func (r *loginThrottleRepository) Block(throttle *model.LoginThrottle) error {
+	updates := map[string]interface{}{}
+	if throttle.BlockedUntil != nil {
+		updates["blocked_until"] = gorm.Expr("GREATEST(COALESCE(blocked_until, ?), ?)", *throttle.BlockedUntil, *throttle.BlockedUntil)
+	}
+	if throttle.LockedUntil != nil {
+		updates["locked_until"] = gorm.Expr("GREATEST(COALESCE(locked_until, ?), ?)", *throttle.LockedUntil, *throttle.LockedUntil)
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return r.db.Model(&model.LoginThrottle{}).
+		Where("tenant_id = ? AND kind = ? AND key = ?", throttle.TenantID, throttle.Kind, throttle.Key).
+		Updates(updates).Error
+}
+
+func (r *loginThrottleRepository) Delete(tenantID string, kind string, key string) error {
+	return r.db.Where("tenant_id = ? AND kind = ? AND key = ?", tenantID, kind, key).Delete(&model.LoginThrottle{}).Error
+}
@@ -0,0 +1,88 @@
+package persistence
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type membershipRepository struct {
+	db *Database
+}
+
+func NewMembershipRepository(db *Database) repository.MembershipRepository {
+	return &membershipRepository{db}
+}
+
+func (r *membershipRepository) Create(membership *model.Membership) error {
+	return r.db.Create(membership).Error
+}
+
+func (r *membershipRepository) FindByUserID(userID string, tenantID string) (*model.Membership, error) {
+	var membership model.Membership
+	err := r.db.First(&membership, "user_id = ? AND tenant_id = ?", userID, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// FindByAccountID returns the memberships of the account in every tenant.
+// It reads them through account_memberships, which row-level security doesn't
+// limit to the current tenant.
+func (r *membershipRepository) FindByAccountID(accountID string) ([]*model.Membership, error) {
+	var memberships []*model.Membership
+	err := r.db.
+		Raw("SELECT * FROM account_memberships(?) ORDER BY created_at ASC", accountID).
+		Scan(&memberships).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+// errInvitationUsed rolls back a join whose invitation was used concurrently
+var errInvitationUsed = errors.New("invitation is no longer pending")
+
+// Join creates the account of a user in the invitation's tenant with its
+// memberships and marks the invitation accepted, all in one transaction. It
+// reports false, saving nothing, when the invitation was no longer pending,
+// so that the same invitation cannot be used twice concurrently.
+func (r *membershipRepository) Join(account *model.User, memberships []*model.Membership, invitation *model.Invitation) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+		for _, membership := range memberships {
+			if err := tx.Create(membership).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&model.Invitation{}).
+			Where("id = ? AND status = ?", invitation.ID, model.InvitationStatusPending).
+			Updates(map[string]interface{}{
+				"status":      invitation.Status,
+				"accepted_at": invitation.AcceptedAt,
+				"updated_at":  invitation.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errInvitationUsed
+		}
+		return nil
+	})
+	if errors.Is(err, errInvitationUsed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,14 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_moderation_actions_tenant_id;
+DROP INDEX IF EXISTS idx_comment_reports_comment_id;
+DROP INDEX IF EXISTS idx_comment_reports_tenant_id_status;
+DROP INDEX IF EXISTS idx_comment_revisions_comment_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS moderation_actions;
+DROP TABLE IF EXISTS comment_reports;
+DROP TABLE IF EXISTS comment_revisions;
+
+-- Drop moderation columns
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden;
+ALTER TABLE knowledge DROP COLUMN IF EXISTS discussion_locked;
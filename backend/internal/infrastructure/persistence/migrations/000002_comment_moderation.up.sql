@@ -0,0 +1,43 @@
+-- Add moderation columns
+ALTER TABLE knowledge ADD COLUMN discussion_locked BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE comments ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create comment_revisions table
+CREATE TABLE comment_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    comment_id UUID NOT NULL REFERENCES comments(id),
+    editor_id UUID NOT NULL REFERENCES users(id),
+    content TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create comment_reports table
+CREATE TABLE comment_reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    comment_id UUID NOT NULL REFERENCES comments(id),
+    reporter_id UUID NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create moderation_actions table
+CREATE TABLE moderation_actions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    actor_id UUID NOT NULL REFERENCES users(id),
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    target_id UUID NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_comment_revisions_comment_id ON comment_revisions(comment_id);
+CREATE INDEX idx_comment_reports_tenant_id_status ON comment_reports(tenant_id, status);
+CREATE INDEX idx_comment_reports_comment_id ON comment_reports(comment_id);
+CREATE INDEX idx_moderation_actions_tenant_id ON moderation_actions(tenant_id);
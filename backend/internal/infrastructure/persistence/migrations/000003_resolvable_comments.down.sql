@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_comments_knowledge_id_resolved;
+
+-- Drop resolved state from comments
+ALTER TABLE comments DROP COLUMN IF EXISTS resolved_at;
+ALTER TABLE comments DROP COLUMN IF EXISTS resolved_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS resolved;
@@ -0,0 +1,7 @@
+-- Add resolved state to comments
+ALTER TABLE comments ADD COLUMN resolved BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE comments ADD COLUMN resolved_by UUID REFERENCES users(id);
+ALTER TABLE comments ADD COLUMN resolved_at TIMESTAMP WITH TIME ZONE;
+
+-- Create indexes
+CREATE INDEX idx_comments_knowledge_id_resolved ON comments(knowledge_id, resolved);
@@ -0,0 +1,6 @@
+-- Drop text range anchors from comments
+ALTER TABLE comments DROP COLUMN IF EXISTS outdated;
+ALTER TABLE comments DROP COLUMN IF EXISTS anchor_quote;
+ALTER TABLE comments DROP COLUMN IF EXISTS anchor_end;
+ALTER TABLE comments DROP COLUMN IF EXISTS anchor_start;
+ALTER TABLE comments DROP COLUMN IF EXISTS anchor_block;
@@ -0,0 +1,6 @@
+-- Add text range anchors to comments
+ALTER TABLE comments ADD COLUMN anchor_block VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN anchor_start INTEGER;
+ALTER TABLE comments ADD COLUMN anchor_end INTEGER;
+ALTER TABLE comments ADD COLUMN anchor_quote TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN outdated BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+-- Drop deactivation from users
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_at;
@@ -0,0 +1,2 @@
+-- Add deactivation to users
+ALTER TABLE users ADD COLUMN deactivated_at TIMESTAMP WITH TIME ZONE;
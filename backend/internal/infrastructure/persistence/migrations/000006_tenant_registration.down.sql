@@ -0,0 +1,3 @@
+-- Drop registration policy from tenants
+ALTER TABLE tenants DROP COLUMN IF EXISTS registration_allowed_domains;
+ALTER TABLE tenants DROP COLUMN IF EXISTS registration_mode;
@@ -0,0 +1,3 @@
+-- Add registration policy to tenants
+ALTER TABLE tenants ADD COLUMN registration_mode VARCHAR(50) NOT NULL DEFAULT 'closed';
+ALTER TABLE tenants ADD COLUMN registration_allowed_domains TEXT;
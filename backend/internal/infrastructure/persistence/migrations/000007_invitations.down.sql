@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_invitations_tenant_id_status;
+
+-- Drop tables
+DROP TABLE IF EXISTS invitations;
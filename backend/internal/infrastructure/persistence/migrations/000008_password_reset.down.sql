@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_password_reset_tokens_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS password_reset_tokens;
+
+-- Drop password change tracking from users
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_at;
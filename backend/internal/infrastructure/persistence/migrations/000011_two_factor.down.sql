@@ -0,0 +1,13 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_recovery_codes_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS recovery_codes;
+
+-- Drop two-factor requirement from tenants
+ALTER TABLE tenants DROP COLUMN IF EXISTS security_require_two_factor;
+
+-- Drop two-factor authentication from users
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_last_step;
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_secret;
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_enabled;
@@ -0,0 +1,20 @@
+-- Add two-factor authentication to users
+ALTER TABLE users ADD COLUMN two_factor_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN two_factor_secret VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN two_factor_last_step BIGINT NOT NULL DEFAULT 0;
+
+-- Add two-factor requirement to tenants
+ALTER TABLE tenants ADD COLUMN security_require_two_factor BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create recovery_codes table
+CREATE TABLE recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes(user_id);
@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_login_throttles_key;
+
+-- Drop tables
+DROP TABLE IF EXISTS login_throttles;
+
+-- Drop account lockout policy from tenants
+ALTER TABLE tenants DROP COLUMN IF EXISTS security_lockout_minutes;
+ALTER TABLE tenants DROP COLUMN IF EXISTS security_max_failed_logins;
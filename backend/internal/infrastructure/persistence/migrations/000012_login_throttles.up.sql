@@ -0,0 +1,20 @@
+-- Add account lockout policy to tenants
+ALTER TABLE tenants ADD COLUMN security_max_failed_logins INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tenants ADD COLUMN security_lockout_minutes INTEGER NOT NULL DEFAULT 0;
+
+-- Create login_throttles table
+CREATE TABLE login_throttles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    kind VARCHAR(20) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    blocked_until TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_login_throttles_key ON login_throttles(tenant_id, kind, key);
@@ -0,0 +1,15 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_password_histories_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS password_histories;
+
+-- Drop password policy from tenants
+ALTER TABLE tenants DROP COLUMN IF EXISTS password_history_size;
+ALTER TABLE tenants DROP COLUMN IF EXISTS password_disallow_common;
+ALTER TABLE tenants DROP COLUMN IF EXISTS password_disallow_personal_info;
+ALTER TABLE tenants DROP COLUMN IF EXISTS password_require_symbol;
+ALTER TABLE tenants DROP COLUMN IF EXISTS password_require_digit;
+ALTER TABLE tenants DROP COLUMN IF EXISTS password_require_lowercase;
+ALTER TABLE tenants DROP COLUMN IF EXISTS password_require_uppercase;
+ALTER TABLE tenants DROP COLUMN IF EXISTS password_min_length;
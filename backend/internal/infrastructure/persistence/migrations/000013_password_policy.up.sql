@@ -0,0 +1,21 @@
+-- Add password policy to tenants
+ALTER TABLE tenants ADD COLUMN password_min_length INTEGER NOT NULL DEFAULT 8;
+ALTER TABLE tenants ADD COLUMN password_require_uppercase BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenants ADD COLUMN password_require_lowercase BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenants ADD COLUMN password_require_digit BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenants ADD COLUMN password_require_symbol BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenants ADD COLUMN password_disallow_personal_info BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenants ADD COLUMN password_disallow_common BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenants ADD COLUMN password_history_size INTEGER NOT NULL DEFAULT 0;
+
+-- Create password_histories table
+CREATE TABLE password_histories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_password_histories_user_id ON password_histories(user_id, created_at);
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_api_tokens_user_id;
+DROP INDEX IF EXISTS idx_api_tokens_tenant_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS api_tokens;
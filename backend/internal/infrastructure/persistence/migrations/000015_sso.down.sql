@@ -0,0 +1,16 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_sso_states_expires_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS sso_states;
+
+-- Drop SSO configuration from tenants
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_disable_password_login;
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_default_role;
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_role_mapping;
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_role_claim;
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_allowed_domains;
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_client_secret;
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_client_id;
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_issuer;
+ALTER TABLE tenants DROP COLUMN IF EXISTS sso_enabled;
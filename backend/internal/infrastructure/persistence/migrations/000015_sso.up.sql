@@ -0,0 +1,24 @@
+-- Add SSO configuration to tenants
+ALTER TABLE tenants ADD COLUMN sso_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenants ADD COLUMN sso_issuer VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN sso_client_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN sso_client_secret VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN sso_allowed_domains TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE tenants ADD COLUMN sso_role_claim VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN sso_role_mapping TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE tenants ADD COLUMN sso_default_role VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN sso_disable_password_login BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create sso_states table
+CREATE TABLE sso_states (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    state_hash VARCHAR(64) NOT NULL UNIQUE,
+    nonce VARCHAR(255) NOT NULL,
+    code_verifier VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_sso_states_expires_at ON sso_states(expires_at);
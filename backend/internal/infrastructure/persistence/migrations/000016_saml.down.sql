@@ -0,0 +1,16 @@
+-- Restore sso_states defaults
+ALTER TABLE sso_states ALTER COLUMN code_verifier DROP DEFAULT;
+ALTER TABLE sso_states ALTER COLUMN nonce DROP DEFAULT;
+
+-- Drop SAML configuration from tenants
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_disable_password_login;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_default_role;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_role_mapping;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_role_attribute;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_allowed_domains;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_name_attribute;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_email_attribute;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_idp_certificate;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_idp_sso_url;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_idp_entity_id;
+ALTER TABLE tenants DROP COLUMN IF EXISTS saml_enabled;
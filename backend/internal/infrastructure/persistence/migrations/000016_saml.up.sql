@@ -0,0 +1,16 @@
+-- Add SAML configuration to tenants
+ALTER TABLE tenants ADD COLUMN saml_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenants ADD COLUMN saml_idp_entity_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN saml_idp_sso_url VARCHAR(1024) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN saml_idp_certificate TEXT NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN saml_email_attribute VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN saml_name_attribute VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN saml_allowed_domains TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE tenants ADD COLUMN saml_role_attribute VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN saml_role_mapping TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE tenants ADD COLUMN saml_default_role VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN saml_disable_password_login BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- SAML logins keep no nonce or PKCE verifier
+ALTER TABLE sso_states ALTER COLUMN nonce SET DEFAULT '';
+ALTER TABLE sso_states ALTER COLUMN code_verifier SET DEFAULT '';
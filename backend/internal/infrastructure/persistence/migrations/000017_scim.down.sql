@@ -0,0 +1,7 @@
+-- Drop groups
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
+
+-- Drop identity provider IDs from users
+DROP INDEX IF EXISTS idx_users_external_id;
+ALTER TABLE users DROP COLUMN IF EXISTS external_id;
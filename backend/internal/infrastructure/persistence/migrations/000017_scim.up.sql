@@ -0,0 +1,28 @@
+-- Add identity provider IDs to users
+ALTER TABLE users ADD COLUMN external_id VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Create groups table
+CREATE TABLE groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    name VARCHAR(255) NOT NULL,
+    external_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create group_members table
+CREATE TABLE group_members (
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, user_id)
+);
+
+-- Create indexes
+CREATE INDEX idx_users_external_id ON users(tenant_id, external_id) WHERE external_id <> '';
+CREATE UNIQUE INDEX idx_groups_tenant_name ON groups(tenant_id, LOWER(name));
+CREATE INDEX idx_groups_external_id ON groups(tenant_id, external_id) WHERE external_id <> '';
+CREATE INDEX idx_group_members_user_id ON group_members(user_id);
+
@@ -0,0 +1,2 @@
+-- Drop roles table
+DROP TABLE IF EXISTS roles;
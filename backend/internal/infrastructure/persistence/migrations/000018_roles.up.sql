@@ -0,0 +1,29 @@
+-- Create roles table
+CREATE TABLE roles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    name VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    permissions TEXT NOT NULL DEFAULT '[]',
+    system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_roles_tenant_name ON roles(tenant_id, name);
+
+-- Seed the default roles of existing tenants
+INSERT INTO roles (tenant_id, name, description, permissions, system)
+SELECT id, 'admin', 'Full access to the tenant',
+    '["knowledge.write","knowledge.publish","knowledge.manage","comments.write","comments.moderate","tags.create","tags.manage","users.invite","users.manage","tenant.manage"]', TRUE
+FROM tenants;
+
+INSERT INTO roles (tenant_id, name, description, permissions, system)
+SELECT id, 'editor', 'Writes and publishes knowledge and comments',
+    '["knowledge.write","knowledge.publish","comments.write","tags.create"]', TRUE
+FROM tenants;
+
+INSERT INTO roles (tenant_id, name, description, permissions, system)
+SELECT id, 'viewer', 'Reads knowledge', '[]', TRUE
+FROM tenants;
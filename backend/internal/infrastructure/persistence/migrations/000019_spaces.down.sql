@@ -0,0 +1,12 @@
+-- Revoke the spaces permission
+UPDATE roles SET permissions = REPLACE(permissions, ',"spaces.manage"', '');
+UPDATE roles SET permissions = REPLACE(permissions, '"spaces.manage",', '');
+UPDATE roles SET permissions = REPLACE(permissions, '"spaces.manage"', '');
+
+-- Drop spaces from knowledge
+DROP INDEX IF EXISTS idx_knowledge_space_id;
+ALTER TABLE knowledge DROP COLUMN IF EXISTS space_id;
+
+-- Drop spaces
+DROP TABLE IF EXISTS space_default_tags;
+DROP TABLE IF EXISTS spaces;
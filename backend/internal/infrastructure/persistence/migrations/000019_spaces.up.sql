@@ -0,0 +1,30 @@
+-- Create spaces table
+CREATE TABLE spaces (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    icon VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create space_default_tags table
+CREATE TABLE space_default_tags (
+    space_id UUID NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (space_id, tag_id)
+);
+
+-- Add spaces to knowledge
+ALTER TABLE knowledge ADD COLUMN space_id UUID REFERENCES spaces(id);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_spaces_tenant_slug ON spaces(tenant_id, slug);
+CREATE INDEX idx_knowledge_space_id ON knowledge(space_id);
+
+-- Grant the new permission to the admin roles, which have every permission
+UPDATE roles
+SET permissions = '["knowledge.write","knowledge.publish","knowledge.manage","comments.write","comments.moderate","tags.create","tags.manage","spaces.manage","users.invite","users.manage","tenant.manage"]'
+WHERE system AND name = 'admin';
@@ -0,0 +1,6 @@
+-- Drop access entries
+DROP TABLE IF EXISTS access_entries;
+
+-- Drop private flags
+ALTER TABLE knowledge DROP COLUMN IF EXISTS private;
+ALTER TABLE spaces DROP COLUMN IF EXISTS private;
@@ -0,0 +1,20 @@
+-- Add private flags
+ALTER TABLE spaces ADD COLUMN private BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE knowledge ADD COLUMN private BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create access_entries table
+CREATE TABLE access_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id UUID NOT NULL,
+    principal_type VARCHAR(50) NOT NULL,
+    principal_id UUID NOT NULL,
+    level VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_access_entries_resource_principal ON access_entries(resource_type, resource_id, principal_type, principal_id);
+CREATE INDEX idx_access_entries_principal ON access_entries(principal_type, principal_id);
+CREATE INDEX idx_access_entries_tenant_id ON access_entries(tenant_id);
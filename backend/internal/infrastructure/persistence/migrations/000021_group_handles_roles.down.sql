@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_groups_tenant_handle;
+
+-- Drop handles and roles of groups
+ALTER TABLE groups DROP COLUMN IF EXISTS role;
+ALTER TABLE groups DROP COLUMN IF EXISTS handle;
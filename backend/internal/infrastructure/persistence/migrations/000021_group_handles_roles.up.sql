@@ -0,0 +1,17 @@
+-- Add handles used to mention groups and roles granted to their members
+ALTER TABLE groups ADD COLUMN handle VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE groups ADD COLUMN role VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Derive handles of existing groups from their names
+UPDATE groups SET handle = COALESCE(NULLIF(TRIM(BOTH '-' FROM regexp_replace(LOWER(name), '[^a-z0-9]+', '-', 'g')), ''), 'group');
+
+-- Make handles unique in each tenant
+UPDATE groups SET handle = numbered.handle || '-' || numbered.position
+FROM (
+    SELECT id, handle, ROW_NUMBER() OVER (PARTITION BY tenant_id, handle ORDER BY created_at, id) AS position
+    FROM groups
+) AS numbered
+WHERE groups.id = numbered.id AND numbered.position > 1;
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_groups_tenant_handle ON groups(tenant_id, handle);
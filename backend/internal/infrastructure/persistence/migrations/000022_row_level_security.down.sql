@@ -0,0 +1,60 @@
+-- Drop policies
+DROP POLICY IF EXISTS tenant_isolation ON tenants;
+DROP POLICY IF EXISTS tenant_isolation ON users;
+DROP POLICY IF EXISTS tenant_isolation ON knowledge;
+DROP POLICY IF EXISTS tenant_isolation ON tags;
+DROP POLICY IF EXISTS tenant_isolation ON knowledge_tags;
+DROP POLICY IF EXISTS tenant_isolation ON comments;
+DROP POLICY IF EXISTS tenant_isolation ON comment_revisions;
+DROP POLICY IF EXISTS tenant_isolation ON comment_reports;
+DROP POLICY IF EXISTS tenant_isolation ON moderation_actions;
+DROP POLICY IF EXISTS tenant_isolation ON invitations;
+DROP POLICY IF EXISTS tenant_isolation ON password_reset_tokens;
+DROP POLICY IF EXISTS tenant_isolation ON sessions;
+DROP POLICY IF EXISTS tenant_isolation ON refresh_tokens;
+DROP POLICY IF EXISTS tenant_isolation ON recovery_codes;
+DROP POLICY IF EXISTS tenant_isolation ON login_throttles;
+DROP POLICY IF EXISTS tenant_isolation ON password_histories;
+DROP POLICY IF EXISTS tenant_isolation ON api_tokens;
+DROP POLICY IF EXISTS tenant_isolation ON sso_states;
+DROP POLICY IF EXISTS tenant_isolation ON groups;
+DROP POLICY IF EXISTS tenant_isolation ON group_members;
+DROP POLICY IF EXISTS tenant_isolation ON roles;
+DROP POLICY IF EXISTS tenant_isolation ON spaces;
+DROP POLICY IF EXISTS tenant_isolation ON space_default_tags;
+DROP POLICY IF EXISTS tenant_isolation ON access_entries;
+
+-- Disable row-level security
+ALTER TABLE tenants DISABLE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+ALTER TABLE knowledge DISABLE ROW LEVEL SECURITY;
+ALTER TABLE tags DISABLE ROW LEVEL SECURITY;
+ALTER TABLE knowledge_tags DISABLE ROW LEVEL SECURITY;
+ALTER TABLE comments DISABLE ROW LEVEL SECURITY;
+ALTER TABLE comment_revisions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE comment_reports DISABLE ROW LEVEL SECURITY;
+ALTER TABLE moderation_actions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations DISABLE ROW LEVEL SECURITY;
+ALTER TABLE password_reset_tokens DISABLE ROW LEVEL SECURITY;
+ALTER TABLE sessions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE refresh_tokens DISABLE ROW LEVEL SECURITY;
+ALTER TABLE recovery_codes DISABLE ROW LEVEL SECURITY;
+ALTER TABLE login_throttles DISABLE ROW LEVEL SECURITY;
+ALTER TABLE password_histories DISABLE ROW LEVEL SECURITY;
+ALTER TABLE api_tokens DISABLE ROW LEVEL SECURITY;
+ALTER TABLE sso_states DISABLE ROW LEVEL SECURITY;
+ALTER TABLE groups DISABLE ROW LEVEL SECURITY;
+ALTER TABLE group_members DISABLE ROW LEVEL SECURITY;
+ALTER TABLE roles DISABLE ROW LEVEL SECURITY;
+ALTER TABLE spaces DISABLE ROW LEVEL SECURITY;
+ALTER TABLE space_default_tags DISABLE ROW LEVEL SECURITY;
+ALTER TABLE access_entries DISABLE ROW LEVEL SECURITY;
+
+-- Drop the tenant function and role
+DROP FUNCTION IF EXISTS app_current_tenant();
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE USAGE, SELECT ON SEQUENCES FROM knowledge_hub_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM knowledge_hub_tenant;
+REVOKE ALL ON ALL SEQUENCES IN SCHEMA public FROM knowledge_hub_tenant;
+REVOKE ALL ON ALL TABLES IN SCHEMA public FROM knowledge_hub_tenant;
+REVOKE USAGE ON SCHEMA public FROM knowledge_hub_tenant;
+DROP ROLE IF EXISTS knowledge_hub_tenant;
@@ -0,0 +1,82 @@
+-- Create the role row-level security applies to. Requests switch to it with
+-- SET LOCAL ROLE, so the user running the migrations must be a member of it.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'knowledge_hub_tenant') THEN
+        CREATE ROLE knowledge_hub_tenant NOLOGIN;
+    END IF;
+END
+$$;
+GRANT knowledge_hub_tenant TO CURRENT_USER;
+GRANT USAGE ON SCHEMA public TO knowledge_hub_tenant;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO knowledge_hub_tenant;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO knowledge_hub_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO knowledge_hub_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO knowledge_hub_tenant;
+
+-- Tenant of the current transaction, NULL when it isn't limited to one
+CREATE OR REPLACE FUNCTION app_current_tenant() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')::UUID
+$$ LANGUAGE SQL STABLE;
+
+-- Enable row-level security. The tables' owner isn't subject to it, so
+-- background jobs and lookups across tenants (e.g. of API tokens) still work.
+ALTER TABLE tenants ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE knowledge ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE knowledge_tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE comment_revisions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE comment_reports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE moderation_actions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE password_reset_tokens ENABLE ROW LEVEL SECURITY;
+ALTER TABLE sessions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE refresh_tokens ENABLE ROW LEVEL SECURITY;
+ALTER TABLE recovery_codes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE login_throttles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE password_histories ENABLE ROW LEVEL SECURITY;
+ALTER TABLE api_tokens ENABLE ROW LEVEL SECURITY;
+ALTER TABLE sso_states ENABLE ROW LEVEL SECURITY;
+ALTER TABLE groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE group_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE roles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE spaces ENABLE ROW LEVEL SECURITY;
+ALTER TABLE space_default_tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE access_entries ENABLE ROW LEVEL SECURITY;
+
+-- Create policies
+CREATE POLICY tenant_isolation ON tenants USING (id = app_current_tenant());
+CREATE POLICY tenant_isolation ON users USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON knowledge USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON tags USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON comments USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON comment_revisions USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON comment_reports USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON moderation_actions USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON invitations USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON password_reset_tokens USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON sessions USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON refresh_tokens USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON recovery_codes USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON login_throttles USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON password_histories USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON api_tokens USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON sso_states USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON groups USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON group_members USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON roles USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON spaces USING (tenant_id = app_current_tenant());
+CREATE POLICY tenant_isolation ON access_entries USING (tenant_id = app_current_tenant());
+
+-- Join tables have no tenant, their rows belong to the tenant of both sides,
+-- which the policies of the joined tables already limit
+CREATE POLICY tenant_isolation ON knowledge_tags USING (
+    EXISTS (SELECT 1 FROM knowledge WHERE knowledge.id = knowledge_tags.knowledge_id)
+    AND EXISTS (SELECT 1 FROM tags WHERE tags.id = knowledge_tags.tag_id)
+);
+CREATE POLICY tenant_isolation ON space_default_tags USING (
+    EXISTS (SELECT 1 FROM spaces WHERE spaces.id = space_default_tags.space_id)
+    AND EXISTS (SELECT 1 FROM tags WHERE tags.id = space_default_tags.tag_id)
+);
@@ -0,0 +1,2 @@
+-- Drop memberships table
+DROP TABLE IF EXISTS memberships;
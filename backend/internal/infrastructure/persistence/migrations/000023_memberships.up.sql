@@ -0,0 +1,20 @@
+-- Allow an email address to have an account in several tenants
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_users_email;
+
+-- Create memberships table
+CREATE TABLE memberships (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_memberships_user_id ON memberships(user_id);
+CREATE UNIQUE INDEX idx_memberships_account_tenant ON memberships(account_id, tenant_id);
+CREATE INDEX idx_memberships_tenant_id ON memberships(tenant_id);
+
+-- Limit memberships to the tenant of the request like other tenant tables
+ALTER TABLE memberships ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON memberships USING (tenant_id = app_current_tenant());
@@ -0,0 +1,5 @@
+-- Drop tenant_deletions table
+DROP TABLE IF EXISTS tenant_deletions;
+
+-- Drop disabled_at from tenants
+ALTER TABLE tenants DROP COLUMN IF EXISTS disabled_at;
@@ -0,0 +1,28 @@
+-- Add disabled_at to tenants
+ALTER TABLE tenants ADD COLUMN disabled_at TIMESTAMP WITH TIME ZONE;
+
+-- Create tenant_deletions table. It has no foreign key to tenants, as it is
+-- kept after the tenant is purged, and no row-level security, as the purge
+-- worker reads it across tenants. Requests only read the deletions of their
+-- own tenant by its ID.
+CREATE TABLE tenant_deletions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    tenant_name VARCHAR(255) NOT NULL DEFAULT '',
+    requested_by UUID NOT NULL,
+    notify_email VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(50) NOT NULL,
+    purge_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    canceled_at TIMESTAMP WITH TIME ZONE,
+    error TEXT NOT NULL DEFAULT '',
+    report TEXT NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_tenant_deletions_tenant_id ON tenant_deletions(tenant_id);
+CREATE INDEX idx_tenant_deletions_status_purge_after ON tenant_deletions(status, purge_after);
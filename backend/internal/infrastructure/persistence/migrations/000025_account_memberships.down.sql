@@ -0,0 +1,2 @@
+-- Drop account_memberships function
+DROP FUNCTION IF EXISTS account_memberships(UUID);
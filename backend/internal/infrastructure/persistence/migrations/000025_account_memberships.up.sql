@@ -0,0 +1,10 @@
+-- Memberships of an account in every tenant. Listing and switching tenants
+-- need them while row-level security limits memberships to the current
+-- tenant, so the function runs as its owner, which the policy doesn't apply
+-- to. The account is the one of the user's membership in the current tenant.
+CREATE OR REPLACE FUNCTION account_memberships(account UUID) RETURNS SETOF memberships AS $$
+    SELECT * FROM memberships WHERE account_id = account
+$$ LANGUAGE SQL STABLE SECURITY DEFINER SET search_path = public;
+
+REVOKE ALL ON FUNCTION account_memberships(UUID) FROM PUBLIC;
+GRANT EXECUTE ON FUNCTION account_memberships(UUID) TO knowledge_hub_tenant;
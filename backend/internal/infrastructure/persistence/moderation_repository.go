@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type moderationRepository struct {
+	db *Database
+}
+
+func NewModerationRepository(db *Database) repository.ModerationRepository {
+	return &moderationRepository{db}
+}
+
+func (r *moderationRepository) CreateReport(report *model.CommentReport) error {
+	return r.db.Create(report).Error
+}
+
+func (r *moderationRepository) FindReportByID(id string, tenantID string) (*model.CommentReport, error) {
+	var report model.CommentReport
+	err := r.db.
+		Preload("Comment").
+		First(&report, "id = ? AND tenant_id = ?", id, tenantID).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *moderationRepository) FindOpenReports(tenantID string) ([]*model.CommentReport, error) {
+	var reports []*model.CommentReport
+	err := r.db.
+		Preload("Comment").
+		Where("tenant_id = ? AND status = ?", tenantID, model.ReportStatusOpen).
+		Order("created_at ASC").
+		Find(&reports).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *moderationRepository) UpdateReport(report *model.CommentReport) error {
+	return r.db.Omit("Comment").Save(report).Error
+}
+
+func (r *moderationRepository) ResolveReportsForComment(commentID string, tenantID string) error {
+	return r.db.Model(&model.CommentReport{}).
+		Where("comment_id = ? AND tenant_id = ? AND status = ?", commentID, tenantID, model.ReportStatusOpen).
+		Updates(map[string]interface{}{
+			"status":     model.ReportStatusResolved,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *moderationRepository) CreateAction(action *model.ModerationAction) error {
+	return r.db.Create(action).Error
+}
+
+func (r *moderationRepository) FindActions(tenantID string) ([]*model.ModerationAction, error) {
+	var actions []*model.ModerationAction
+	err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").
+		Find(&actions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
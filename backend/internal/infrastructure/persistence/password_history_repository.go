@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type passwordHistoryRepository struct {
+	db *Database
+}
+
+func NewPasswordHistoryRepository(db *Database) repository.PasswordHistoryRepository {
+	return &passwordHistoryRepository{db}
+}
+
+func (r *passwordHistoryRepository) Create(entry *model.PasswordHistory) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *passwordHistoryRepository) FindRecentByUserID(userID string, tenantID string, limit int) ([]*model.PasswordHistory, error) {
+	var entries []*model.PasswordHistory
+	err := r.db.
+		Where("user_id = ? AND tenant_id = ?", userID, tenantID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Prune deletes all but the most recent entries of the user
+func (r *passwordHistoryRepository) Prune(userID string, tenantID string, keep int) error {
+	if keep <= 0 {
+		return r.db.Where("user_id = ? AND tenant_id = ?", userID, tenantID).Delete(&model.PasswordHistory{}).Error
+	}
+
+	recent := r.db.Model(&model.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ? AND tenant_id = ?", userID, tenantID).
+		Order("created_at DESC").
+		Limit(keep)
+	return r.db.
+		Where("user_id = ? AND tenant_id = ? AND id NOT IN (?)", userID, tenantID, recent).
+		Delete(&model.PasswordHistory{}).
+		Error
+}
@@ -0,0 +1,35 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type passwordResetRepository struct {
+	db *Database
+}
+
+func NewPasswordResetRepository(db *Database) repository.PasswordResetRepository {
+	return &passwordResetRepository{db}
+}
+
+func (r *passwordResetRepository) Create(token *model.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *passwordResetRepository) FindByTokenHash(tokenHash string) (*model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	err := r.db.First(&token, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *passwordResetRepository) InvalidateForUser(userID string, tenantID string) error {
+	return r.db.Model(&model.PasswordResetToken{}).
+		Where("user_id = ? AND tenant_id = ? AND used_at IS NULL", userID, tenantID).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type recoveryCodeRepository struct {
+	db *Database
+}
+
+func NewRecoveryCodeRepository(db *Database) repository.RecoveryCodeRepository {
+	return &recoveryCodeRepository{db}
+}
+
+func (r *recoveryCodeRepository) ReplaceForUser(userID string, tenantID string, codes []*model.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND tenant_id = ?", userID, tenantID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(codes).Error
+	})
+}
+
+func (r *recoveryCodeRepository) FindByHash(userID string, tenantID string, codeHash string) (*model.RecoveryCode, error) {
+	var code model.RecoveryCode
+	err := r.db.First(&code, "user_id = ? AND tenant_id = ? AND code_hash = ?", userID, tenantID, codeHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// MarkUsed marks the code as used. It reports false when the code had already
+// been used, so that the same code cannot be redeemed twice concurrently.
+func (r *recoveryCodeRepository) MarkUsed(code *model.RecoveryCode) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", code.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	code.UsedAt = &now
+	return true, nil
+}
+
+func (r *recoveryCodeRepository) CountUnused(userID string, tenantID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND tenant_id = ? AND used_at IS NULL", userID, tenantID).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *recoveryCodeRepository) DeleteForUser(userID string, tenantID string) error {
+	return r.db.Where("user_id = ? AND tenant_id = ?", userID, tenantID).Delete(&model.RecoveryCode{}).Error
+}
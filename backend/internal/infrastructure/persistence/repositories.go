@@ -7,22 +7,52 @@ import (
 )
 
 type Repositories struct {
-	db        *gorm.DB
-	tenant    repository.TenantRepository
-	user      repository.UserRepository
-	knowledge repository.KnowledgeRepository
-	tag       repository.TagRepository
-	comment   repository.CommentRepository
+	db              *gorm.DB
+	tenant          repository.TenantRepository
+	user            repository.UserRepository
+	knowledge       repository.KnowledgeRepository
+	tag             repository.TagRepository
+	comment         repository.CommentRepository
+	moderation      repository.ModerationRepository
+	invitation      repository.InvitationRepository
+	passwordReset   repository.PasswordResetRepository
+	session         repository.SessionRepository
+	recoveryCode    repository.RecoveryCodeRepository
+	loginThrottle   repository.LoginThrottleRepository
+	passwordHistory repository.PasswordHistoryRepository
+	apiToken        repository.APITokenRepository
+	ssoState        repository.SSOStateRepository
+	group           repository.GroupRepository
+	role            repository.RoleRepository
+	space           repository.SpaceRepository
+	access          repository.AccessRepository
+	membership      repository.MembershipRepository
+	tenantDeletion  repository.TenantDeletionRepository
 }
 
 func NewRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		db:        db,
-		tenant:    NewTenantRepository(&Database{db}),
-		user:      NewUserRepository(&Database{db}),
-		knowledge: NewKnowledgeRepository(&Database{db}),
-		tag:       NewTagRepository(&Database{db}),
-		comment:   NewCommentRepository(&Database{db}),
+		db:              db,
+		tenant:          NewTenantRepository(&Database{db}),
+		user:            NewUserRepository(&Database{db}),
+		knowledge:       NewKnowledgeRepository(&Database{db}),
+		tag:             NewTagRepository(&Database{db}),
+		comment:         NewCommentRepository(&Database{db}),
+		moderation:      NewModerationRepository(&Database{db}),
+		invitation:      NewInvitationRepository(&Database{db}),
+		passwordReset:   NewPasswordResetRepository(&Database{db}),
+		session:         NewSessionRepository(&Database{db}),
+		recoveryCode:    NewRecoveryCodeRepository(&Database{db}),
+		loginThrottle:   NewLoginThrottleRepository(&Database{db}),
+		passwordHistory: NewPasswordHistoryRepository(&Database{db}),
+		apiToken:        NewAPITokenRepository(&Database{db}),
+		ssoState:        NewSSOStateRepository(&Database{db}),
+		group:           NewGroupRepository(&Database{db}),
+		role:            NewRoleRepository(&Database{db}),
+		space:           NewSpaceRepository(&Database{db}),
+		access:          NewAccessRepository(&Database{db}),
+		membership:      NewMembershipRepository(&Database{db}),
+		tenantDeletion:  NewTenantDeletionRepository(&Database{db}),
 	}
 }
 
@@ -46,6 +76,66 @@ func (r *Repositories) Comment() repository.CommentRepository {
 	return r.comment
 }
 
+func (r *Repositories) Moderation() repository.ModerationRepository {
+	return r.moderation
+}
+
+func (r *Repositories) Invitation() repository.InvitationRepository {
+	return r.invitation
+}
+
+func (r *Repositories) PasswordReset() repository.PasswordResetRepository {
+	return r.passwordReset
+}
+
+func (r *Repositories) Session() repository.SessionRepository {
+	return r.session
+}
+
+func (r *Repositories) RecoveryCode() repository.RecoveryCodeRepository {
+	return r.recoveryCode
+}
+
+func (r *Repositories) LoginThrottle() repository.LoginThrottleRepository {
+	return r.loginThrottle
+}
+
+func (r *Repositories) PasswordHistory() repository.PasswordHistoryRepository {
+	return r.passwordHistory
+}
+
+func (r *Repositories) APIToken() repository.APITokenRepository {
+	return r.apiToken
+}
+
+func (r *Repositories) SSOState() repository.SSOStateRepository {
+	return r.ssoState
+}
+
+func (r *Repositories) Group() repository.GroupRepository {
+	return r.group
+}
+
+func (r *Repositories) Role() repository.RoleRepository {
+	return r.role
+}
+
+func (r *Repositories) Space() repository.SpaceRepository {
+	return r.space
+}
+
+func (r *Repositories) Access() repository.AccessRepository {
+	return r.access
+}
+
+func (r *Repositories) Membership() repository.MembershipRepository {
+	return r.membership
+}
+
+func (r *Repositories) TenantDeletion() repository.TenantDeletionRepository {
+	return r.tenantDeletion
+}
+
 func (r *Repositories) DB() *gorm.DB {
 	return r.db
 }
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type roleRepository struct {
+	db *Database
+}
+
+func NewRoleRepository(db *Database) repository.RoleRepository {
+	return &roleRepository{db}
+}
+
+func (r *roleRepository) Create(role *model.TenantRole) error {
+	return r.db.Create(role).Error
+}
+
+func (r *roleRepository) FindByID(id string, tenantID string) (*model.TenantRole, error) {
+	var role model.TenantRole
+	err := r.db.First(&role, "id = ? AND tenant_id = ?", id, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) FindByName(name string, tenantID string) (*model.TenantRole, error) {
+	var role model.TenantRole
+	err := r.db.First(&role, "name = ? AND tenant_id = ?", name, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) FindAll(tenantID string) ([]*model.TenantRole, error) {
+	var roles []*model.TenantRole
+	err := r.db.Where("tenant_id = ?", tenantID).Order("system DESC, name ASC").Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *roleRepository) Update(role *model.TenantRole) error {
+	return r.db.Save(role).Error
+}
+
+func (r *roleRepository) Delete(id string, tenantID string) error {
+	return r.db.Delete(&model.TenantRole{}, "id = ? AND tenant_id = ?", id, tenantID).Error
+}
+
+func (r *roleRepository) CountUsers(name string, tenantID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.User{}).Where("role = ? AND tenant_id = ?", name, tenantID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *roleRepository) CountGroups(name string, tenantID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Group{}).Where("role = ? AND tenant_id = ?", name, tenantID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
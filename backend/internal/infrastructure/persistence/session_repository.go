@@ -0,0 +1,162 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type sessionRepository struct {
+	db *Database
+}
+
+func NewSessionRepository(db *Database) repository.SessionRepository {
+	return &sessionRepository{db}
+}
+
+func (r *sessionRepository) Create(session *model.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *sessionRepository) FindByID(id string, tenantID string) (*model.Session, error) {
+	var session model.Session
+	err := r.db.First(&session, "id = ? AND tenant_id = ?", id, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) FindActiveByUserID(userID string, tenantID string) ([]*model.Session, error) {
+	var sessions []*model.Session
+	err := r.db.
+		Where("user_id = ? AND tenant_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, tenantID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *sessionRepository) Update(session *model.Session) error {
+	return r.db.Save(session).Error
+}
+
+// Touch records that the session was just used, without rewriting the rest of the row
+func (r *sessionRepository) Touch(session *model.Session, ipAddress string) error {
+	now := time.Now()
+	err := r.db.Model(&model.Session{}).
+		Where("id = ?", session.ID).
+		Updates(map[string]interface{}{
+			"last_seen_at": now,
+			"ip_address":   ipAddress,
+		}).Error
+	if err != nil {
+		return err
+	}
+	session.LastSeenAt = now
+	session.IPAddress = ipAddress
+	return nil
+}
+
+// Extend moves the expiry of an active session. It reports false when the
+// session was revoked in the meantime, so that a refresh racing a revocation
+// can't bring the session back.
+func (r *sessionRepository) Extend(session *model.Session, expiresAt time.Time, ipAddress string) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", session.ID).
+		Updates(map[string]interface{}{
+			"expires_at":   expiresAt,
+			"last_seen_at": now,
+			"ip_address":   ipAddress,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	session.ExpiresAt = expiresAt
+	session.LastSeenAt = now
+	session.IPAddress = ipAddress
+	session.UpdatedAt = now
+	return true, nil
+}
+
+// Revoke ends the session, keeping the time of an earlier revocation
+func (r *sessionRepository) Revoke(session *model.Session) error {
+	now := time.Now()
+	err := r.db.Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", session.ID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}).Error
+	if err != nil {
+		return err
+	}
+	if session.RevokedAt == nil {
+		session.RevokedAt = &now
+		session.UpdatedAt = now
+	}
+	return nil
+}
+
+func (r *sessionRepository) RevokeAllForUser(userID string, tenantID string) error {
+	now := time.Now()
+	return r.db.Model(&model.Session{}).
+		Where("user_id = ? AND tenant_id = ? AND revoked_at IS NULL", userID, tenantID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}).Error
+}
+
+// RevokeAllForTenant revokes the sessions of every user of the tenant, except
+// the given one when exceptSessionID is set
+func (r *sessionRepository) RevokeAllForTenant(tenantID string, exceptSessionID string) error {
+	now := time.Now()
+	db := r.db.Model(&model.Session{}).Where("tenant_id = ? AND revoked_at IS NULL", tenantID)
+	if exceptSessionID != "" {
+		db = db.Where("id <> ?", exceptSessionID)
+	}
+	return db.Updates(map[string]interface{}{
+		"revoked_at": now,
+		"updated_at": now,
+	}).Error
+}
+
+func (r *sessionRepository) CreateRefreshToken(token *model.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *sessionRepository) FindRefreshTokenByHash(tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	err := r.db.First(&token, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed marks the token as used. It reports false when the token
+// had already been used, so that concurrent refreshes cannot both succeed.
+func (r *sessionRepository) MarkRefreshTokenUsed(token *model.RefreshToken) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	token.UsedAt = &now
+	return true, nil
+}
@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type spaceRepository struct {
+	db *Database
+}
+
+func NewSpaceRepository(db *Database) repository.SpaceRepository {
+	return &spaceRepository{db}
+}
+
+func (r *spaceRepository) Create(space *model.Space) error {
+	return r.db.Create(space).Error
+}
+
+func (r *spaceRepository) FindByID(id string, tenantID string, viewerID string) (*model.Space, error) {
+	var space model.Space
+	err := visibleSpaces(r.db.DB, viewerID).Preload("DefaultTags").First(&space, "id = ? AND tenant_id = ?", id, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &space, nil
+}
+
+func (r *spaceRepository) FindBySlug(slug string, tenantID string, viewerID string) (*model.Space, error) {
+	var space model.Space
+	err := visibleSpaces(r.db.DB, viewerID).Preload("DefaultTags").First(&space, "slug = ? AND tenant_id = ?", slug, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &space, nil
+}
+
+func (r *spaceRepository) FindAll(tenantID string, viewerID string) ([]*model.Space, error) {
+	var spaces []*model.Space
+	err := visibleSpaces(r.db.DB, viewerID).Preload("DefaultTags").Where("tenant_id = ?", tenantID).Order("name ASC").Find(&spaces).Error
+	if err != nil {
+		return nil, err
+	}
+	return spaces, nil
+}
+
+func (r *spaceRepository) Update(space *model.Space) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Update default tags
+		if err := tx.Model(space).Association("DefaultTags").Replace(space.DefaultTags); err != nil {
+			return err
+		}
+
+		// Update space
+		return tx.Omit("DefaultTags").Save(space).Error
+	})
+}
+
+func (r *spaceRepository) Delete(id string, tenantID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Delete access entries
+		if err := deleteAccessOfResource(tx, model.AccessResourceSpace, id, tenantID); err != nil {
+			return err
+		}
+
+		// Delete space_default_tags associations, only of the tenant's space
+		spaceIDs := tx.Model(&model.Space{}).Select("id").Where("id = ? AND tenant_id = ?", id, tenantID)
+		if err := tx.Exec("DELETE FROM space_default_tags WHERE space_id IN (?)", spaceIDs).Error; err != nil {
+			return err
+		}
+
+		// Delete space
+		return tx.Delete(&model.Space{}, "id = ? AND tenant_id = ?", id, tenantID).Error
+	})
+}
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type ssoStateRepository struct {
+	db *Database
+}
+
+func NewSSOStateRepository(db *Database) repository.SSOStateRepository {
+	return &ssoStateRepository{db}
+}
+
+// Create stores the state and clears out expired ones left by abandoned sign-ins
+func (r *ssoStateRepository) Create(state *model.SSOState) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&model.SSOState{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(state).Error
+	})
+}
+
+// Consume deletes and returns the state. It returns nil when the state does
+// not exist or was consumed concurrently, so each state is used at most once.
+func (r *ssoStateRepository) Consume(stateHash string) (*model.SSOState, error) {
+	var state model.SSOState
+	err := r.db.First(&state, "state_hash = ?", stateHash).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := r.db.Where("id = ?", state.ID).Delete(&model.SSOState{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &state, nil
+}
@@ -43,11 +43,16 @@ func (r *tagRepository) Update(tag *model.Tag) error {
 
 func (r *tagRepository) Delete(id string, tenantID string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Get the tag to delete
-		tag := &model.Tag{ID: id}
+		// Get the tag to delete, only if it belongs to the tenant
+		tagIDs := tx.Model(&model.Tag{}).Select("id").Where("id = ? AND tenant_id = ?", id, tenantID)
 
 		// Remove associations with knowledge
-		if err := tx.Model(tag).Association("Knowledge").Clear(); err != nil {
+		if err := tx.Exec("DELETE FROM knowledge_tags WHERE tag_id IN (?)", tagIDs).Error; err != nil {
+			return err
+		}
+
+		// Remove it from the default tags of spaces
+		if err := tx.Exec("DELETE FROM space_default_tags WHERE tag_id IN (?)", tagIDs).Error; err != nil {
 			return err
 		}
 
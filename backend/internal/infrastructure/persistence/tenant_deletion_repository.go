@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type tenantDeletionRepository struct {
+	db *Database
+}
+
+func NewTenantDeletionRepository(db *Database) repository.TenantDeletionRepository {
+	return &tenantDeletionRepository{db}
+}
+
+func (r *tenantDeletionRepository) Create(deletion *model.TenantDeletion) error {
+	return r.db.Create(deletion).Error
+}
+
+func (r *tenantDeletionRepository) FindByID(id string) (*model.TenantDeletion, error) {
+	var deletion model.TenantDeletion
+	err := r.db.First(&deletion, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+func (r *tenantDeletionRepository) FindLatestByTenantID(tenantID string) (*model.TenantDeletion, error) {
+	var deletion model.TenantDeletion
+	err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").
+		First(&deletion).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+// FindDue returns the deletions whose grace period has passed and whose data
+// is still to be purged, including failed ones so that they are retried
+func (r *tenantDeletionRepository) FindDue(now time.Time) ([]*model.TenantDeletion, error) {
+	var deletions []*model.TenantDeletion
+	err := r.db.
+		Where("status IN ? AND purge_after <= ?", []string{
+			model.TenantDeletionStatusScheduled,
+			model.TenantDeletionStatusPurging,
+			model.TenantDeletionStatusFailed,
+		}, now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Order("purge_after ASC").
+		Find(&deletions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return deletions, nil
+}
+
+// Claim leases the deletion to the caller until lockedUntil and marks it as
+// purging. It reports false when the deletion was canceled or is leased to
+// another worker, so that a tenant is only purged by one worker at a time.
+func (r *tenantDeletionRepository) Claim(deletion *model.TenantDeletion, lockedUntil time.Time) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.TenantDeletion{}).
+		Where("id = ? AND status <> ? AND status <> ?", deletion.ID, model.TenantDeletionStatusCanceled, model.TenantDeletionStatusCompleted).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Updates(map[string]interface{}{
+			"status":       model.TenantDeletionStatusPurging,
+			"locked_until": lockedUntil,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	deletion.Status = model.TenantDeletionStatusPurging
+	deletion.LockedUntil = &lockedUntil
+	return true, nil
+}
+
+// Cancel marks the deletion as canceled. It reports false when the deletion is
+// no longer scheduled, so that a deletion can't be canceled once purging began.
+func (r *tenantDeletionRepository) Cancel(deletion *model.TenantDeletion) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.TenantDeletion{}).
+		Where("id = ? AND status = ?", deletion.ID, model.TenantDeletionStatusScheduled).
+		Updates(map[string]interface{}{
+			"status":      model.TenantDeletionStatusCanceled,
+			"canceled_at": now,
+			"updated_at":  now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	deletion.Status = model.TenantDeletionStatusCanceled
+	deletion.CanceledAt = &now
+	return true, nil
+}
+
+func (r *tenantDeletionRepository) Update(deletion *model.TenantDeletion) error {
+	return r.db.Save(deletion).Error
+}
@@ -44,20 +44,75 @@ func (r *tenantRepository) Update(tenant *model.Tenant) error {
 func (r *tenantRepository) Delete(id string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Delete related records first
-		if err := tx.Where("tenant_id = ?", id).Delete(&model.User{}).Error; err != nil {
-			return err
-		}
-		if err := tx.Where("tenant_id = ?", id).Delete(&model.Knowledge{}).Error; err != nil {
-			return err
-		}
-		if err := tx.Where("tenant_id = ?", id).Delete(&model.Tag{}).Error; err != nil {
-			return err
-		}
-		if err := tx.Where("tenant_id = ?", id).Delete(&model.Comment{}).Error; err != nil {
-			return err
+		for _, step := range tenantPurgeSteps {
+			if err := tx.Exec("DELETE FROM "+step.table+" WHERE "+step.condition, purgeArgs(id, 0)).Error; err != nil {
+				return err
+			}
 		}
 
 		// Delete tenant
 		return tx.Delete(&model.Tenant{}, "id = ?", id).Error
 	})
 }
+
+// PurgeBatch deletes up to limit rows of the tenant's data from the first
+// table that still has any, children before the tables they reference. It
+// returns an empty table name once all data but the tenant itself is gone.
+func (r *tenantRepository) PurgeBatch(id string, limit int) (string, int64, error) {
+	for _, step := range tenantPurgeSteps {
+		result := r.db.Exec(
+			"DELETE FROM "+step.table+" WHERE ctid IN (SELECT ctid FROM "+step.table+" WHERE "+step.condition+" LIMIT @limit)",
+			purgeArgs(id, limit),
+		)
+		if result.Error != nil {
+			return step.table, 0, result.Error
+		}
+		if result.RowsAffected > 0 {
+			return step.table, result.RowsAffected, nil
+		}
+	}
+	return "", 0, nil
+}
+
+// tenantPurgeStep selects the rows of a table that belong to the tenant
+type tenantPurgeStep struct {
+	table     string
+	condition string
+}
+
+// tenantPurgeSteps lists the tables holding tenant data in the order they
+// can be emptied without violating foreign keys
+var tenantPurgeSteps = []tenantPurgeStep{
+	{"knowledge_tags", "knowledge_id IN (SELECT id FROM knowledge WHERE tenant_id = @tenant)"},
+	{"space_default_tags", "space_id IN (SELECT id FROM spaces WHERE tenant_id = @tenant)"},
+	{"access_entries", "tenant_id = @tenant"},
+	{"comment_revisions", "tenant_id = @tenant"},
+	{"comment_reports", "tenant_id = @tenant"},
+	{"moderation_actions", "tenant_id = @tenant"},
+	{"comments", "tenant_id = @tenant"},
+	{"knowledge", "tenant_id = @tenant"},
+	{"spaces", "tenant_id = @tenant"},
+	{"tags", "tenant_id = @tenant"},
+	{"group_members", "tenant_id = @tenant"},
+	{"groups", "tenant_id = @tenant"},
+	{"refresh_tokens", "tenant_id = @tenant"},
+	{"sessions", "tenant_id = @tenant"},
+	{"recovery_codes", "tenant_id = @tenant"},
+	{"password_histories", "tenant_id = @tenant"},
+	{"password_reset_tokens", "tenant_id = @tenant"},
+	{"login_throttles", "tenant_id = @tenant"},
+	{"api_tokens", "tenant_id = @tenant"},
+	{"sso_states", "tenant_id = @tenant"},
+	{"invitations", "tenant_id = @tenant"},
+	{"memberships", "tenant_id = @tenant"},
+	{"roles", "tenant_id = @tenant"},
+	{"users", "tenant_id = @tenant"},
+}
+
+// purgeArgs returns the named arguments of the purge statements
+func purgeArgs(tenantID string, limit int) map[string]interface{} {
+	return map[string]interface{}{
+		"tenant": tenantID,
+		"limit":  limit,
+	}
+}
@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"gorm.io/gorm"
+)
+
+// tenantRole is the database role row-level security applies to. Its
+// policies only let it see and change the rows of the tenant named by the
+// app.tenant_id setting, and no rows at all when the setting is missing.
+const tenantRole = "knowledge_hub_tenant"
+
+// WithTenant runs fc with repositories limited by row-level security to the
+// rows of the tenant, so that a query missing its tenant condition can't read
+// or change other tenants' data. The repositories share a transaction, which
+// is committed if fc returns nil and rolled back otherwise.
+func (r *Repositories) WithTenant(tenantID string, fc func(scoped *Repositories) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := scopeToTenant(tx, tenantID); err != nil {
+			return err
+		}
+		return fc(NewRepositories(tx))
+	})
+}
+
+// scopeToTenant switches the transaction to the role row-level security
+// applies to and sets the tenant its policies compare rows with. Both only
+// last until the end of the transaction.
+func scopeToTenant(tx *gorm.DB, tenantID string) error {
+	if err := tx.Exec("SET LOCAL ROLE " + tenantRole).Error; err != nil {
+		return err
+	}
+	return tx.Exec("SELECT set_config('app.tenant_id', ?, true)", tenantID).Error
+}
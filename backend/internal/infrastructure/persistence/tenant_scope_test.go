@@ -0,0 +1,155 @@
+package persistence_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/persistence"
+)
+
+// openTestDatabase connects to the database named by TEST_DATABASE_URL as the
+// user which ran the migrations, i.e. the tables' owner, and skips the test
+// when it isn't set
+func openTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set to a migrated database")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	return db
+}
+
+// tenantFixture is a tenant with one tag, created by the tables' owner
+type tenantFixture struct {
+	tenant *model.Tenant
+	tag    *model.Tag
+}
+
+func createTenantFixture(t *testing.T, db *gorm.DB, name string) tenantFixture {
+	t.Helper()
+	id := uuid.New().String()
+	f := tenantFixture{
+		tenant: &model.Tenant{ID: id, Name: name, Domain: "rls-" + id},
+		tag:    &model.Tag{ID: uuid.New().String(), Name: name + "-tag", TenantID: id},
+	}
+	repos := persistence.NewRepositories(db)
+	if err := repos.Tenant().Create(f.tenant); err != nil {
+		t.Fatalf("failed to create tenant: %v", err)
+	}
+	if err := repos.Tag().Create(f.tag); err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM tags WHERE tenant_id = ?", id)
+		db.Exec("DELETE FROM tenants WHERE id = ?", id)
+	})
+	return f
+}
+
+func TestWithTenantReadsOnlyTheTenant(t *testing.T) {
+	db := openTestDatabase(t)
+	own := createTenantFixture(t, db, "own")
+	other := createTenantFixture(t, db, "other")
+
+	err := persistence.NewRepositories(db).WithTenant(own.tenant.ID, func(scoped *persistence.Repositories) error {
+		if _, err := scoped.Tenant().FindByID(own.tenant.ID); err != nil {
+			t.Errorf("own tenant: %v", err)
+		}
+		if _, err := scoped.Tag().FindByID(own.tag.ID, own.tenant.ID); err != nil {
+			t.Errorf("own tag: %v", err)
+		}
+
+		// The queries name the other tenant, which only row-level security stops
+		if _, err := scoped.Tenant().FindByID(other.tenant.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("other tenant: got error %v, want record not found", err)
+		}
+		if _, err := scoped.Tag().FindByID(other.tag.ID, other.tenant.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("other tag: got error %v, want record not found", err)
+		}
+		tags, err := scoped.Tag().FindAll(other.tenant.ID)
+		if err != nil {
+			t.Errorf("other tags: %v", err)
+		}
+		if len(tags) != 0 {
+			t.Errorf("other tags: got %d tags, want none", len(tags))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTenant: %v", err)
+	}
+}
+
+func TestWithTenantWritesOnlyTheTenant(t *testing.T) {
+	db := openTestDatabase(t)
+	own := createTenantFixture(t, db, "own")
+	other := createTenantFixture(t, db, "other")
+
+	tests := []struct {
+		name  string
+		write func(scoped *persistence.Repositories) error
+	}{
+		{
+			name: "create in the other tenant",
+			write: func(scoped *persistence.Repositories) error {
+				return scoped.Tag().Create(&model.Tag{ID: uuid.New().String(), Name: "injected", TenantID: other.tenant.ID})
+			},
+		},
+		{
+			name: "update a row of the other tenant",
+			write: func(scoped *persistence.Repositories) error {
+				tag := *other.tag
+				tag.Name = "renamed"
+				return scoped.Tag().Update(&tag)
+			},
+		},
+		{
+			name: "move a row to the other tenant",
+			write: func(scoped *persistence.Repositories) error {
+				tag := *own.tag
+				tag.TenantID = other.tenant.ID
+				return scoped.Tag().Update(&tag)
+			},
+		},
+		{
+			name: "delete a row of the other tenant",
+			write: func(scoped *persistence.Repositories) error {
+				return scoped.Tag().Delete(other.tag.ID, other.tenant.ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Failing writes roll the transaction back, succeeding ones must not have changed anything
+			_ = persistence.NewRepositories(db).WithTenant(own.tenant.ID, tt.write)
+
+			var tags []model.Tag
+			if err := db.Where("tenant_id = ?", other.tenant.ID).Find(&tags).Error; err != nil {
+				t.Fatalf("failed to read tags: %v", err)
+			}
+			if len(tags) != 1 || tags[0].ID != other.tag.ID || tags[0].Name != other.tag.Name {
+				t.Errorf("tags of the other tenant changed: %+v", tags)
+			}
+
+			var ownTag model.Tag
+			if err := db.First(&ownTag, "id = ?", own.tag.ID).Error; err != nil {
+				t.Fatalf("failed to read own tag: %v", err)
+			}
+			if ownTag.TenantID != own.tenant.ID {
+				t.Errorf("own tag moved to tenant %s", ownTag.TenantID)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package persistence
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
@@ -19,6 +23,40 @@ func (r *userRepository) Create(user *model.User) error {
 	return r.db.Create(user).Error
 }
 
+// CreateInvited creates the user and marks their invitation accepted in one
+// transaction. It reports false, saving nothing, when the invitation was no
+// longer pending, so that the same invitation cannot be used twice
+// concurrently.
+func (r *userRepository) CreateInvited(user *model.User, invitation *model.Invitation) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&model.Invitation{}).
+			Where("id = ? AND status = ?", invitation.ID, model.InvitationStatusPending).
+			Updates(map[string]interface{}{
+				"status":      invitation.Status,
+				"accepted_at": invitation.AcceptedAt,
+				"updated_at":  invitation.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errInvitationUsed
+		}
+		return nil
+	})
+	if errors.Is(err, errInvitationUsed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *userRepository) FindByID(id string, tenantID string) (*model.User, error) {
 	var user model.User
 	err := r.db.First(&user, "id = ? AND tenant_id = ?", id, tenantID).Error
@@ -37,10 +75,78 @@ func (r *userRepository) FindByEmail(email string, tenantID string) (*model.User
 	return &user, nil
 }
 
+func (r *userRepository) FindByExternalID(externalID string, tenantID string) (*model.User, error) {
+	var user model.User
+	err := r.db.First(&user, "external_id = ? AND tenant_id = ?", externalID, tenantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Search(query string, tenantID string, offset int, limit int) ([]*model.User, int64, error) {
+	db := r.db.DB.Model(&model.User{}).Where("tenant_id = ?", tenantID)
+
+	// Add search conditions
+	if query != "" {
+		searchQuery := "%" + strings.ToLower(query) + "%"
+		db = db.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ?", searchQuery, searchQuery)
+	}
+
+	// Count all matches before paginating
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []*model.User
+	err := db.
+		Order("name ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&users).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (r *userRepository) Count(tenantID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.User{}).Where("tenant_id = ?", tenantID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (r *userRepository) Update(user *model.User) error {
 	return r.db.Save(user).Error
 }
 
+// UseTwoFactorStep records the time step of an accepted TOTP code. It reports
+// false when the step or a later one was already used, so that concurrent
+// requests can't both accept the same code.
+func (r *userRepository) UseTwoFactorStep(user *model.User, step int64) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.User{}).
+		Where("id = ? AND tenant_id = ? AND two_factor_last_step < ?", user.ID, user.TenantID, step).
+		Updates(map[string]interface{}{
+			"two_factor_last_step": step,
+			"updated_at":           now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	user.TwoFactorLastStep = step
+	user.UpdatedAt = now
+	return true, nil
+}
+
 func (r *userRepository) Delete(id string, tenantID string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Update related records to set author_id to null
@@ -56,6 +162,50 @@ func (r *userRepository) Delete(id string, tenantID string) error {
 			return err
 		}
 
+		// Delete password reset tokens
+		if err := tx.Where("user_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+
+		// Delete sessions and their refresh tokens
+		if err := tx.Where("session_id IN (?)", tx.Model(&model.Session{}).Select("id").Where("user_id = ? AND tenant_id = ?", id, tenantID)).
+			Delete(&model.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.Session{}).Error; err != nil {
+			return err
+		}
+
+		// Delete recovery codes
+		if err := tx.Where("user_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		// Delete password history
+		if err := tx.Where("user_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.PasswordHistory{}).Error; err != nil {
+			return err
+		}
+
+		// Delete API tokens acting as the user
+		if err := tx.Where("user_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.APIToken{}).Error; err != nil {
+			return err
+		}
+
+		// Delete group memberships
+		if err := tx.Where("user_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.GroupMember{}).Error; err != nil {
+			return err
+		}
+
+		// Delete access granted to the user
+		if err := deleteAccessOfPrincipal(tx, model.PrincipalUser, id, tenantID); err != nil {
+			return err
+		}
+
+		// Delete the membership of the account
+		if err := tx.Where("user_id = ? AND tenant_id = ?", id, tenantID).Delete(&model.Membership{}).Error; err != nil {
+			return err
+		}
+
 		// Delete user
 		return tx.Delete(&model.User{}, "id = ? AND tenant_id = ?", id, tenantID).Error
 	})
@@ -0,0 +1,355 @@
+// Package saml implements a SAML 2.0 service provider for web browser SSO:
+// metadata, redirect-bound authentication requests and validation of signed
+// responses posted to the assertion consumer service. It only uses the
+// standard library.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SAML namespaces and identifiers
+const (
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	bindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	nameIDFormatEmail   = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	statusSuccess       = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	confirmationBearer  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	maxClockSkew        = 3 * time.Minute
+	maxResponseDataSize = 1 << 20
+)
+
+// ErrInvalidResponse is returned when a SAML response fails validation
+var ErrInvalidResponse = errors.New("invalid SAML response")
+
+// ServiceProvider describes this application to an identity provider
+type ServiceProvider struct {
+	EntityID string
+	ACSURL   string // Assertion consumer service receiving responses
+}
+
+// IdentityProvider is the configured identity provider of a tenant
+type IdentityProvider struct {
+	EntityID    string
+	SSOURL      string // Single sign-on service using the HTTP-Redirect binding
+	Certificate *x509.Certificate
+}
+
+// Assertion contains the validated statements about the signed-in user
+type Assertion struct {
+	NameID       string
+	InResponseTo string              // ID of the authentication request
+	Attributes   map[string][]string // By name and by friendly name
+}
+
+// ParseCertificate parses a PEM certificate, or the bare base64 of one as
+// found in identity provider metadata
+func ParseCertificate(data string) (*x509.Certificate, error) {
+	if block, _ := pem.Decode([]byte(data)); block != nil {
+		return x509.ParseCertificate(block.Bytes)
+	}
+	der, err := decodeBase64(data)
+	if err != nil {
+		return nil, errors.New("certificate is neither PEM nor base64")
+	}
+	return x509.ParseCertificate(der)
+}
+
+// Metadata returns the service provider metadata to register with identity providers
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	type assertionConsumerService struct {
+		Binding  string `xml:"Binding,attr"`
+		Location string `xml:"Location,attr"`
+		Index    int    `xml:"index,attr"`
+	}
+	type spSSODescriptor struct {
+		AuthnRequestsSigned        bool                     `xml:"AuthnRequestsSigned,attr"`
+		WantAssertionsSigned       bool                     `xml:"WantAssertionsSigned,attr"`
+		ProtocolSupportEnumeration string                   `xml:"protocolSupportEnumeration,attr"`
+		NameIDFormat               string                   `xml:"md:NameIDFormat"`
+		AssertionConsumerService   assertionConsumerService `xml:"md:AssertionConsumerService"`
+	}
+	type entityDescriptor struct {
+		XMLName         xml.Name        `xml:"md:EntityDescriptor"`
+		Namespace       string          `xml:"xmlns:md,attr"`
+		EntityID        string          `xml:"entityID,attr"`
+		SPSSODescriptor spSSODescriptor `xml:"md:SPSSODescriptor"`
+	}
+
+	metadata, err := xml.MarshalIndent(entityDescriptor{
+		Namespace: namespaceMetadata,
+		EntityID:  sp.EntityID,
+		SPSSODescriptor: spSSODescriptor{
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: namespaceProtocol,
+			NameIDFormat:               nameIDFormatEmail,
+			AssertionConsumerService: assertionConsumerService{
+				Binding:  bindingHTTPPost,
+				Location: sp.ACSURL,
+				Index:    0,
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), metadata...), nil
+}
+
+// NewRequestID returns a random ID for an authentication request
+func NewRequestID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// IDs must not start with a digit
+	return "_" + hex.EncodeToString(b), nil
+}
+
+// AuthnRequestURL returns the identity provider URL that signs the user in,
+// carrying an authentication request with the HTTP-Redirect binding
+func (sp *ServiceProvider) AuthnRequestURL(idp *IdentityProvider, requestID string, relayState string, now time.Time) (string, error) {
+	request := `<samlp:AuthnRequest xmlns:samlp="` + namespaceProtocol + `" xmlns:saml="` + namespaceAssertion + `"` +
+		` ID="` + escapeAttr(requestID) + `" Version="2.0" IssueInstant="` + now.UTC().Format(time.RFC3339) + `"` +
+		` Destination="` + escapeAttr(idp.SSOURL) + `" AssertionConsumerServiceURL="` + escapeAttr(sp.ACSURL) + `"` +
+		` ProtocolBinding="` + bindingHTTPPost + `">` +
+		`<saml:Issuer>` + escapeText(sp.EntityID) + `</saml:Issuer>` +
+		`<samlp:NameIDPolicy Format="` + nameIDFormatEmail + `" AllowCreate="true"></samlp:NameIDPolicy>` +
+		`</samlp:AuthnRequest>`
+
+	var compressed bytes.Buffer
+	writer, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write([]byte(request)); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("SAMLRequest", base64.StdEncoding.EncodeToString(compressed.Bytes()))
+	if relayState != "" {
+		params.Set("RelayState", relayState)
+	}
+
+	separator := "?"
+	if strings.Contains(idp.SSOURL, "?") {
+		separator = "&"
+	}
+	return idp.SSOURL + separator + params.Encode(), nil
+}
+
+// ParseResponse validates a base64 encoded response posted to the assertion
+// consumer service and returns its assertion. The response or the assertion
+// must be signed by the identity provider's certificate.
+func (sp *ServiceProvider) ParseResponse(idp *IdentityProvider, samlResponse string, now time.Time) (*Assertion, error) {
+	data, err := decodeBase64(samlResponse)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed encoding", ErrInvalidResponse)
+	}
+	if len(data) > maxResponseDataSize {
+		return nil, fmt.Errorf("%w: response too large", ErrInvalidResponse)
+	}
+
+	root, err := parseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if !root.is(namespaceProtocol, "Response") {
+		return nil, fmt.Errorf("%w: not a response", ErrInvalidResponse)
+	}
+
+	// Check the response is meant for us and succeeded
+	if destination := root.attr("Destination"); destination != "" && destination != sp.ACSURL {
+		return nil, fmt.Errorf("%w: unexpected destination %q", ErrInvalidResponse, destination)
+	}
+	status := root.child(namespaceProtocol, "Status")
+	if status == nil {
+		return nil, fmt.Errorf("%w: missing status", ErrInvalidResponse)
+	}
+	statusCode := status.child(namespaceProtocol, "StatusCode")
+	if statusCode == nil || statusCode.attr("Value") != statusSuccess {
+		return nil, fmt.Errorf("%w: identity provider reported failure", ErrInvalidResponse)
+	}
+	if issuer := root.child(namespaceAssertion, "Issuer"); issuer != nil && strings.TrimSpace(issuer.text()) != idp.EntityID {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidResponse)
+	}
+
+	if root.child(namespaceAssertion, "EncryptedAssertion") != nil {
+		return nil, fmt.Errorf("%w: encrypted assertions are not supported", ErrInvalidResponse)
+	}
+	assertions := root.childElements(namespaceAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, fmt.Errorf("%w: expected one assertion, found %d", ErrInvalidResponse, len(assertions))
+	}
+	assertionElement := assertions[0]
+
+	// Verify signatures. A signed response covers its assertion.
+	responseSigned := hasSignature(root)
+	if responseSigned {
+		if err := verifySignature(root, root, idp.Certificate); err != nil {
+			return nil, err
+		}
+	}
+	if hasSignature(assertionElement) {
+		if err := verifySignature(root, assertionElement, idp.Certificate); err != nil {
+			return nil, err
+		}
+	} else if !responseSigned {
+		return nil, fmt.Errorf("%w: neither the response nor the assertion is signed", ErrInvalidSignature)
+	}
+
+	// Read the assertion from its canonical form, which is exactly what was signed
+	var parsed assertionXML
+	if err := xml.Unmarshal(canonicalize(assertionElement, assertionElement.child(namespaceDSig, "Signature"), nil), &parsed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	return sp.validateAssertion(idp, &parsed, now)
+}
+
+// assertionXML is the part of an assertion used for signing in
+type assertionXML struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+	Issuer  string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Subject struct {
+		NameID               string `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+		SubjectConfirmations []struct {
+			Method string `xml:"Method,attr"`
+			Data   struct {
+				InResponseTo string `xml:"InResponseTo,attr"`
+				Recipient    string `xml:"Recipient,attr"`
+				NotOnOrAfter string `xml:"NotOnOrAfter,attr"`
+			} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmationData"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmation"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject"`
+	Conditions *struct {
+		NotBefore            string `xml:"NotBefore,attr"`
+		NotOnOrAfter         string `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Audience"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AudienceRestriction"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Conditions"`
+	AttributeStatements []struct {
+		Attributes []struct {
+			Name         string   `xml:"Name,attr"`
+			FriendlyName string   `xml:"FriendlyName,attr"`
+			Values       []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeValue"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Attribute"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement"`
+}
+
+// validateAssertion checks the issuer, audience, validity period and bearer
+// confirmation of an assertion
+func (sp *ServiceProvider) validateAssertion(idp *IdentityProvider, a *assertionXML, now time.Time) (*Assertion, error) {
+	if strings.TrimSpace(a.Issuer) != idp.EntityID {
+		return nil, fmt.Errorf("%w: unexpected assertion issuer", ErrInvalidResponse)
+	}
+
+	// Conditions
+	if a.Conditions == nil {
+		return nil, fmt.Errorf("%w: missing conditions", ErrInvalidResponse)
+	}
+	if err := checkTime(a.Conditions.NotBefore, now.Add(maxClockSkew), true); err != nil {
+		return nil, err
+	}
+	if err := checkTime(a.Conditions.NotOnOrAfter, now.Add(-maxClockSkew), false); err != nil {
+		return nil, err
+	}
+	if len(a.Conditions.AudienceRestrictions) == 0 {
+		return nil, fmt.Errorf("%w: missing audience restriction", ErrInvalidResponse)
+	}
+	for _, restriction := range a.Conditions.AudienceRestrictions {
+		found := false
+		for _, audience := range restriction.Audiences {
+			if strings.TrimSpace(audience) == sp.EntityID {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: assertion is meant for another audience", ErrInvalidResponse)
+		}
+	}
+
+	// Bearer subject confirmation
+	var inResponseTo string
+	confirmed := false
+	for _, confirmation := range a.Subject.SubjectConfirmations {
+		if confirmation.Method != confirmationBearer || confirmation.Data.Recipient != sp.ACSURL {
+			continue
+		}
+		if confirmation.Data.NotOnOrAfter == "" {
+			continue
+		}
+		if err := checkTime(confirmation.Data.NotOnOrAfter, now.Add(-maxClockSkew), false); err != nil {
+			continue
+		}
+		inResponseTo = confirmation.Data.InResponseTo
+		confirmed = true
+		break
+	}
+	if !confirmed {
+		return nil, fmt.Errorf("%w: missing valid bearer confirmation", ErrInvalidResponse)
+	}
+
+	nameID := strings.TrimSpace(a.Subject.NameID)
+	if nameID == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidResponse)
+	}
+
+	attributes := make(map[string][]string)
+	for _, statement := range a.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			values := make([]string, 0, len(attribute.Values))
+			for _, value := range attribute.Values {
+				values = append(values, strings.TrimSpace(value))
+			}
+			attributes[attribute.Name] = append(attributes[attribute.Name], values...)
+			if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+				attributes[attribute.FriendlyName] = append(attributes[attribute.FriendlyName], values...)
+			}
+		}
+	}
+
+	return &Assertion{
+		NameID:       nameID,
+		InResponseTo: inResponseTo,
+		Attributes:   attributes,
+	}, nil
+}
+
+// checkTime checks an optional timestamp. With notBefore the time must not be
+// after the limit, otherwise it must be after the limit.
+func checkTime(value string, limit time.Time, notBefore bool) error {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return fmt.Errorf("%w: malformed time %q", ErrInvalidResponse, value)
+	}
+	if notBefore && t.After(limit) {
+		return fmt.Errorf("%w: assertion is not yet valid", ErrInvalidResponse)
+	}
+	if !notBefore && !t.After(limit) {
+		return fmt.Errorf("%w: assertion has expired", ErrInvalidResponse)
+	}
+	return nil
+}
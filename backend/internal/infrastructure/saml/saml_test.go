@@ -0,0 +1,354 @@
+package saml_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/saml"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/saml/samltest"
+)
+
+const (
+	testIdPEntityID = "https://idp.example.com/metadata"
+	testRequestID   = "_4f2a9c1e7b3d5a6f8e0c2b4d6f8a0c2e4b6d8f0a"
+)
+
+var testSP = &saml.ServiceProvider{
+	EntityID: "https://hub.example.com/api/auth/saml/acme/metadata",
+	ACSURL:   "https://hub.example.com/api/auth/saml/acme/acs",
+}
+
+var testNow = time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC)
+
+var (
+	testIdPOnce sync.Once
+	testIdP     *samltest.IdP
+	testIdPErr  error
+)
+
+// newTestIdP returns the mock identity provider, whose key is generated once
+func newTestIdP(t *testing.T) (*samltest.IdP, *saml.IdentityProvider) {
+	t.Helper()
+	testIdPOnce.Do(func() {
+		testIdP, testIdPErr = samltest.NewIdP(testIdPEntityID, "https://idp.example.com/sso")
+	})
+	if testIdPErr != nil {
+		t.Fatal(testIdPErr)
+	}
+
+	certificate, err := saml.ParseCertificate(testIdP.CertificatePEM())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testIdP, &saml.IdentityProvider{
+		EntityID:    testIdP.EntityID,
+		SSOURL:      testIdP.SSOURL,
+		Certificate: certificate,
+	}
+}
+
+// responseOptions returns the options of a valid response to testSP
+func responseOptions() samltest.ResponseOptions {
+	return samltest.ResponseOptions{
+		ACSURL:       testSP.ACSURL,
+		Audience:     testSP.EntityID,
+		InResponseTo: testRequestID,
+		NameID:       "alice@example.com",
+		Attributes:   map[string][]string{"displayName": {"Alice Example"}, "groups": {"engineering", "admins"}},
+		Now:          testNow,
+	}
+}
+
+// signedResponse returns a signed response as XML
+func signedResponse(t *testing.T, idp *samltest.IdP, opts samltest.ResponseOptions) string {
+	t.Helper()
+	document, err := idp.ResponseXML(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(document)
+}
+
+// parse validates a response given as XML
+func parse(idp *saml.IdentityProvider, document string, now time.Time) (*saml.Assertion, error) {
+	return testSP.ParseResponse(idp, base64.StdEncoding.EncodeToString([]byte(document)), now)
+}
+
+// assertionOf returns the assertion element of a response as written by samltest
+func assertionOf(t *testing.T, document string) string {
+	t.Helper()
+	start := strings.Index(document, "<saml:Assertion ")
+	end := strings.Index(document, "</saml:Assertion>")
+	if start < 0 || end < 0 {
+		t.Fatal("response has no assertion")
+	}
+	return document[start : end+len("</saml:Assertion>")]
+}
+
+var signaturePattern = regexp.MustCompile(`<ds:Signature .*?</ds:Signature>`)
+
+func TestParseResponse(t *testing.T) {
+	mock, idp := newTestIdP(t)
+
+	for _, signResponse := range []bool{false, true} {
+		name := "signed assertion"
+		if signResponse {
+			name = "signed response"
+		}
+		t.Run(name, func(t *testing.T) {
+			opts := responseOptions()
+			opts.SignResponse = signResponse
+
+			assertion, err := parse(idp, signedResponse(t, mock, opts), testNow.Add(time.Minute))
+			if err != nil {
+				t.Fatalf("ParseResponse: %v", err)
+			}
+			if assertion.NameID != "alice@example.com" {
+				t.Errorf("NameID = %q", assertion.NameID)
+			}
+			if assertion.InResponseTo != testRequestID {
+				t.Errorf("InResponseTo = %q", assertion.InResponseTo)
+			}
+			if got := strings.Join(assertion.Attributes["groups"], ","); got != "engineering,admins" {
+				t.Errorf("groups = %q", got)
+			}
+		})
+	}
+}
+
+// The fixture was not produced by the code under test. It is shaped like the
+// responses of Microsoft Entra ID: default namespaces instead of prefixes,
+// empty element tags and attribute order as written rather than canonical.
+// It was signed with a throwaway key whose certificate is idp_cert.pem, using
+// Python's xml.etree.ElementTree.canonicalize for canonicalization and the
+// openssl command for the RSA-SHA256 signature.
+func TestParseResponseFixture(t *testing.T) {
+	document, err := os.ReadFile("testdata/idp_response.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificatePEM, err := os.ReadFile("testdata/idp_cert.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := saml.ParseCertificate(string(certificatePEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &saml.IdentityProvider{
+		EntityID:    "https://sts.example.net/5f1c2a40-7d8e-4b1a-9c3e-2f6d8a0b4c71/",
+		Certificate: certificate,
+	}
+	issued := time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	assertion, err := parse(idp, string(document), issued.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ParseResponse: %v", err)
+	}
+	if assertion.NameID != "alice@acme.example.com" {
+		t.Errorf("NameID = %q", assertion.NameID)
+	}
+	if assertion.InResponseTo != "_8f3b2c1d4e5f60718293a4b5c6d7e8f901234567" {
+		t.Errorf("InResponseTo = %q", assertion.InResponseTo)
+	}
+	claims := "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/"
+	if got := strings.Join(assertion.Attributes[claims+"name"], ","); got != "Alice Example & Co" {
+		t.Errorf("name = %q", got)
+	}
+	if got := strings.Join(assertion.Attributes["http://schemas.microsoft.com/ws/2008/06/identity/claims/groups"], ","); got != "engineering,knowledge-admins" {
+		t.Errorf("groups = %q", got)
+	}
+
+	// The same response no longer verifies once changed
+	tampered := strings.Replace(string(document), ">alice@acme.example.com</NameID>", ">mallory@acme.example.com</NameID>", 1)
+	if _, err := parse(idp, tampered, issued.Add(time.Minute)); !errors.Is(err, saml.ErrInvalidSignature) {
+		t.Errorf("tampered fixture: err = %v, want ErrInvalidSignature", err)
+	}
+
+	// It has expired an hour after it was issued
+	if _, err := parse(idp, string(document), issued.Add(2*time.Hour)); !errors.Is(err, saml.ErrInvalidResponse) {
+		t.Errorf("expired fixture: err = %v, want ErrInvalidResponse", err)
+	}
+}
+
+func TestParseResponseTampered(t *testing.T) {
+	mock, idp := newTestIdP(t)
+
+	tests := []struct {
+		name         string
+		signResponse bool
+		tamper       func(document string) string
+	}{
+		{"subject", false, func(d string) string {
+			return strings.Replace(d, ">alice@example.com<", ">mallory@example.com<", 1)
+		}},
+		{"attribute value", false, func(d string) string {
+			return strings.Replace(d, ">engineering<", ">finance<", 1)
+		}},
+		{"added attribute", false, func(d string) string {
+			return strings.Replace(d, "</saml:AttributeStatement>", `<saml:Attribute Name="role"><saml:AttributeValue>admin</saml:AttributeValue></saml:Attribute></saml:AttributeStatement>`, 1)
+		}},
+		{"signature value", false, func(d string) string {
+			return strings.Replace(d, "<ds:SignatureValue>", "<ds:SignatureValue>AAAA", 1)
+		}},
+		{"digest value", false, func(d string) string {
+			return strings.Replace(d, "<ds:DigestValue>", "<ds:DigestValue>AAAA", 1)
+		}},
+		{"assertion of a signed response", true, func(d string) string {
+			return strings.Replace(d, ">alice@example.com<", ">mallory@example.com<", 1)
+		}},
+		{"request ID of a signed response", true, func(d string) string {
+			return strings.Replace(d, `InResponseTo="`+testRequestID+`" IssueInstant`, `InResponseTo="_other" IssueInstant`, 1)
+		}},
+		{"signature removed", false, func(d string) string {
+			return signaturePattern.ReplaceAllString(d, "")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := responseOptions()
+			opts.SignResponse = tt.signResponse
+			document := signedResponse(t, mock, opts)
+
+			tampered := tt.tamper(document)
+			if tampered == document {
+				t.Fatal("tamper did not change the response")
+			}
+			if _, err := parse(idp, tampered, testNow); !errors.Is(err, saml.ErrInvalidSignature) {
+				t.Errorf("err = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
+
+func TestParseResponseSignatureWrapping(t *testing.T) {
+	mock, idp := newTestIdP(t)
+	document := signedResponse(t, mock, responseOptions())
+	original := assertionOf(t, document)
+
+	// evil is the original assertion for another subject, still carrying the original signature
+	evil := strings.Replace(original, ">alice@example.com<", ">mallory@example.com<", 1)
+	evilID := strings.Replace(evil, `ID="_`, `ID="_evil`, 1)
+	evilUnsigned := signaturePattern.ReplaceAllString(evilID, "")
+
+	// hide moves the signed assertion where the service provider doesn't look for assertions
+	hide := func(assertion string) string {
+		return strings.Replace(document, original, "<samlp:Extensions>"+original+"</samlp:Extensions>"+assertion, 1)
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		want     error
+	}{
+		{"second assertion", strings.Replace(document, original, original+evilUnsigned, 1), saml.ErrInvalidResponse},
+		{"second assertion first", strings.Replace(document, original, evilUnsigned+original, 1), saml.ErrInvalidResponse},
+		{"signed assertion hidden, copy with the same ID", hide(evil), saml.ErrInvalidSignature},
+		{"signed assertion hidden, signature moved to another ID", hide(evilID), saml.ErrInvalidSignature},
+		{"signed assertion hidden, unsigned assertion", hide(evilUnsigned), saml.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertion, err := parse(idp, tt.document, testNow)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+			if assertion != nil {
+				t.Errorf("accepted assertion for %q", assertion.NameID)
+			}
+		})
+	}
+}
+
+func TestParseResponseCommentInSubject(t *testing.T) {
+	mock, idp := newTestIdP(t)
+	opts := responseOptions()
+	opts.NameID = "alice@example.com.evil.example"
+	document := signedResponse(t, mock, opts)
+
+	// Comments aren't signed, so the subject must be read including the text after one
+	commented := strings.Replace(document, ">alice@example.com.evil.example<", ">alice@example.com<!---->.evil.example<", 1)
+	assertion, err := parse(idp, commented, testNow)
+	if err != nil {
+		t.Fatalf("ParseResponse: %v", err)
+	}
+	if assertion.NameID != "alice@example.com.evil.example" {
+		t.Errorf("NameID = %q, want the whole signed subject", assertion.NameID)
+	}
+}
+
+func TestParseResponseConditions(t *testing.T) {
+	mock, idp := newTestIdP(t)
+	other, err := samltest.NewIdP(testIdPEntityID, "https://idp.example.com/sso")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		idp    *samltest.IdP
+		modify func(opts *samltest.ResponseOptions)
+		now    time.Time
+		want   error
+	}{
+		{"valid", mock, nil, testNow, nil},
+		{"within clock skew after expiry", mock, nil, testNow.Add(7 * time.Minute), nil},
+		{"within clock skew before issue", mock, nil, testNow.Add(-2 * time.Minute), nil},
+		{"expired", mock, nil, testNow.Add(9 * time.Minute), saml.ErrInvalidResponse},
+		{"not yet valid", mock, nil, testNow.Add(-4 * time.Minute), saml.ErrInvalidResponse},
+		{"other audience", mock, func(opts *samltest.ResponseOptions) {
+			opts.Audience = "https://hub.example.com/api/auth/saml/other/metadata"
+		}, testNow, saml.ErrInvalidResponse},
+		{"other destination", mock, func(opts *samltest.ResponseOptions) {
+			opts.ACSURL = "https://hub.example.com/api/auth/saml/other/acs"
+		}, testNow, saml.ErrInvalidResponse},
+		{"missing subject", mock, func(opts *samltest.ResponseOptions) {
+			opts.NameID = ""
+		}, testNow, saml.ErrInvalidResponse},
+		{"signed by another key", other, nil, testNow, saml.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := responseOptions()
+			if tt.modify != nil {
+				tt.modify(&opts)
+			}
+
+			_, err := parse(idp, signedResponse(t, tt.idp, opts), tt.now)
+			if tt.want == nil && err != nil {
+				t.Errorf("ParseResponse: %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseResponseOtherIssuer(t *testing.T) {
+	mock, idp := newTestIdP(t)
+	configured := *idp
+	configured.EntityID = "https://other-idp.example.com/metadata"
+
+	if _, err := parse(&configured, signedResponse(t, mock, responseOptions()), testNow); !errors.Is(err, saml.ErrInvalidResponse) {
+		t.Errorf("err = %v, want ErrInvalidResponse", err)
+	}
+}
+
+func TestParseResponseRejectsDTD(t *testing.T) {
+	mock, idp := newTestIdP(t)
+	document := `<!DOCTYPE r [<!ENTITY e "alice@example.com">]>` + signedResponse(t, mock, responseOptions())
+
+	if _, err := parse(idp, document, testNow); !errors.Is(err, saml.ErrInvalidResponse) {
+		t.Errorf("err = %v, want ErrInvalidResponse", err)
+	}
+}
@@ -0,0 +1,235 @@
+// Package samltest provides a SAML identity provider with a locally generated
+// certificate, producing signed fixture responses for exercising the SAML
+// service provider without a real identity provider.
+//
+// Responses are written directly in their exclusive canonical form, so the
+// signatures are computed without the service provider's canonicalizer and
+// a bug in it can't be hidden by signing and verifying with the same code.
+package samltest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SAML namespaces and identifiers used in responses
+const (
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceDSig      = "http://www.w3.org/2000/09/xmldsig#"
+
+	statusSuccess = "urn:oasis:names:tc:SAML:2.0:status:Success"
+)
+
+// IdP is a mock identity provider
+type IdP struct {
+	EntityID    string
+	SSOURL      string
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+}
+
+// NewIdP creates an identity provider with a new self-signed certificate
+func NewIdP(entityID string, ssoURL string) (*IdP, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano()),
+		Subject:      pkix.Name{CommonName: entityID},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IdP{
+		EntityID:    entityID,
+		SSOURL:      ssoURL,
+		Key:         key,
+		Certificate: cert,
+	}, nil
+}
+
+// CertificatePEM returns the certificate to configure on the tenant
+func (i *IdP) CertificatePEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: i.Certificate.Raw}))
+}
+
+// ResponseOptions describes the response to produce
+type ResponseOptions struct {
+	ACSURL       string // Destination and recipient
+	Audience     string // Service provider entity ID
+	InResponseTo string // ID of the authentication request
+	NameID       string
+	Attributes   map[string][]string
+	SignResponse bool          // Sign the whole response instead of the assertion
+	Now          time.Time     // Defaults to the current time
+	Validity     time.Duration // Defaults to five minutes
+}
+
+// Response returns a signed, base64 encoded response as posted to the
+// assertion consumer service
+func (i *IdP) Response(opts ResponseOptions) (string, error) {
+	document, err := i.ResponseXML(opts)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(document), nil
+}
+
+// ResponseXML returns a signed response before encoding, so that tests can
+// tamper with it
+func (i *IdP) ResponseXML(opts ResponseOptions) ([]byte, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	validity := opts.Validity
+	if validity == 0 {
+		validity = 5 * time.Minute
+	}
+	issued := now.UTC().Format(time.RFC3339)
+	expires := now.Add(validity).UTC().Format(time.RFC3339)
+
+	responseID, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	assertionID, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	// Attribute names are sorted so that responses are reproducible
+	names := make([]string, 0, len(opts.Attributes))
+	for name := range opts.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var attributes strings.Builder
+	for _, name := range names {
+		attributes.WriteString(`<saml:Attribute Name="` + escapeAttr(name) + `">`)
+		for _, value := range opts.Attributes[name] {
+			attributes.WriteString(`<saml:AttributeValue>` + escapeText(value) + `</saml:AttributeValue>`)
+		}
+		attributes.WriteString(`</saml:Attribute>`)
+	}
+
+	// Every element is written as exclusive canonicalization renders it:
+	// namespaces declared where first used, attributes sorted and no empty
+	// element tags
+	issuer := `<saml:Issuer>` + escapeText(i.EntityID) + `</saml:Issuer>`
+	assertionBody := `<saml:Subject>` +
+		`<saml:NameID Format="urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress">` + escapeText(opts.NameID) + `</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">` +
+		`<saml:SubjectConfirmationData InResponseTo="` + escapeAttr(opts.InResponseTo) + `" NotOnOrAfter="` + expires + `" Recipient="` + escapeAttr(opts.ACSURL) + `"></saml:SubjectConfirmationData>` +
+		`</saml:SubjectConfirmation>` +
+		`</saml:Subject>` +
+		`<saml:Conditions NotBefore="` + issued + `" NotOnOrAfter="` + expires + `">` +
+		`<saml:AudienceRestriction><saml:Audience>` + escapeText(opts.Audience) + `</saml:Audience></saml:AudienceRestriction>` +
+		`</saml:Conditions>` +
+		`<saml:AuthnStatement AuthnInstant="` + issued + `"><saml:AuthnContext><saml:AuthnContextClassRef>urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport</saml:AuthnContextClassRef></saml:AuthnContext></saml:AuthnStatement>` +
+		`<saml:AttributeStatement>` + attributes.String() + `</saml:AttributeStatement>`
+
+	assertionStart := `<saml:Assertion xmlns:saml="` + namespaceAssertion + `" ID="` + assertionID + `" IssueInstant="` + issued + `" Version="2.0">`
+	assertion := assertionStart + issuer + assertionBody + `</saml:Assertion>`
+	if !opts.SignResponse {
+		signature, err := i.sign(assertionID, []byte(assertion))
+		if err != nil {
+			return nil, err
+		}
+		assertion = assertionStart + issuer + signature + assertionBody + `</saml:Assertion>`
+	}
+
+	responseStart := `<samlp:Response xmlns:samlp="` + namespaceProtocol + `" Destination="` + escapeAttr(opts.ACSURL) + `" ID="` + responseID + `"` +
+		` InResponseTo="` + escapeAttr(opts.InResponseTo) + `" IssueInstant="` + issued + `" Version="2.0">`
+	responseIssuer := `<saml:Issuer xmlns:saml="` + namespaceAssertion + `">` + escapeText(i.EntityID) + `</saml:Issuer>`
+	responseBody := `<samlp:Status><samlp:StatusCode Value="` + statusSuccess + `"></samlp:StatusCode></samlp:Status>` + assertion
+	response := responseStart + responseIssuer + responseBody + `</samlp:Response>`
+	if opts.SignResponse {
+		signature, err := i.sign(responseID, []byte(response))
+		if err != nil {
+			return nil, err
+		}
+		response = responseStart + responseIssuer + signature + responseBody + `</samlp:Response>`
+	}
+
+	return []byte(response), nil
+}
+
+// sign returns the enveloped RSA-SHA256 signature of an element given in its
+// canonical form without the signature
+func (i *IdP) sign(id string, canonical []byte) (string, error) {
+	digest := sha256.Sum256(canonical)
+
+	// SignedInfo in its canonical form, as signed
+	signedInfo := `<ds:SignedInfo xmlns:ds="` + namespaceDSig + `">` +
+		`<ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"></ds:CanonicalizationMethod>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"></ds:SignatureMethod>` +
+		`<ds:Reference URI="#` + escapeAttr(id) + `">` +
+		`<ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"></ds:Transform>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"></ds:Transform>` +
+		`</ds:Transforms>` +
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"></ds:DigestMethod>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue>` +
+		`</ds:Reference>` +
+		`</ds:SignedInfo>`
+
+	signedInfoHash := sha256.Sum256([]byte(signedInfo))
+	signatureValue, err := rsa.SignPKCS1v15(rand.Reader, i.Key, crypto.SHA256, signedInfoHash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return `<ds:Signature xmlns:ds="` + namespaceDSig + `">` +
+		strings.Replace(signedInfo, ` xmlns:ds="`+namespaceDSig+`"`, "", 1) +
+		`<ds:SignatureValue>` + base64.StdEncoding.EncodeToString(signatureValue) + `</ds:SignatureValue>` +
+		`<ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString(i.Certificate.Raw) + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo>` +
+		`</ds:Signature>`, nil
+}
+
+// newID returns a random XML ID, which must not start with a digit
+func newID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "_" + hex.EncodeToString(b), nil
+}
+
+// Escaping as done by canonicalization
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
@@ -0,0 +1,157 @@
+package saml
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	_ "crypto/sha1" // Registers SHA-1, still used by some identity providers
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// XML signature namespaces and algorithms
+const (
+	namespaceDSig = "http://www.w3.org/2000/09/xmldsig#"
+
+	algorithmExcC14N      = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	algorithmEnveloped    = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	algorithmRSASHA1      = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+	algorithmRSASHA256    = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algorithmRSASHA512    = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	algorithmDigestSHA1   = "http://www.w3.org/2000/09/xmldsig#sha1"
+	algorithmDigestSHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algorithmDigestSHA512 = "http://www.w3.org/2001/04/xmlenc#sha512"
+)
+
+// ErrInvalidSignature is returned when an XML signature is missing or does not verify
+var ErrInvalidSignature = errors.New("invalid XML signature")
+
+var signatureMethods = map[string]crypto.Hash{
+	algorithmRSASHA1:   crypto.SHA1,
+	algorithmRSASHA256: crypto.SHA256,
+	algorithmRSASHA512: crypto.SHA512,
+}
+
+var digestMethods = map[string]crypto.Hash{
+	algorithmDigestSHA1:   crypto.SHA1,
+	algorithmDigestSHA256: crypto.SHA256,
+	algorithmDigestSHA512: crypto.SHA512,
+}
+
+// hasSignature reports whether the element carries an enveloped signature
+func hasSignature(e *element) bool {
+	return e.child(namespaceDSig, "Signature") != nil
+}
+
+// verifySignature checks the enveloped signature of the element. Only the
+// configured certificate is trusted; keys embedded in the signature are
+// ignored.
+func verifySignature(root *element, e *element, cert *x509.Certificate) error {
+	signatures := e.childElements(namespaceDSig, "Signature")
+	if len(signatures) != 1 {
+		return fmt.Errorf("%w: expected one signature, found %d", ErrInvalidSignature, len(signatures))
+	}
+	signature := signatures[0]
+
+	signedInfo := signature.child(namespaceDSig, "SignedInfo")
+	if signedInfo == nil {
+		return fmt.Errorf("%w: missing SignedInfo", ErrInvalidSignature)
+	}
+
+	// The signature must cover exactly this element, and its ID must be
+	// unique so that another element can't be swapped in
+	id := e.attr("ID")
+	if id == "" || len(root.findByID(id)) != 1 {
+		return fmt.Errorf("%w: signed element must have a unique ID", ErrInvalidSignature)
+	}
+	references := signedInfo.childElements(namespaceDSig, "Reference")
+	if len(references) != 1 || references[0].attr("URI") != "#"+id {
+		return fmt.Errorf("%w: signature does not reference the signed element", ErrInvalidSignature)
+	}
+	reference := references[0]
+
+	// Check the digest of the element
+	var inclusive []string
+	if transforms := reference.child(namespaceDSig, "Transforms"); transforms != nil {
+		for _, transform := range transforms.childElements(namespaceDSig, "Transform") {
+			switch transform.attr("Algorithm") {
+			case algorithmEnveloped:
+			case algorithmExcC14N:
+				inclusive = inclusivePrefixes(transform)
+			default:
+				return fmt.Errorf("%w: unsupported transform %q", ErrInvalidSignature, transform.attr("Algorithm"))
+			}
+		}
+	}
+	digestMethod := reference.child(namespaceDSig, "DigestMethod")
+	digestValue := reference.child(namespaceDSig, "DigestValue")
+	if digestMethod == nil || digestValue == nil {
+		return fmt.Errorf("%w: incomplete reference", ErrInvalidSignature)
+	}
+	digestHash, ok := digestMethods[digestMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("%w: unsupported digest method %q", ErrInvalidSignature, digestMethod.attr("Algorithm"))
+	}
+	expectedDigest, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return fmt.Errorf("%w: malformed digest", ErrInvalidSignature)
+	}
+	digest := digestHash.New()
+	digest.Write(canonicalize(e, signature, inclusive))
+	if !bytes.Equal(digest.Sum(nil), expectedDigest) {
+		return fmt.Errorf("%w: digest mismatch", ErrInvalidSignature)
+	}
+
+	// Check the signature of SignedInfo
+	canonicalizationMethod := signedInfo.child(namespaceDSig, "CanonicalizationMethod")
+	if canonicalizationMethod == nil || canonicalizationMethod.attr("Algorithm") != algorithmExcC14N {
+		return fmt.Errorf("%w: unsupported canonicalization method", ErrInvalidSignature)
+	}
+	signatureMethod := signedInfo.child(namespaceDSig, "SignatureMethod")
+	if signatureMethod == nil {
+		return fmt.Errorf("%w: missing signature method", ErrInvalidSignature)
+	}
+	signatureHash, ok := signatureMethods[signatureMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("%w: unsupported signature method %q", ErrInvalidSignature, signatureMethod.attr("Algorithm"))
+	}
+	signatureValue := signature.child(namespaceDSig, "SignatureValue")
+	if signatureValue == nil {
+		return fmt.Errorf("%w: missing signature value", ErrInvalidSignature)
+	}
+	signatureBytes, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature value", ErrInvalidSignature)
+	}
+
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: certificate does not hold an RSA key", ErrInvalidSignature)
+	}
+	signedInfoHash := signatureHash.New()
+	signedInfoHash.Write(canonicalize(signedInfo, nil, inclusivePrefixes(canonicalizationMethod)))
+	if err := rsa.VerifyPKCS1v15(publicKey, signatureHash, signedInfoHash.Sum(nil), signatureBytes); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return nil
+}
+
+// inclusivePrefixes returns the InclusiveNamespaces prefix list of a canonicalization transform
+func inclusivePrefixes(transform *element) []string {
+	inclusive := transform.child(algorithmExcC14N, "InclusiveNamespaces")
+	if inclusive == nil {
+		return nil
+	}
+	return strings.Fields(inclusive.attr("PrefixList"))
+}
+
+// decodeBase64 decodes base64 that may be wrapped over several lines
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.Join(strings.Fields(s), "")
+	return base64.StdEncoding.DecodeString(s)
+}
@@ -0,0 +1,307 @@
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// xmlNamespace is bound to the xml prefix in every document
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// element is a node of a parsed document. Unlike encoding/xml it keeps the
+// prefixes as written, which canonicalization needs.
+type element struct {
+	prefix   string
+	local    string
+	attrs    []attribute
+	nsDecls  map[string]string // Namespaces declared on this element; "" is the default namespace
+	scope    map[string]string // Namespaces in scope, including those declared here
+	children []interface{}     // *element or string
+	parent   *element
+}
+
+type attribute struct {
+	prefix string
+	local  string
+	value  string
+}
+
+// parseDocument parses a document into a tree. Documents with a DTD are
+// rejected.
+func parseDocument(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			el := &element{
+				prefix:  t.Name.Space,
+				local:   t.Name.Local,
+				nsDecls: make(map[string]string),
+				parent:  current,
+			}
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "xmlns":
+					el.nsDecls[attr.Name.Local] = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					el.nsDecls[""] = attr.Value
+				default:
+					el.attrs = append(el.attrs, attribute{prefix: attr.Name.Space, local: attr.Name.Local, value: attr.Value})
+				}
+			}
+			el.scope = inheritScope(current, el.nsDecls)
+
+			if current == nil {
+				if root != nil {
+					return nil, errors.New("document has more than one root element")
+				}
+				root = el
+			} else {
+				current.children = append(current.children, el)
+			}
+			current = el
+		case xml.EndElement:
+			if current == nil || t.Name.Space != current.prefix || t.Name.Local != current.local {
+				return nil, fmt.Errorf("unexpected end element %s", t.Name.Local)
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, string(t))
+			}
+		case xml.Directive:
+			return nil, errors.New("document type declarations are not allowed")
+		}
+	}
+
+	if root == nil || current != nil {
+		return nil, errors.New("document is incomplete")
+	}
+	return root, nil
+}
+
+// inheritScope returns the namespaces in scope of a child of parent
+func inheritScope(parent *element, decls map[string]string) map[string]string {
+	scope := map[string]string{"xml": xmlNamespace}
+	if parent != nil {
+		for prefix, uri := range parent.scope {
+			scope[prefix] = uri
+		}
+	}
+	for prefix, uri := range decls {
+		scope[prefix] = uri
+	}
+	return scope
+}
+
+// namespace returns the namespace URI of the element
+func (e *element) namespace() string {
+	return e.scope[e.prefix]
+}
+
+// is reports whether the element has the given namespace and local name
+func (e *element) is(namespace, local string) bool {
+	return e.local == local && e.namespace() == namespace
+}
+
+// attr returns the value of an unprefixed attribute
+func (e *element) attr(local string) string {
+	for _, a := range e.attrs {
+		if a.prefix == "" && a.local == local {
+			return a.value
+		}
+	}
+	return ""
+}
+
+// childElements returns the child elements with the given namespace and local name
+func (e *element) childElements(namespace, local string) []*element {
+	var result []*element
+	for _, child := range e.children {
+		if el, ok := child.(*element); ok && el.is(namespace, local) {
+			result = append(result, el)
+		}
+	}
+	return result
+}
+
+// child returns the first child element with the given namespace and local name
+func (e *element) child(namespace, local string) *element {
+	children := e.childElements(namespace, local)
+	if len(children) == 0 {
+		return nil
+	}
+	return children[0]
+}
+
+// text returns the concatenated character data of the element
+func (e *element) text() string {
+	var b strings.Builder
+	for _, child := range e.children {
+		if s, ok := child.(string); ok {
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// findByID returns all elements of the tree with the given ID attribute
+func (e *element) findByID(id string) []*element {
+	var result []*element
+	if e.attr("ID") == id {
+		result = append(result, e)
+	}
+	for _, child := range e.children {
+		if el, ok := child.(*element); ok {
+			result = append(result, el.findByID(id)...)
+		}
+	}
+	return result
+}
+
+// canonicalize serializes the element with Exclusive XML Canonicalization
+// (without comments). The skip element, if any, is left out, as done by the
+// enveloped signature transform. Prefixes in inclusive are rendered as in
+// inclusive canonicalization.
+func canonicalize(e *element, skip *element, inclusive []string) []byte {
+	c := &canonicalizer{skip: skip, inclusive: inclusive}
+	c.write(e, map[string]string{})
+	return c.buf.Bytes()
+}
+
+type canonicalizer struct {
+	buf       bytes.Buffer
+	skip      *element
+	inclusive []string
+}
+
+func (c *canonicalizer) write(e *element, rendered map[string]string) {
+	// Render namespaces the element and its attributes use, unless an output
+	// ancestor already rendered them
+	used := map[string]bool{e.prefix: true}
+	for _, a := range e.attrs {
+		if a.prefix != "" {
+			used[a.prefix] = true
+		}
+	}
+	for _, prefix := range c.inclusive {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		if _, ok := e.scope[prefix]; ok {
+			used[prefix] = true
+		}
+	}
+
+	var prefixes []string
+	for prefix := range used {
+		if prefix == "xml" {
+			continue
+		}
+		uri, ok := e.scope[prefix]
+		if !ok && prefix != "" {
+			continue
+		}
+		// An unrendered default namespace is the empty namespace
+		if rendered[prefix] == uri {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	childRendered := make(map[string]string, len(rendered)+len(prefixes))
+	for prefix, uri := range rendered {
+		childRendered[prefix] = uri
+	}
+
+	c.buf.WriteByte('<')
+	c.buf.WriteString(qualifiedName(e.prefix, e.local))
+	for _, prefix := range prefixes {
+		uri := e.scope[prefix]
+		childRendered[prefix] = uri
+		if prefix == "" {
+			c.buf.WriteString(` xmlns="`)
+		} else {
+			c.buf.WriteString(` xmlns:` + prefix + `="`)
+		}
+		c.buf.WriteString(escapeAttr(uri))
+		c.buf.WriteByte('"')
+	}
+
+	// Attributes are sorted by namespace URI, then local name
+	attrs := make([]attribute, len(e.attrs))
+	copy(attrs, e.attrs)
+	sort.SliceStable(attrs, func(i, j int) bool {
+		ni, nj := e.attrNamespace(attrs[i]), e.attrNamespace(attrs[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return attrs[i].local < attrs[j].local
+	})
+	for _, a := range attrs {
+		c.buf.WriteByte(' ')
+		c.buf.WriteString(qualifiedName(a.prefix, a.local))
+		c.buf.WriteString(`="`)
+		c.buf.WriteString(escapeAttr(a.value))
+		c.buf.WriteByte('"')
+	}
+	c.buf.WriteByte('>')
+
+	for _, child := range e.children {
+		switch n := child.(type) {
+		case *element:
+			if n != c.skip {
+				c.write(n, childRendered)
+			}
+		case string:
+			c.buf.WriteString(escapeText(n))
+		}
+	}
+
+	c.buf.WriteString("</")
+	c.buf.WriteString(qualifiedName(e.prefix, e.local))
+	c.buf.WriteByte('>')
+}
+
+// attrNamespace returns the namespace URI of an attribute of the element
+func (e *element) attrNamespace(a attribute) string {
+	if a.prefix == "" {
+		return ""
+	}
+	return e.scope[a.prefix]
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+
+var attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
@@ -0,0 +1,151 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 seed of the RFC 6238 test vectors ("12345678901234567890")
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// fixedClock returns a Clock that always reports the given Unix time
+func fixedClock(unix int64) Clock {
+	return func() time.Time {
+		return time.Unix(unix, 0).UTC()
+	}
+}
+
+func TestCodeRFC6238(t *testing.T) {
+	// RFC 6238 appendix B, SHA1. The RFC lists 8-digit codes; 6-digit codes
+	// are their last six digits.
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		clock := fixedClock(tt.unix)
+		code, err := Code(rfcSecret, Step(clock()))
+		if err != nil {
+			t.Fatalf("Code at %d: %v", tt.unix, err)
+		}
+		if code != tt.code {
+			t.Errorf("Code at %d = %s, want %s", tt.unix, code, tt.code)
+		}
+	}
+}
+
+func TestCodeSecretFormat(t *testing.T) {
+	want, err := Code(rfcSecret, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticator apps show secrets in lowercase groups of four
+	var groups []string
+	lower := strings.ToLower(rfcSecret)
+	for i := 0; i < len(lower); i += 4 {
+		groups = append(groups, lower[i:i+4])
+	}
+	got, err := Code(strings.Join(groups, " "), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Code with grouped lowercase secret = %s, want %s", got, want)
+	}
+
+	if _, err := Code("not base32!", 1); err != ErrInvalidSecret {
+		t.Errorf("Code with invalid secret: err = %v, want ErrInvalidSecret", err)
+	}
+}
+
+func TestValidateWindow(t *testing.T) {
+	// 1111111111 is in step 37037037, 1 second before its end
+	clock := fixedClock(1111111111)
+	current := Step(clock())
+
+	tests := []struct {
+		name     string
+		offset   int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", 0, current, true},
+		{"previous step", -1, current - 1, true},
+		{"next step", 1, current + 1, true},
+		{"two steps behind", -2, 0, false},
+		{"two steps ahead", 2, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Code(rfcSecret, current+tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			step, ok := Validate(rfcSecret, code, clock())
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("Validate = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateSkewAcrossStepBoundary(t *testing.T) {
+	// A code generated just before a step ends is still accepted just after
+	// it, but not once the following step has passed as well
+	generated := fixedClock(1111111109)
+	code, err := Code(rfcSecret, Step(generated()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := Validate(rfcSecret, code, fixedClock(1111111111)()); !ok {
+		t.Error("code rejected in the next step")
+	}
+	if _, ok := Validate(rfcSecret, code, fixedClock(1111111109+Period)()); !ok {
+		t.Error("code rejected one period later")
+	}
+	if _, ok := Validate(rfcSecret, code, fixedClock(1111111109+2*Period)()); ok {
+		t.Error("code accepted two periods later")
+	}
+}
+
+func TestValidateRejectsMalformedCodes(t *testing.T) {
+	clock := fixedClock(59)
+
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"empty", ""},
+		{"too short", "28708"},
+		{"too long", "2870820"},
+		{"eight digits", "94287082"},
+		{"wrong code", "287083"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := Validate(rfcSecret, tt.code, clock()); ok {
+				t.Errorf("Validate(%q) accepted", tt.code)
+			}
+		})
+	}
+
+	// Spaces are ignored as authenticator apps show codes in groups
+	if _, ok := Validate(rfcSecret, "287 082", clock()); !ok {
+		t.Error("Validate with a space rejected")
+	}
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/access"
+)
+
+type AccessHandler struct {
+	updateAccessUseCase access.UpdateAccessUseCase
+}
+
+func NewAccessHandler(updateAccessUseCase access.UpdateAccessUseCase) *AccessHandler {
+	return &AccessHandler{
+		updateAccessUseCase: updateAccessUseCase,
+	}
+}
+
+// AccessEntryRequest grants a user or a group access to a space or knowledge
+type AccessEntryRequest struct {
+	PrincipalType string `json:"principal_type" validate:"required,oneof=user group"`
+	PrincipalID   string `json:"principal_id" validate:"required"`
+	Level         string `json:"level" validate:"required,oneof=read write admin"`
+}
+
+// UpdateAccessRequest represents the update access request body. Omitted fields are unchanged.
+type UpdateAccessRequest struct {
+	Private *bool                `json:"private"`
+	Entries []AccessEntryRequest `json:"entries" validate:"omitempty,dive"`
+}
+
+// accessError maps access use case errors to HTTP errors
+func accessError(err error, message string) error {
+	switch {
+	case errors.Is(err, access.ErrResourceNotFound):
+		return appErrors.NotFound("Resource not found", err)
+	case errors.Is(err, access.ErrInvalidAccessLevel):
+		return appErrors.NewValidationError("Access level must be read, write or admin", nil, err)
+	case errors.Is(err, access.ErrPrincipalNotFound):
+		return appErrors.NewValidationError("User or group not found", nil, err)
+	case errors.Is(err, authz.ErrForbidden):
+		return appErrors.Forbidden("You don't have permission to share this resource", err)
+	}
+	return appErrors.InternalServerError(message, err)
+}
+
+// GetSpaceAccess handles getting the access control of a space
+// @Summary Get space access
+// @Description Get whether a space is private and the users and groups it is shared with
+// @Tags access
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID or slug"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.AccessList
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /spaces/{id}/access [get]
+func (h *AccessHandler) GetSpaceAccess(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get space
+	found, err := findSpace(c, id, claims)
+	if err != nil {
+		return appErrors.NotFound("Space not found", err)
+	}
+
+	// Get access control list
+	repo := c.Get("repositories").(RepositoriesProvider).Access()
+	list, err := access.List(repo, model.AccessResourceSpace, found.ID, claims.TenantID, found.Private, nil)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to get space access", err)
+	}
+
+	return appErrors.SendOK(c, list)
+}
+
+// UpdateSpaceAccess handles changing the access control of a space
+// @Summary Update space access
+// @Description Make a space private or public and replace the users and groups it is shared with. Knowledge in the space inherits its access.
+// @Tags access
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID"
+// @Param request body UpdateAccessRequest true "Access control data"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.AccessList
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /spaces/{id}/access [put]
+func (h *AccessHandler) UpdateSpaceAccess(c echo.Context) error {
+	return h.update(c, model.AccessResourceSpace, "Failed to update space access")
+}
+
+// GetKnowledgeAccess handles getting the access control of a knowledge
+// @Summary Get knowledge access
+// @Description Get whether a knowledge is private and the users and groups it is shared with, including those inherited from its space
+// @Tags access
+// @Accept json
+// @Produce json
+// @Param id path string true "Knowledge ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.AccessList
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /knowledge/{id}/access [get]
+func (h *AccessHandler) GetKnowledgeAccess(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get repositories
+	repos := c.Get("repositories").(RepositoriesProvider)
+
+	// Get knowledge
+	found, err := repos.Knowledge().FindByID(id, claims.TenantID, authz.Viewer(claims.Subject()))
+	if err != nil {
+		return appErrors.NotFound("Knowledge not found", err)
+	}
+
+	// Get access control list
+	list, err := access.List(repos.Access(), model.AccessResourceKnowledge, found.ID, claims.TenantID, found.Private, found.SpaceID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to get knowledge access", err)
+	}
+
+	return appErrors.SendOK(c, list)
+}
+
+// UpdateKnowledgeAccess handles changing the access control of a knowledge
+// @Summary Update knowledge access
+// @Description Make a knowledge private or public and replace the users and groups it is shared with
+// @Tags access
+// @Accept json
+// @Produce json
+// @Param id path string true "Knowledge ID"
+// @Param request body UpdateAccessRequest true "Access control data"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.AccessList
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /knowledge/{id}/access [put]
+func (h *AccessHandler) UpdateKnowledgeAccess(c echo.Context) error {
+	return h.update(c, model.AccessResourceKnowledge, "Failed to update knowledge access")
+}
+
+// update changes the access control of the space or knowledge with the ID in the path
+func (h *AccessHandler) update(c echo.Context, resourceType string, message string) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req UpdateAccessRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Convert entries
+	var entries []access.EntryInput
+	if req.Entries != nil {
+		entries = make([]access.EntryInput, 0, len(req.Entries))
+		for _, entry := range req.Entries {
+			entries = append(entries, access.EntryInput{
+				PrincipalType: entry.PrincipalType,
+				PrincipalID:   entry.PrincipalID,
+				Level:         entry.Level,
+			})
+		}
+	}
+
+	// Update access
+	list, err := h.updateAccessUseCase.Execute(access.UpdateAccessInput{
+		ResourceType: resourceType,
+		ResourceID:   id,
+		TenantID:     claims.TenantID,
+		Private:      req.Private,
+		Entries:      entries,
+		Actor:        claims.Subject(),
+	})
+	if err != nil {
+		return accessError(err, message)
+	}
+
+	return appErrors.SendOK(c, list)
+}
@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/apitoken"
+)
+
+type APITokenHandler struct {
+	createAPITokenUseCase apitoken.CreateAPITokenUseCase
+	revokeAPITokenUseCase apitoken.RevokeAPITokenUseCase
+}
+
+func NewAPITokenHandler(
+	createAPITokenUseCase apitoken.CreateAPITokenUseCase,
+	revokeAPITokenUseCase apitoken.RevokeAPITokenUseCase,
+) *APITokenHandler {
+	return &APITokenHandler{
+		createAPITokenUseCase: createAPITokenUseCase,
+		revokeAPITokenUseCase: revokeAPITokenUseCase,
+	}
+}
+
+// CreateAPITokenRequest represents the create API token request body
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=knowledge:read knowledge:write tags:read tags:write comments:read comments:write"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"omitempty,min=1,max=365"` // Never expires when omitted
+}
+
+// CreateSCIMTokenRequest represents the create SCIM token request body
+type CreateSCIMTokenRequest struct {
+	Name          string `json:"name" validate:"required,max=100"`
+	ExpiresInDays int    `json:"expires_in_days" validate:"omitempty,min=1,max=365"` // Never expires when omitted
+}
+
+// ListPersonal handles listing the current user's personal access tokens
+// @Summary List personal access tokens
+// @Description List the current user's personal access tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} model.APIToken
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/tokens [get]
+func (h *APITokenHandler) ListPersonal(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	return h.list(c, model.APITokenKindPersonal, claims.TenantID, claims.UserID)
+}
+
+// CreatePersonal handles creating a personal access token for the current user
+// @Summary Create personal access token
+// @Description Create a token that acts as the current user within its scopes. The token is only shown in this response.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body CreateAPITokenRequest true "Token data"
+// @Security ApiKeyAuth
+// @Success 201 {object} apitoken.CreatedAPIToken
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/tokens [post]
+func (h *APITokenHandler) CreatePersonal(c echo.Context) error {
+	return h.createWithScopes(c, model.APITokenKindPersonal)
+}
+
+// RevokePersonal handles revoking one of the current user's personal access tokens
+// @Summary Revoke personal access token
+// @Description Revoke a personal access token of the current user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path string true "Token ID"
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/tokens/{id} [delete]
+func (h *APITokenHandler) RevokePersonal(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	return h.revoke(c, model.APITokenKindPersonal, claims.UserID)
+}
+
+// ListService handles listing the tenant's service API keys
+// @Summary List service API keys
+// @Description List the API keys of the tenant's automation
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} model.APIToken
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /api-keys [get]
+func (h *APITokenHandler) ListService(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	return h.list(c, model.APITokenKindService, claims.TenantID, "")
+}
+
+// CreateService handles creating a service API key
+// @Summary Create service API key
+// @Description Create an API key for the tenant's automation. It acts as the admin who created it, within its scopes, seeing only the knowledge that admin can see. It stops working when the admin is deactivated, deleted or loses tenant.manage, so create a new key to hand it over. The key is only shown in this response.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param request body CreateAPITokenRequest true "API key data"
+// @Security ApiKeyAuth
+// @Success 201 {object} apitoken.CreatedAPIToken
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /api-keys [post]
+func (h *APITokenHandler) CreateService(c echo.Context) error {
+	return h.createWithScopes(c, model.APITokenKindService)
+}
+
+// RevokeService handles revoking a service API key
+// @Summary Revoke service API key
+// @Description Revoke an API key of the tenant
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path string true "API key ID"
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /api-keys/{id} [delete]
+func (h *APITokenHandler) RevokeService(c echo.Context) error {
+	return h.revoke(c, model.APITokenKindService, "")
+}
+
+// ListSCIM handles listing the tenant's SCIM tokens
+// @Summary List SCIM tokens
+// @Description List the tokens the tenant's identity provider uses to provision users
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} model.APIToken
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /scim-tokens [get]
+func (h *APITokenHandler) ListSCIM(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	return h.list(c, model.APITokenKindSCIM, claims.TenantID, "")
+}
+
+// CreateSCIM handles creating a SCIM token
+// @Summary Create SCIM token
+// @Description Create a token for the tenant's identity provider to provision users and groups through /scim/v2. It can't be used for the rest of the API. The token is only shown in this response.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param request body CreateSCIMTokenRequest true "Token data"
+// @Security ApiKeyAuth
+// @Success 201 {object} apitoken.CreatedAPIToken
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /scim-tokens [post]
+func (h *APITokenHandler) CreateSCIM(c echo.Context) error {
+	var req CreateSCIMTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	return h.create(c, model.APITokenKindSCIM, req.Name, nil, req.ExpiresInDays)
+}
+
+// RevokeSCIM handles revoking a SCIM token
+// @Summary Revoke SCIM token
+// @Description Revoke a SCIM token of the tenant
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Token ID"
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /scim-tokens/{id} [delete]
+func (h *APITokenHandler) RevokeSCIM(c echo.Context) error {
+	return h.revoke(c, model.APITokenKindSCIM, "")
+}
+
+func (h *APITokenHandler) list(c echo.Context, kind string, tenantID string, userID string) error {
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).APIToken()
+
+	// Get API tokens
+	tokens, err := repo.FindByTenantID(tenantID, kind, userID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list API tokens", err)
+	}
+
+	return appErrors.SendOK(c, tokens)
+}
+
+func (h *APITokenHandler) createWithScopes(c echo.Context, kind string) error {
+	var req CreateAPITokenRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	return h.create(c, kind, req.Name, req.Scopes, req.ExpiresInDays)
+}
+
+func (h *APITokenHandler) create(c echo.Context, kind string, name string, scopes []string, expiresInDays int) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	var expiresAt *time.Time
+	if expiresInDays > 0 {
+		t := time.Now().AddDate(0, 0, expiresInDays)
+		expiresAt = &t
+	}
+
+	// Create API token
+	createdToken, err := h.createAPITokenUseCase.Execute(apitoken.CreateAPITokenInput{
+		TenantID:  claims.TenantID,
+		UserID:    claims.UserID,
+		Kind:      kind,
+		Name:      name,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, apitoken.ErrInvalidScope) {
+			return appErrors.NewValidationError("Invalid scopes", map[string]string{
+				"scopes": "Invalid scopes",
+			}, err)
+		}
+		return appErrors.InternalServerError("Failed to create API token", err)
+	}
+
+	return appErrors.SendCreated(c, createdToken)
+}
+
+func (h *APITokenHandler) revoke(c echo.Context, kind string, userID string) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Revoke API token
+	err := h.revokeAPITokenUseCase.Execute(apitoken.RevokeAPITokenInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+		Kind:     kind,
+		UserID:   userID,
+	})
+	if err != nil {
+		if errors.Is(err, apitoken.ErrAPITokenNotFound) {
+			return appErrors.NotFound("API token not found", err)
+		}
+		return appErrors.InternalServerError("Failed to revoke API token", err)
+	}
+
+	return appErrors.SendNoContent(c)
+}
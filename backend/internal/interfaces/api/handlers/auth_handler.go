@@ -1,28 +1,45 @@
 package handlers
 
 import (
-	"os"
+	"errors"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/user"
 )
 
 type AuthHandler struct {
 	authenticateUserUseCase user.AuthenticateUserUseCase
 	registerUserUseCase     user.RegisterUserUseCase
+	updateUserUseCase       user.UpdateUserUseCase
+	createSessionUseCase    session.CreateSessionUseCase
+	refreshSessionUseCase   session.RefreshSessionUseCase
+	revokeSessionUseCase    session.RevokeSessionUseCase
 }
 
 func NewAuthHandler(
 	authenticateUserUseCase user.AuthenticateUserUseCase,
 	registerUserUseCase user.RegisterUserUseCase,
+	updateUserUseCase user.UpdateUserUseCase,
+	createSessionUseCase session.CreateSessionUseCase,
+	refreshSessionUseCase session.RefreshSessionUseCase,
+	revokeSessionUseCase session.RevokeSessionUseCase,
 ) *AuthHandler {
 	return &AuthHandler{
 		authenticateUserUseCase: authenticateUserUseCase,
 		registerUserUseCase:     registerUserUseCase,
+		updateUserUseCase:       updateUserUseCase,
+		createSessionUseCase:    createSessionUseCase,
+		refreshSessionUseCase:   refreshSessionUseCase,
+		revokeSessionUseCase:    revokeSessionUseCase,
 	}
 }
 
@@ -30,38 +47,58 @@ func NewAuthHandler(
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
-	TenantID string `json:"tenant_id" validate:"required"`
+	TenantID string `json:"tenant_id"` // Optional, must match the tenant resolved from the host or X-Tenant header
 }
 
 // RegisterRequest represents the register request body
 type RegisterRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
-	TenantID string `json:"tenant_id" validate:"required"`
-	Role     string `json:"role" validate:"required,oneof=admin editor viewer"`
+	Password string `json:"password" validate:"required"`
+	TenantID string `json:"tenant_id"` // Optional, must match the tenant resolved from the host or X-Tenant header
+}
+
+// UpdateProfileRequest represents the update profile request body
+type UpdateProfileRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email" validate:"omitempty,email"`
+}
+
+// RefreshRequest represents the refresh request body
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// SessionResponse represents a signed-in device of the current user
+type SessionResponse struct {
+	*model.Session
+	Current bool `json:"current"`
 }
 
 // TokenResponse represents the token response
 type TokenResponse struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expires_at"`
-	UserID    string `json:"user_id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Role      string `json:"role"`
+	Token        string `json:"token"`
+	ExpiresAt    int64  `json:"expires_at"`
+	RefreshToken string `json:"refresh_token"`
+	UserID       string `json:"user_id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
 }
 
 // Login handles user login
 // @Summary Login user
-// @Description Authenticate user and return JWT token
+// @Description Authenticate user and return a JWT access token and a refresh token. When a second factor is needed, a challenge token for /auth/2fa/verify is returned instead.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body LoginRequest true "Login credentials"
 // @Success 200 {object} TokenResponse
+// @Success 202 {object} TwoFactorChallengeResponse
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 429 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c echo.Context) error {
@@ -74,41 +111,72 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return err
 	}
 
+	// Resolve tenant
+	tenantID, err := requestTenantID(c, req.TenantID)
+	if err != nil {
+		return err
+	}
+
 	// Authenticate user
-	user, err := h.authenticateUserUseCase.Execute(user.AuthenticateUserInput{
-		Email:    req.Email,
-		Password: req.Password,
-		TenantID: req.TenantID,
+	authenticatedUser, err := h.authenticateUserUseCase.Execute(user.AuthenticateUserInput{
+		Email:     req.Email,
+		Password:  req.Password,
+		TenantID:  tenantID,
+		IPAddress: c.RealIP(),
 	})
 	if err != nil {
+		if throttledErr := loginThrottledError(c, err); throttledErr != nil {
+			return throttledErr
+		}
+		if errors.Is(err, user.ErrUserDeactivated) {
+			return appErrors.Forbidden("Your account has been deactivated", err)
+		}
+		if errors.Is(err, user.ErrPasswordLoginDisabled) {
+			return appErrors.Forbidden("Password login is disabled, please sign in with SSO", err)
+		}
+		if errors.Is(err, user.ErrTenantDisabled) {
+			return appErrors.Forbidden("This tenant is scheduled for deletion", err)
+		}
 		return appErrors.Unauthorized("Invalid email or password", err)
 	}
 
-	// Generate JWT token
-	token, expiresAt, err := generateToken(user.ID, user.Email, user.Role, user.TenantID)
+	// Ask for a second factor, or for enrollment when the tenant requires one
+	setupRequired := false
+	if !authenticatedUser.TwoFactorEnabled {
+		repo := c.Get("repositories").(RepositoriesProvider).Tenant()
+		userTenant, err := repo.FindByID(authenticatedUser.TenantID)
+		if err != nil || userTenant == nil {
+			return appErrors.InternalServerError("Failed to get tenant", err)
+		}
+		setupRequired = userTenant.Settings.Security.RequireTwoFactor
+	}
+	if authenticatedUser.TwoFactorEnabled || setupRequired {
+		challenge, err := newTwoFactorChallenge(authenticatedUser, setupRequired)
+		if err != nil {
+			return appErrors.InternalServerError("Failed to generate token", err)
+		}
+		return appErrors.SendAccepted(c, challenge)
+	}
+
+	// Start session
+	response, err := issueTokens(c, h.createSessionUseCase, authenticatedUser)
 	if err != nil {
-		return appErrors.InternalServerError("Failed to generate token", err)
+		return sessionError(err)
 	}
 
-	return appErrors.SendOK(c, TokenResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
-		UserID:    user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-	})
+	return appErrors.SendOK(c, response)
 }
 
 // Register handles user registration
 // @Summary Register user
-// @Description Register a new user
+// @Description Register a new user with the viewer role. Only tenants whose registration mode is open accept registrations, from the allowed email domains if any are set. Invite-only tenants require an invitation, and tenants which sign in through SSO provision users on their first login.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body RegisterRequest true "User registration data"
 // @Success 201 {object} TokenResponse
 // @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
 // @Failure 409 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /auth/register [post]
@@ -122,44 +190,256 @@ func (h *AuthHandler) Register(c echo.Context) error {
 		return err
 	}
 
+	// Resolve tenant
+	tenantID, err := requestTenantID(c, req.TenantID)
+	if err != nil {
+		return err
+	}
+
 	// Register user
-	user, err := h.registerUserUseCase.Execute(user.RegisterUserInput{
+	registeredUser, err := h.registerUserUseCase.Execute(user.RegisterUserInput{
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
-		TenantID: req.TenantID,
-		Role:     req.Role,
+		TenantID: tenantID,
+		// Self-registered users start as viewers; admins grant higher roles
+		SelfRegistration: true,
 	})
 	if err != nil {
+		if policyErr := passwordPolicyError(err, "password"); policyErr != nil {
+			return policyErr
+		}
+		switch {
+		case errors.Is(err, user.ErrRegistrationClosed):
+			return appErrors.Forbidden("Registration is closed for this tenant", err)
+		case errors.Is(err, user.ErrInvitationRequired):
+			return appErrors.Forbidden("Registration requires an invitation", err)
+		case errors.Is(err, user.ErrEmailDomainNotAllowed):
+			return appErrors.Forbidden("Your email domain is not allowed to register", err)
+		case errors.Is(err, user.ErrPasswordLoginDisabled):
+			return appErrors.Forbidden("Registration is disabled, please sign in with SSO", err)
+		case errors.Is(err, user.ErrTenantDisabled):
+			return appErrors.Forbidden("This tenant is scheduled for deletion", err)
+		}
 		return appErrors.Conflict("User registration failed", err)
 	}
 
+	// Start session
+	response, err := issueTokens(c, h.createSessionUseCase, registeredUser)
+	if err != nil {
+		return sessionError(err)
+	}
+
+	return appErrors.SendCreated(c, response)
+}
+
+// Refresh handles exchanging a refresh token for new tokens
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and a new refresh token. Reusing a refresh token revokes its session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	var req RefreshRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Rotate refresh token, which must belong to the tenant of the request if there is one
+	input := session.RefreshSessionInput{
+		RefreshToken: req.RefreshToken,
+		IPAddress:    c.RealIP(),
+	}
+	if tenant := getRequestTenant(c); tenant != nil {
+		input.TenantID = tenant.ID
+	}
+	refreshed, err := h.refreshSessionUseCase.Execute(input)
+	if err != nil {
+		switch {
+		case errors.Is(err, session.ErrRefreshTokenReused):
+			return appErrors.Unauthorized("Refresh token has already been used, please log in again", err)
+		case errors.Is(err, session.ErrInvalidRefreshToken):
+			return appErrors.Unauthorized("Invalid or expired refresh token", err)
+		}
+		return appErrors.InternalServerError("Failed to refresh token", err)
+	}
+
 	// Generate JWT token
-	token, expiresAt, err := generateToken(user.ID, user.Email, user.Role, user.TenantID)
+	response, err := newTokenResponse(refreshed.User, &refreshed.SessionTokens)
 	if err != nil {
 		return appErrors.InternalServerError("Failed to generate token", err)
 	}
 
-	return appErrors.SendCreated(c, TokenResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
-		UserID:    user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
+	return appErrors.SendOK(c, response)
+}
+
+// Logout handles ending the current session
+// @Summary Logout
+// @Description Revoke the current session and its refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Revoke session
+	err := h.revokeSessionUseCase.Execute(session.RevokeSessionInput{
+		ID:       claims.ID,
+		UserID:   claims.UserID,
+		TenantID: claims.TenantID,
 	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to log out", err)
+	}
+
+	return appErrors.SendNoContent(c)
 }
 
-// generateToken generates a JWT token
-func generateToken(userID, email, role, tenantID string) (string, int64, error) {
-	// Get JWT secret from environment variable
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "default-secret-key" // Default secret for development
+// ListSessions handles listing the current user's active sessions
+// @Summary List sessions
+// @Description List the devices the current user is signed in on
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} SessionResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
 	}
 
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Session()
+
+	// Get sessions
+	sessions, err := repo.FindActiveByUserID(claims.UserID, claims.TenantID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list sessions", err)
+	}
+
+	response := make([]SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		response = append(response, SessionResponse{
+			Session: s,
+			Current: s.ID == claims.ID,
+		})
+	}
+
+	return appErrors.SendOK(c, response)
+}
+
+// RevokeSession handles signing out one of the current user's devices
+// @Summary Revoke session
+// @Description Sign out a specific device of the current user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Revoke session
+	err := h.revokeSessionUseCase.Execute(session.RevokeSessionInput{
+		ID:       id,
+		UserID:   claims.UserID,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return appErrors.NotFound("Session not found", err)
+		}
+		return appErrors.InternalServerError("Failed to revoke session", err)
+	}
+
+	return appErrors.SendNoContent(c)
+}
+
+// issueTokens starts a session for the user on the requesting device and returns its tokens
+func issueTokens(c echo.Context, createSessionUseCase session.CreateSessionUseCase, u *model.User) (*TokenResponse, error) {
+	tokens, err := createSessionUseCase.Execute(session.CreateSessionInput{
+		UserID:    u.ID,
+		TenantID:  u.TenantID,
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTokenResponse(u, tokens)
+}
+
+// sessionError maps an error of issueTokens to an HTTP error
+func sessionError(err error) error {
+	if errors.Is(err, session.ErrTenantDisabled) {
+		return appErrors.Forbidden("This tenant is scheduled for deletion", err)
+	}
+	return appErrors.InternalServerError("Failed to generate token", err)
+}
+
+// newTokenResponse generates an access token for the session and builds the token response
+func newTokenResponse(u *model.User, tokens *session.SessionTokens) (*TokenResponse, error) {
+	token, expiresAt, err := generateToken(u.ID, u.Email, u.Role, u.TenantID, tokens.Session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		Token:        token,
+		ExpiresAt:    expiresAt,
+		RefreshToken: tokens.RefreshToken,
+		UserID:       u.ID,
+		Name:         u.Name,
+		Email:        u.Email,
+		Role:         u.Role,
+	}, nil
+}
+
+// generateToken generates a short-lived JWT access token. Its ID is the
+// session ID, so revoking the session revokes the token.
+func generateToken(userID, email, role, tenantID, sessionID string) (string, int64, error) {
 	// Set expiration time
-	expiresAt := time.Now().Add(24 * time.Hour).Unix()
+	expiresAt := time.Now().Add(session.AccessTokenTTL).Unix()
 
 	// Create claims
 	claims := &Claims{
@@ -173,6 +453,7 @@ func generateToken(userID, email, role, tenantID string) (string, int64, error)
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "knowledge-hub",
 			Subject:   userID,
+			ID:        sessionID,
 		},
 	}
 
@@ -180,7 +461,7 @@ func generateToken(userID, email, role, tenantID string) (string, int64, error)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	// Sign token
-	tokenString, err := token.SignedString([]byte(jwtSecret))
+	tokenString, err := token.SignedString(security.Secret())
 	if err != nil {
 		return "", 0, err
 	}
@@ -205,11 +486,56 @@ func (h *AuthHandler) Me(c echo.Context) error {
 	claims := user.Claims.(*Claims)
 
 	return appErrors.SendOK(c, map[string]interface{}{
-		"user_id":   claims.UserID,
-		"email":     claims.Email,
-		"role":      claims.Role,
-		"tenant_id": claims.TenantID,
+		"user_id":     claims.UserID,
+		"email":       claims.Email,
+		"role":        claims.Role,
+		"permissions": claims.Permissions,
+		"tenant_id":   claims.TenantID,
+	})
+}
+
+// UpdateMe handles updating the current user's profile
+// @Summary Update current user
+// @Description Update the name or email of the current authenticated user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body UpdateProfileRequest true "Profile data"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.User
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/me [put]
+func (h *AuthHandler) UpdateMe(c echo.Context) error {
+	var req UpdateProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Update profile (role changes go through the user management API)
+	user, err := h.updateUserUseCase.Execute(user.UpdateUserInput{
+		ID:       claims.UserID,
+		Name:     req.Name,
+		Email:    req.Email,
+		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
 	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to update profile", err)
+	}
+
+	return appErrors.SendOK(c, user)
 }
 
 // RegisterRoutes registers the public auth routes
@@ -217,10 +543,22 @@ func (h *AuthHandler) RegisterRoutes(g *echo.Group) {
 	auth := g.Group("/auth")
 	auth.POST("/login", h.Login)
 	auth.POST("/register", h.Register)
+	auth.POST("/refresh", h.Refresh)
 }
 
-// RegisterProtectedRoutes registers the protected auth routes
-func (h *AuthHandler) RegisterProtectedRoutes(g *echo.Group) {
-	auth := g.Group("/auth")
-	auth.GET("/me", h.Me)
-}
\ No newline at end of file
+// loginThrottledError returns the response for a login attempt blocked by the
+// failed-login limits, or nil if err is not a user.LoginThrottledError
+func loginThrottledError(c echo.Context, err error) error {
+	var throttled *user.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return nil
+	}
+
+	// Tell the client when it may try again
+	retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+	c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	if errors.Is(err, user.ErrAccountLocked) {
+		return appErrors.TooManyRequests("Your account is temporarily locked after too many failed logins", err)
+	}
+	return appErrors.TooManyRequests("Too many failed login attempts, please try again later", err)
+}
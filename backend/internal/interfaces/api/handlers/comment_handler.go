@@ -1,33 +1,48 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/labstack/echo/v4"
 
 	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/anchor"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/comment"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/notification"
 )
 
 type CommentHandler struct {
-	createCommentUseCase comment.CreateCommentUseCase
-	updateCommentUseCase comment.UpdateCommentUseCase
-	deleteCommentUseCase comment.DeleteCommentUseCase
+	createCommentUseCase  comment.CreateCommentUseCase
+	updateCommentUseCase  comment.UpdateCommentUseCase
+	deleteCommentUseCase  comment.DeleteCommentUseCase
+	resolveCommentUseCase comment.ResolveCommentUseCase
+	notifyMentionsUseCase notification.NotifyMentionsUseCase
 }
 
 func NewCommentHandler(
 	createCommentUseCase comment.CreateCommentUseCase,
 	updateCommentUseCase comment.UpdateCommentUseCase,
 	deleteCommentUseCase comment.DeleteCommentUseCase,
+	resolveCommentUseCase comment.ResolveCommentUseCase,
+	notifyMentionsUseCase notification.NotifyMentionsUseCase,
 ) *CommentHandler {
 	return &CommentHandler{
-		createCommentUseCase: createCommentUseCase,
-		updateCommentUseCase: updateCommentUseCase,
-		deleteCommentUseCase: deleteCommentUseCase,
+		createCommentUseCase:  createCommentUseCase,
+		updateCommentUseCase:  updateCommentUseCase,
+		deleteCommentUseCase:  deleteCommentUseCase,
+		resolveCommentUseCase: resolveCommentUseCase,
+		notifyMentionsUseCase: notifyMentionsUseCase,
 	}
 }
 
 // CreateCommentRequest represents the create comment request body
 type CreateCommentRequest struct {
-	Content string `json:"content" validate:"required"`
+	Content     string `json:"content" validate:"required"`
+	AnchorBlock string `json:"anchor_block"`
+	AnchorStart *int   `json:"anchor_start" validate:"required_with=AnchorBlock,omitempty,min=0"`
+	AnchorEnd   *int   `json:"anchor_end" validate:"required_with=AnchorBlock,omitempty,min=0"`
 }
 
 // UpdateCommentRequest represents the update comment request body
@@ -35,9 +50,14 @@ type UpdateCommentRequest struct {
 	Content string `json:"content" validate:"required"`
 }
 
+// ListCommentsRequest represents the list comments request query
+type ListCommentsRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=open resolved"`
+}
+
 // Create handles creating a new comment
 // @Summary Create comment
-// @Description Create a new comment for a knowledge
+// @Description Create a new comment for a knowledge, optionally anchored to a text range of its content
 // @Tags comments
 // @Accept json
 // @Produce json
@@ -47,6 +67,7 @@ type UpdateCommentRequest struct {
 // @Success 201 {object} model.Comment
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
 // @Failure 404 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /knowledge/{knowledge_id}/comments [post]
@@ -72,17 +93,44 @@ func (h *CommentHandler) Create(c echo.Context) error {
 	}
 
 	// Create comment
-	comment, err := h.createCommentUseCase.Execute(comment.CreateCommentInput{
+	newComment, err := h.createCommentUseCase.Execute(comment.CreateCommentInput{
 		Content:     req.Content,
 		AuthorID:    claims.UserID,
 		KnowledgeID: knowledgeID,
 		TenantID:    claims.TenantID,
+		AnchorBlock: req.AnchorBlock,
+		AnchorStart: req.AnchorStart,
+		AnchorEnd:   req.AnchorEnd,
+		Actor:       claims.Subject(),
 	})
 	if err != nil {
+		if errors.Is(err, comment.ErrKnowledgeNotFound) {
+			return appErrors.NotFound("Knowledge not found", err)
+		}
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to comment", err)
+		}
+		if errors.Is(err, comment.ErrDiscussionLocked) {
+			return appErrors.Forbidden("Discussion is locked", err)
+		}
+		if errors.Is(err, anchor.ErrInvalidAnchor) {
+			return appErrors.NewValidationError("Anchor does not match the knowledge content", nil, err)
+		}
 		return appErrors.InternalServerError("Failed to create comment", err)
 	}
 
-	return appErrors.SendCreated(c, comment)
+	// Notify the groups mentioned in the comment
+	err = h.notifyMentionsUseCase.Execute(notification.NotifyMentionsInput{
+		KnowledgeID: knowledgeID,
+		TenantID:    claims.TenantID,
+		AuthorID:    claims.UserID,
+		Text:        newComment.Content,
+	})
+	if err != nil {
+		c.Logger().Warnf("failed to notify mentions: %v", err)
+	}
+
+	return appErrors.SendCreated(c, newComment)
 }
 
 // Update handles updating a comment
@@ -131,28 +179,35 @@ func (h *CommentHandler) Update(c echo.Context) error {
 	// Get repositories
 	repo := c.Get("repositories").(RepositoriesProvider).Comment()
 
-	// Check if comment exists and belongs to the user
-	existingComment, err := repo.FindByID(commentID, claims.TenantID)
+	// Check if comment exists
+	_, err := repo.FindByID(commentID, claims.TenantID)
 	if err != nil {
 		return appErrors.NotFound("Comment not found", err)
 	}
 
-	// Check if user is the author of the comment
-	if existingComment.AuthorID != claims.UserID && claims.Role != "admin" {
-		return appErrors.Forbidden("You don't have permission to update this comment", nil)
-	}
-
 	// Update comment
-	comment, err := h.updateCommentUseCase.Execute(comment.UpdateCommentInput{
+	updatedComment, err := h.updateCommentUseCase.Execute(comment.UpdateCommentInput{
 		ID:       commentID,
 		Content:  req.Content,
+		EditorID: claims.UserID,
 		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
 	})
 	if err != nil {
+		switch {
+		case errors.Is(err, comment.ErrKnowledgeNotFound):
+			return appErrors.NotFound("Knowledge not found", err)
+		case errors.Is(err, authz.ErrForbidden):
+			return appErrors.Forbidden("You don't have permission to update this comment", err)
+		case errors.Is(err, comment.ErrDiscussionLocked):
+			return appErrors.Forbidden("Discussion is locked", err)
+		case errors.Is(err, comment.ErrCommentHidden):
+			return appErrors.Forbidden("Comment has been hidden by a moderator", err)
+		}
 		return appErrors.InternalServerError("Failed to update comment", err)
 	}
 
-	return appErrors.SendOK(c, comment)
+	return appErrors.SendOK(c, updatedComment)
 }
 
 // Delete handles deleting a comment
@@ -191,23 +246,22 @@ func (h *CommentHandler) Delete(c echo.Context) error {
 	// Get repositories
 	repo := c.Get("repositories").(RepositoriesProvider).Comment()
 
-	// Check if comment exists and belongs to the user
-	existingComment, err := repo.FindByID(commentID, claims.TenantID)
+	// Check if comment exists
+	_, err := repo.FindByID(commentID, claims.TenantID)
 	if err != nil {
 		return appErrors.NotFound("Comment not found", err)
 	}
 
-	// Check if user is the author of the comment or an admin
-	if existingComment.AuthorID != claims.UserID && claims.Role != "admin" {
-		return appErrors.Forbidden("You don't have permission to delete this comment", nil)
-	}
-
 	// Delete comment
 	err = h.deleteCommentUseCase.Execute(comment.DeleteCommentInput{
 		ID:       commentID,
 		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
 	})
 	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to delete this comment", err)
+		}
 		return appErrors.InternalServerError("Failed to delete comment", err)
 	}
 
@@ -221,10 +275,12 @@ func (h *CommentHandler) Delete(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param knowledge_id path string true "Knowledge ID"
+// @Param status query string false "Filter by state (open or resolved)"
 // @Security ApiKeyAuth
 // @Success 200 {array} model.Comment
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /knowledge/{knowledge_id}/comments [get]
 func (h *CommentHandler) List(c echo.Context) error {
@@ -233,6 +289,22 @@ func (h *CommentHandler) List(c echo.Context) error {
 		return appErrors.NewValidationError("Knowledge ID is required", nil, nil)
 	}
 
+	var req ListCommentsRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request parameters", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Convert status filter
+	var resolved *bool
+	if req.Status != "" {
+		isResolved := req.Status == "resolved"
+		resolved = &isResolved
+	}
+
 	// Get user claims from context
 	claims := getUserClaims(c)
 	if claims == nil {
@@ -240,10 +312,16 @@ func (h *CommentHandler) List(c echo.Context) error {
 	}
 
 	// Get repositories
-	repo := c.Get("repositories").(RepositoriesProvider).Comment()
+	repos := c.Get("repositories").(RepositoriesProvider)
 
-	// List comments
-	comments, err := repo.FindByKnowledgeID(knowledgeID, claims.TenantID)
+	// Verify the knowledge is visible to the user
+	viewer := authz.Viewer(claims.Subject())
+	if _, err := repos.Knowledge().FindByID(knowledgeID, claims.TenantID, viewer); err != nil {
+		return appErrors.NotFound("Knowledge not found", err)
+	}
+
+	// List comments, hidden ones only for moderators
+	comments, err := repos.Comment().FindByKnowledgeID(knowledgeID, claims.TenantID, resolved, viewer)
 	if err != nil {
 		return appErrors.InternalServerError("Failed to list comments", err)
 	}
@@ -251,11 +329,153 @@ func (h *CommentHandler) List(c echo.Context) error {
 	return appErrors.SendOK(c, comments)
 }
 
-// RegisterRoutes registers the comment routes
-func (h *CommentHandler) RegisterRoutes(g *echo.Group) {
-	knowledge := g.Group("/knowledge/:knowledge_id/comments")
-	knowledge.POST("", h.Create)
-	knowledge.GET("", h.List)
-	knowledge.PUT("/:comment_id", h.Update)
-	knowledge.DELETE("/:comment_id", h.Delete)
+// History handles listing the edit history of a comment
+// @Summary List comment history
+// @Description List previous versions of a comment, newest first
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param knowledge_id path string true "Knowledge ID"
+// @Param comment_id path string true "Comment ID"
+// @Security ApiKeyAuth
+// @Success 200 {array} model.CommentRevision
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /knowledge/{knowledge_id}/comments/{comment_id}/history [get]
+func (h *CommentHandler) History(c echo.Context) error {
+	commentID := c.Param("comment_id")
+	if commentID == "" {
+		return appErrors.NewValidationError("Comment ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Comment()
+
+	// Check if comment exists and is visible to the user
+	existingComment, err := findVisibleComment(c, commentID, claims)
+	if err != nil {
+		return err
+	}
+
+	// Check if user is the author of the comment or a moderator
+	if existingComment.AuthorID != claims.UserID && !claims.HasPermission(model.PermissionCommentsModerate) {
+		return appErrors.Forbidden("You don't have permission to view this comment's history", nil)
+	}
+
+	// List revisions
+	revisions, err := repo.FindRevisions(commentID, claims.TenantID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list comment history", err)
+	}
+
+	return appErrors.SendOK(c, revisions)
+}
+
+// Resolve handles marking a comment as resolved
+// @Summary Resolve comment
+// @Description Mark a review comment as addressed
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param comment_id path string true "Comment ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Comment
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /comments/{comment_id}/resolve [post]
+func (h *CommentHandler) Resolve(c echo.Context) error {
+	return h.setResolved(c, true)
+}
+
+// Unresolve handles reopening a resolved comment
+// @Summary Unresolve comment
+// @Description Reopen a resolved review comment
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param comment_id path string true "Comment ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Comment
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /comments/{comment_id}/unresolve [post]
+func (h *CommentHandler) Unresolve(c echo.Context) error {
+	return h.setResolved(c, false)
+}
+
+func (h *CommentHandler) setResolved(c echo.Context, resolved bool) error {
+	commentID := c.Param("comment_id")
+	if commentID == "" {
+		return appErrors.NewValidationError("Comment ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Comment()
+
+	// Check if comment exists
+	_, err := repo.FindByID(commentID, claims.TenantID)
+	if err != nil {
+		return appErrors.NotFound("Comment not found", err)
+	}
+
+	// Update resolved state. The author of the comment or of the knowledge may resolve it.
+	updated, err := h.resolveCommentUseCase.Execute(comment.ResolveCommentInput{
+		ID:         commentID,
+		Resolved:   resolved,
+		ResolverID: claims.UserID,
+		TenantID:   claims.TenantID,
+		Actor:      claims.Subject(),
+	})
+	if err != nil {
+		if errors.Is(err, comment.ErrKnowledgeNotFound) {
+			return appErrors.NotFound("Knowledge not found", err)
+		}
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to resolve this comment", err)
+		}
+		return appErrors.InternalServerError("Failed to update comment", err)
+	}
+
+	return appErrors.SendOK(c, updated)
+}
+
+// findVisibleComment returns the comment if the user can see it and the
+// knowledge it belongs to, and a not found error otherwise
+func findVisibleComment(c echo.Context, commentID string, claims *Claims) (*model.Comment, error) {
+	repos := c.Get("repositories").(RepositoriesProvider)
+
+	found, err := repos.Comment().FindByID(commentID, claims.TenantID)
+	if err != nil || found == nil {
+		return nil, appErrors.NotFound("Comment not found", err)
+	}
+
+	viewer := authz.Viewer(claims.Subject())
+	if found.Hidden && !viewer.HiddenComments {
+		return nil, appErrors.NotFound("Comment not found", nil)
+	}
+	if _, err := repos.Knowledge().FindByID(found.KnowledgeID, claims.TenantID, viewer); err != nil {
+		return nil, appErrors.NotFound("Comment not found", err)
+	}
+	return found, nil
 }
\ No newline at end of file
@@ -4,18 +4,53 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
 )
 
+// TenantContextKey is the context key of the tenant resolved from the request's host or headers
+const TenantContextKey = "tenant"
+
 // Claims represents the JWT claims
 type Claims struct {
 	UserID   string `json:"user_id"`
 	Email    string `json:"email"`
 	Role     string `json:"role"`
 	TenantID string `json:"tenant_id"`
+	// Permissions of the role, loaded on every request rather than signed
+	// into the token so that role changes apply immediately
+	Permissions []string `json:"-"`
 	jwt.RegisteredClaims
 }
 
+// Subject returns the authorization subject of the claims
+func (c *Claims) Subject() authz.Subject {
+	return authz.Subject{
+		UserID:      c.UserID,
+		TenantID:    c.TenantID,
+		Role:        c.Role,
+		Permissions: c.Permissions,
+	}
+}
+
+// HasPermission reports whether the user's role grants the permission
+func (c *Claims) HasPermission(permission string) bool {
+	return c.Subject().HasPermission(permission)
+}
+
+// IsTwoFactorChallenge reports whether the claims are of a two-factor
+// challenge, which only proves the password step of a login
+func (c *Claims) IsTwoFactorChallenge() bool {
+	for _, audience := range c.Audience {
+		if audience == twoFactorChallengeAudience {
+			return true
+		}
+	}
+	return false
+}
+
 // RepositoriesProvider defines the interface for accessing repositories
 type RepositoriesProvider interface {
 	Tenant() repository.TenantRepository
@@ -23,6 +58,41 @@ type RepositoriesProvider interface {
 	Knowledge() repository.KnowledgeRepository
 	Tag() repository.TagRepository
 	Comment() repository.CommentRepository
+	Moderation() repository.ModerationRepository
+	Invitation() repository.InvitationRepository
+	PasswordReset() repository.PasswordResetRepository
+	Session() repository.SessionRepository
+	RecoveryCode() repository.RecoveryCodeRepository
+	LoginThrottle() repository.LoginThrottleRepository
+	PasswordHistory() repository.PasswordHistoryRepository
+	APIToken() repository.APITokenRepository
+	SSOState() repository.SSOStateRepository
+	Group() repository.GroupRepository
+	Role() repository.RoleRepository
+	Space() repository.SpaceRepository
+	Access() repository.AccessRepository
+	Membership() repository.MembershipRepository
+	TenantDeletion() repository.TenantDeletionRepository
+}
+
+// getRequestTenant returns the tenant resolved from the request, or nil
+func getRequestTenant(c echo.Context) *model.Tenant {
+	tenant, _ := c.Get(TenantContextKey).(*model.Tenant)
+	return tenant
+}
+
+// requestTenantID returns the ID of the tenant resolved from the request. A
+// tenant ID sent by the client is only checked against it and never used on
+// its own, so a body can't pick the tenant of a request on another host.
+func requestTenantID(c echo.Context, tenantID string) (string, error) {
+	tenant := getRequestTenant(c)
+	if tenant == nil {
+		return "", appErrors.BadRequest("Tenant could not be determined from the host or X-Tenant header", nil)
+	}
+	if tenantID != "" && tenantID != tenant.ID {
+		return "", appErrors.NewValidationError("Tenant ID does not match the tenant of the request", nil, nil)
+	}
+	return tenant.ID, nil
 }
 
 // getUserClaims extracts the user claims from the context
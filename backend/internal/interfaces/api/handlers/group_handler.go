@@ -0,0 +1,474 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/group"
+)
+
+const (
+	defaultGroupsPerPage = 20
+	maxGroupsPerPage     = 100
+)
+
+type GroupHandler struct {
+	createGroupUseCase        group.CreateGroupUseCase
+	updateGroupUseCase        group.UpdateGroupUseCase
+	deleteGroupUseCase        group.DeleteGroupUseCase
+	updateGroupMembersUseCase group.UpdateGroupMembersUseCase
+}
+
+func NewGroupHandler(
+	createGroupUseCase group.CreateGroupUseCase,
+	updateGroupUseCase group.UpdateGroupUseCase,
+	deleteGroupUseCase group.DeleteGroupUseCase,
+	updateGroupMembersUseCase group.UpdateGroupMembersUseCase,
+) *GroupHandler {
+	return &GroupHandler{
+		createGroupUseCase:        createGroupUseCase,
+		updateGroupUseCase:        updateGroupUseCase,
+		deleteGroupUseCase:        deleteGroupUseCase,
+		updateGroupMembersUseCase: updateGroupMembersUseCase,
+	}
+}
+
+// ListGroupsRequest represents the list groups request query
+type ListGroupsRequest struct {
+	Query   string `query:"query"`
+	Page    int    `query:"page" validate:"omitempty,min=1"`
+	PerPage int    `query:"per_page" validate:"omitempty,min=1,max=100"`
+}
+
+// CreateGroupRequest represents the create group request body
+type CreateGroupRequest struct {
+	Name      string   `json:"name" validate:"required,max=255"`
+	Handle    string   `json:"handle" validate:"max=100"`
+	Role      string   `json:"role" validate:"max=50"`
+	MemberIDs []string `json:"member_ids"`
+}
+
+// UpdateGroupRequest represents the update group request body. Omitted fields are unchanged.
+type UpdateGroupRequest struct {
+	Name   *string `json:"name" validate:"omitempty,max=255"`
+	Handle *string `json:"handle" validate:"omitempty,max=100"`
+	Role   *string `json:"role" validate:"omitempty,max=50"` // Empty removes the group's role
+}
+
+// GroupMembersRequest represents the add group members request body
+type GroupMembersRequest struct {
+	UserIDs []string `json:"user_ids" validate:"required,min=1"`
+}
+
+// GroupListResponse represents a page of groups
+type GroupListResponse struct {
+	Groups  []*model.Group `json:"groups"`
+	Total   int64          `json:"total"`
+	Page    int            `json:"page"`
+	PerPage int            `json:"per_page"`
+}
+
+// GroupMemberResponse represents a member of a group
+type GroupMemberResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// groupError maps group use case errors to HTTP errors
+func groupError(err error, message string) error {
+	switch {
+	case errors.Is(err, group.ErrGroupNotFound):
+		return appErrors.NotFound("Group not found", err)
+	case errors.Is(err, group.ErrGroupNameTaken):
+		return appErrors.Conflict("A group with this name already exists", err)
+	case errors.Is(err, group.ErrInvalidHandle):
+		return appErrors.NewValidationError("Handle must be lowercase letters and digits separated by hyphens", nil, err)
+	case errors.Is(err, group.ErrHandleTaken):
+		return appErrors.Conflict("A group with this handle already exists", err)
+	case errors.Is(err, group.ErrInvalidRole):
+		return appErrors.NewValidationError("Invalid role", nil, err)
+	case errors.Is(err, group.ErrMemberNotFound):
+		return appErrors.NewValidationError("Members must be users of the tenant", nil, err)
+	case errors.Is(err, authz.ErrForbidden):
+		return appErrors.Forbidden("You can't grant a role with permissions you don't have", err)
+	}
+	return appErrors.InternalServerError(message, err)
+}
+
+// List handles listing the tenant's groups
+// @Summary List groups
+// @Description List groups of the tenant, optionally filtered by name or handle
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param query query string false "Search query"
+// @Param page query int false "Page number (starting at 1)"
+// @Param per_page query int false "Groups per page (max 100)"
+// @Security ApiKeyAuth
+// @Success 200 {object} GroupListResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /groups [get]
+func (h *GroupHandler) List(c echo.Context) error {
+	var req ListGroupsRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request parameters", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Apply pagination defaults
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.PerPage == 0 {
+		req.PerPage = defaultGroupsPerPage
+	}
+	if req.PerPage > maxGroupsPerPage {
+		req.PerPage = maxGroupsPerPage
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Group()
+
+	// Search groups
+	groups, total, err := repo.Search(req.Query, claims.TenantID, (req.Page-1)*req.PerPage, req.PerPage)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list groups", err)
+	}
+
+	return appErrors.SendOK(c, GroupListResponse{
+		Groups:  groups,
+		Total:   total,
+		Page:    req.Page,
+		PerPage: req.PerPage,
+	})
+}
+
+// Get handles getting a group by ID
+// @Summary Get group
+// @Description Get a group by ID
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Group
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Router /groups/{id} [get]
+func (h *GroupHandler) Get(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get group
+	repo := c.Get("repositories").(RepositoriesProvider).Group()
+	found, err := repo.FindByID(id, claims.TenantID)
+	if err != nil {
+		return appErrors.NotFound("Group not found", err)
+	}
+
+	return appErrors.SendOK(c, found)
+}
+
+// Members handles listing the members of a group
+// @Summary List group members
+// @Description List the members of a group
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Security ApiKeyAuth
+// @Success 200 {array} GroupMemberResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /groups/{id}/members [get]
+func (h *GroupHandler) Members(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get group
+	repo := c.Get("repositories").(RepositoriesProvider).Group()
+	if _, err := repo.FindByID(id, claims.TenantID); err != nil {
+		return appErrors.NotFound("Group not found", err)
+	}
+
+	// List members
+	members, err := repo.FindMembers(id, claims.TenantID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list group members", err)
+	}
+
+	response := make([]GroupMemberResponse, 0, len(members))
+	for _, member := range members {
+		response = append(response, GroupMemberResponse{ID: member.ID, Name: member.Name})
+	}
+
+	return appErrors.SendOK(c, response)
+}
+
+// Create handles creating a group
+// @Summary Create group
+// @Description Create a group. The handle used to mention the group is derived from the name if omitted.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param request body CreateGroupRequest true "Group data"
+// @Security ApiKeyAuth
+// @Success 201 {object} model.Group
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /groups [post]
+func (h *GroupHandler) Create(c echo.Context) error {
+	var req CreateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Create group
+	created, err := h.createGroupUseCase.Execute(group.CreateGroupInput{
+		TenantID:  claims.TenantID,
+		Name:      req.Name,
+		Handle:    req.Handle,
+		Role:      req.Role,
+		MemberIDs: req.MemberIDs,
+		Actor:     claims.Subject(),
+	})
+	if err != nil {
+		return groupError(err, "Failed to create group")
+	}
+
+	return appErrors.SendCreated(c, created)
+}
+
+// Update handles updating a group
+// @Summary Update group
+// @Description Rename a group, change its handle or the role its members get
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param request body UpdateGroupRequest true "Group data"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Group
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /groups/{id} [put]
+func (h *GroupHandler) Update(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req UpdateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Update group
+	updated, err := h.updateGroupUseCase.Execute(group.UpdateGroupInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+		Name:     req.Name,
+		Handle:   req.Handle,
+		Role:     req.Role,
+		Actor:    claims.Subject(),
+	})
+	if err != nil {
+		return groupError(err, "Failed to update group")
+	}
+
+	return appErrors.SendOK(c, updated)
+}
+
+// Delete handles deleting a group
+// @Summary Delete group
+// @Description Delete a group. Its members are kept, but lose the access and the role granted to the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Security ApiKeyAuth
+// @Success 204 {object} nil
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /groups/{id} [delete]
+func (h *GroupHandler) Delete(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Delete group
+	err := h.deleteGroupUseCase.Execute(group.DeleteGroupInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		return groupError(err, "Failed to delete group")
+	}
+
+	return appErrors.SendNoContent(c)
+}
+
+// AddMembers handles adding members to a group
+// @Summary Add group members
+// @Description Add users to a group. Users who are already members are ignored.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param request body GroupMembersRequest true "Users to add"
+// @Security ApiKeyAuth
+// @Success 204 {object} nil
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /groups/{id}/members [post]
+func (h *GroupHandler) AddMembers(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req GroupMembersRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Add members
+	err := h.updateGroupMembersUseCase.Execute(group.UpdateGroupMembersInput{
+		GroupID:  id,
+		TenantID: claims.TenantID,
+		Add:      req.UserIDs,
+		Actor:    claims.Subject(),
+	})
+	if err != nil {
+		return groupError(err, "Failed to add group members")
+	}
+
+	return appErrors.SendNoContent(c)
+}
+
+// RemoveMember handles removing a member from a group
+// @Summary Remove group member
+// @Description Remove a user from a group
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param user_id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 204 {object} nil
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /groups/{id}/members/{user_id} [delete]
+func (h *GroupHandler) RemoveMember(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Param("user_id")
+	if id == "" || userID == "" {
+		return appErrors.NewValidationError("ID and user ID are required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Remove member
+	err := h.updateGroupMembersUseCase.Execute(group.UpdateGroupMembersInput{
+		GroupID:  id,
+		TenantID: claims.TenantID,
+		Remove:   []string{userID},
+		Actor:    claims.Subject(),
+	})
+	if err != nil {
+		return groupError(err, "Failed to remove group member")
+	}
+
+	return appErrors.SendNoContent(c)
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/invitation"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
+)
+
+type InvitationHandler struct {
+	createInvitationUseCase invitation.CreateInvitationUseCase
+	revokeInvitationUseCase invitation.RevokeInvitationUseCase
+	acceptInvitationUseCase invitation.AcceptInvitationUseCase
+	createSessionUseCase    session.CreateSessionUseCase
+}
+
+func NewInvitationHandler(
+	createInvitationUseCase invitation.CreateInvitationUseCase,
+	revokeInvitationUseCase invitation.RevokeInvitationUseCase,
+	acceptInvitationUseCase invitation.AcceptInvitationUseCase,
+	createSessionUseCase session.CreateSessionUseCase,
+) *InvitationHandler {
+	return &InvitationHandler{
+		createInvitationUseCase: createInvitationUseCase,
+		revokeInvitationUseCase: revokeInvitationUseCase,
+		acceptInvitationUseCase: acceptInvitationUseCase,
+		createSessionUseCase:    createSessionUseCase,
+	}
+}
+
+// CreateInvitationRequest represents the create invitation request body
+type CreateInvitationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,max=50"`
+}
+
+// ListInvitationsRequest represents the list invitations request query
+type ListInvitationsRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending accepted revoked"`
+}
+
+// AcceptInvitationRequest represents the accept invitation request body
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Create handles inviting someone to the tenant
+// @Summary Create invitation
+// @Description Invite an email address to join the tenant with a role. The invitation link is sent by email.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param request body CreateInvitationRequest true "Invitation data"
+// @Security ApiKeyAuth
+// @Success 201 {object} model.Invitation
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /invitations [post]
+func (h *InvitationHandler) Create(c echo.Context) error {
+	var req CreateInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Create invitation
+	newInvitation, err := h.createInvitationUseCase.Execute(invitation.CreateInvitationInput{
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: claims.UserID,
+		TenantID:  claims.TenantID,
+		Actor:     claims.Subject(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, invitation.ErrAlreadyMember):
+			return appErrors.Conflict("A user with this email already exists", err)
+		case errors.Is(err, invitation.ErrInvalidRole):
+			return appErrors.NewValidationError("Invalid role", nil, err)
+		case errors.Is(err, authz.ErrForbidden):
+			return appErrors.Forbidden("You can't invite users to a role with permissions you don't have", err)
+		}
+		return appErrors.InternalServerError("Failed to create invitation", err)
+	}
+
+	return appErrors.SendCreated(c, newInvitation)
+}
+
+// List handles listing the tenant's invitations
+// @Summary List invitations
+// @Description List the invitations of the tenant, optionally filtered by status
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param status query string false "Invitation status (pending, accepted or revoked)"
+// @Security ApiKeyAuth
+// @Success 200 {array} model.Invitation
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /invitations [get]
+func (h *InvitationHandler) List(c echo.Context) error {
+	var req ListInvitationsRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request parameters", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Invitation()
+
+	// Get invitations
+	invitations, err := repo.FindByTenantID(claims.TenantID, req.Status)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list invitations", err)
+	}
+
+	return appErrors.SendOK(c, invitations)
+}
+
+// Revoke handles revoking an invitation
+// @Summary Revoke invitation
+// @Description Revoke a pending invitation so that its link can no longer be used
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Invitation
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /invitations/{id} [delete]
+func (h *InvitationHandler) Revoke(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Revoke invitation
+	revokedInvitation, err := h.revokeInvitationUseCase.Execute(invitation.RevokeInvitationInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		if errors.Is(err, invitation.ErrInvitationNotPending) {
+			return appErrors.Conflict("Invitation is no longer pending", err)
+		}
+		return appErrors.InternalServerError("Failed to revoke invitation", err)
+	}
+
+	return appErrors.SendOK(c, revokedInvitation)
+}
+
+// Accept handles accepting an invitation
+// @Summary Accept invitation
+// @Description Create the invited user's account and return a JWT token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body AcceptInvitationRequest true "Invitation token and account data"
+// @Success 201 {object} TokenResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/accept-invite [post]
+func (h *InvitationHandler) Accept(c echo.Context) error {
+	var req AcceptInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Accept invitation
+	invitedUser, err := h.acceptInvitationUseCase.Execute(invitation.AcceptInvitationInput{
+		Token:    req.Token,
+		Name:     req.Name,
+		Password: req.Password,
+	})
+	if err != nil {
+		if policyErr := passwordPolicyError(err, "password"); policyErr != nil {
+			return policyErr
+		}
+		switch {
+		case errors.Is(err, invitation.ErrInvalidInvitation):
+			return appErrors.BadRequest("Invalid invitation token", err)
+		case errors.Is(err, invitation.ErrInvitationExpired):
+			return appErrors.BadRequest("Invitation has expired", err)
+		case errors.Is(err, invitation.ErrInvitationNotPending):
+			return appErrors.BadRequest("Invitation has already been used or revoked", err)
+		}
+		return appErrors.Conflict("User registration failed", err)
+	}
+
+	// Start session
+	response, err := issueTokens(c, h.createSessionUseCase, invitedUser)
+	if err != nil {
+		return sessionError(err)
+	}
+
+	return appErrors.SendCreated(c, response)
+}
@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/labstack/echo/v4"
 
 	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/anchor"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/knowledge"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/notification"
 )
 
 type KnowledgeHandler struct {
@@ -14,7 +19,8 @@ type KnowledgeHandler struct {
 	updateKnowledgeUseCase knowledge.UpdateKnowledgeUseCase
 	deleteKnowledgeUseCase knowledge.DeleteKnowledgeUseCase
 	searchKnowledgeUseCase knowledge.SearchKnowledgeUseCase
-	knowledgeRepository    repository.KnowledgeRepository
+	moveKnowledgeUseCase   knowledge.MoveKnowledgeUseCase
+	notifyMentionsUseCase  notification.NotifyMentionsUseCase
 }
 
 func NewKnowledgeHandler(
@@ -22,14 +28,16 @@ func NewKnowledgeHandler(
 	updateKnowledgeUseCase knowledge.UpdateKnowledgeUseCase,
 	deleteKnowledgeUseCase knowledge.DeleteKnowledgeUseCase,
 	searchKnowledgeUseCase knowledge.SearchKnowledgeUseCase,
-	knowledgeRepository repository.KnowledgeRepository,
+	moveKnowledgeUseCase knowledge.MoveKnowledgeUseCase,
+	notifyMentionsUseCase notification.NotifyMentionsUseCase,
 ) *KnowledgeHandler {
 	return &KnowledgeHandler{
 		createKnowledgeUseCase: createKnowledgeUseCase,
 		updateKnowledgeUseCase: updateKnowledgeUseCase,
 		deleteKnowledgeUseCase: deleteKnowledgeUseCase,
 		searchKnowledgeUseCase: searchKnowledgeUseCase,
-		knowledgeRepository:    knowledgeRepository,
+		moveKnowledgeUseCase:   moveKnowledgeUseCase,
+		notifyMentionsUseCase:  notifyMentionsUseCase,
 	}
 }
 
@@ -39,6 +47,8 @@ type CreateKnowledgeRequest struct {
 	Content string   `json:"content" validate:"required"`
 	Status  string   `json:"status"`
 	TagIDs  []string `json:"tag_ids"`
+	SpaceID string   `json:"space_id"`
+	Private bool     `json:"private"` // Visible only to the author and the users it is shared with
 }
 
 // UpdateKnowledgeRequest represents the update knowledge request body
@@ -53,6 +63,12 @@ type SearchKnowledgeRequest struct {
 	Query    string   `query:"query"`
 	TagIDs   []string `query:"tag_ids"`
 	AuthorID string   `query:"author_id"`
+	SpaceID  string   `query:"space_id"`
+}
+
+// MoveKnowledgeRequest represents the move knowledge request body
+type MoveKnowledgeRequest struct {
+	SpaceID string `json:"space_id"` // Empty moves the knowledge out of its space
 }
 
 // Create handles creating a new knowledge
@@ -66,6 +82,7 @@ type SearchKnowledgeRequest struct {
 // @Success 201 {object} model.Knowledge
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /knowledge [post]
 func (h *KnowledgeHandler) Create(c echo.Context) error {
@@ -85,19 +102,31 @@ func (h *KnowledgeHandler) Create(c echo.Context) error {
 	}
 
 	// Create knowledge
-	knowledge, err := h.createKnowledgeUseCase.Execute(knowledge.CreateKnowledgeInput{
+	created, err := h.createKnowledgeUseCase.Execute(knowledge.CreateKnowledgeInput{
 		Title:    req.Title,
 		Content:  req.Content,
 		Status:   req.Status,
 		AuthorID: claims.UserID,
 		TenantID: claims.TenantID,
 		TagIDs:   req.TagIDs,
+		SpaceID:  req.SpaceID,
+		Private:  req.Private,
+		Actor:    claims.Subject(),
 	})
 	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to create knowledge", err)
+		}
+		if errors.Is(err, knowledge.ErrSpaceNotFound) {
+			return appErrors.NewValidationError("Space not found", nil, err)
+		}
 		return appErrors.InternalServerError("Failed to create knowledge", err)
 	}
 
-	return appErrors.SendCreated(c, knowledge)
+	// Notify the groups mentioned in the content
+	h.notifyMentions(c, created, "")
+
+	return appErrors.SendCreated(c, created)
 }
 
 // Get handles getting a knowledge by ID
@@ -126,15 +155,12 @@ func (h *KnowledgeHandler) Get(c echo.Context) error {
 		return appErrors.Unauthorized("Authentication required", nil)
 	}
 
-	// Get knowledge by ID directly from repository
+	// Get knowledge by ID directly from repository, with hidden comments only for moderators
 	var knowledgeEntry *model.Knowledge
-	knowledgeEntry, err := h.knowledgeRepository.FindByID(id, claims.TenantID)
-	if err != nil {
-		return appErrors.InternalServerError("Failed to get knowledge", err)
-	}
-
-	if knowledgeEntry == nil {
-		return appErrors.NotFound("Knowledge not found", nil)
+	knowledgeEntry, err := c.Get("repositories").(RepositoriesProvider).Knowledge().FindByID(id, claims.TenantID, authz.Viewer(claims.Subject()))
+	if err != nil || knowledgeEntry == nil {
+		// Knowledge the user has no access to is reported as not found
+		return appErrors.NotFound("Knowledge not found", err)
 	}
 
 	return appErrors.SendOK(c, knowledgeEntry)
@@ -152,6 +178,7 @@ func (h *KnowledgeHandler) Get(c echo.Context) error {
 // @Success 200 {object} model.Knowledge
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
 // @Failure 404 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /knowledge/{id} [put]
@@ -176,19 +203,50 @@ func (h *KnowledgeHandler) Update(c echo.Context) error {
 		return appErrors.Unauthorized("Authentication required", nil)
 	}
 
+	// Keep the previous content so that only new mentions are notified
+	previousContent := ""
+	if previous, err := c.Get("repositories").(RepositoriesProvider).Knowledge().FindByID(id, claims.TenantID, repository.Viewer{}); err == nil && previous != nil {
+		previousContent = previous.Content
+	}
+
 	// Update knowledge
-	knowledge, err := h.updateKnowledgeUseCase.Execute(knowledge.UpdateKnowledgeInput{
+	updated, err := h.updateKnowledgeUseCase.Execute(knowledge.UpdateKnowledgeInput{
 		ID:       id,
 		Title:    req.Title,
 		Content:  req.Content,
 		TenantID: claims.TenantID,
 		TagIDs:   req.TagIDs,
+		Actor:    claims.Subject(),
 	})
 	if err != nil {
+		if errors.Is(err, knowledge.ErrKnowledgeNotFound) {
+			return appErrors.NotFound("Knowledge not found", err)
+		}
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to update this knowledge", err)
+		}
 		return appErrors.InternalServerError("Failed to update knowledge", err)
 	}
 
-	return appErrors.SendOK(c, knowledge)
+	// Notify the groups newly mentioned in the content
+	h.notifyMentions(c, updated, previousContent)
+
+	return appErrors.SendOK(c, updated)
+}
+
+// notifyMentions notifies the groups mentioned in the knowledge content but
+// not in its previous content. Failures don't fail the request.
+func (h *KnowledgeHandler) notifyMentions(c echo.Context, entry *model.Knowledge, previousContent string) {
+	err := h.notifyMentionsUseCase.Execute(notification.NotifyMentionsInput{
+		KnowledgeID:  entry.ID,
+		TenantID:     entry.TenantID,
+		AuthorID:     getUserClaims(c).UserID,
+		Text:         entry.Content,
+		PreviousText: previousContent,
+	})
+	if err != nil {
+		c.Logger().Warnf("failed to notify mentions: %v", err)
+	}
 }
 
 // Delete handles deleting a knowledge
@@ -202,6 +260,7 @@ func (h *KnowledgeHandler) Update(c echo.Context) error {
 // @Success 204 {object} nil
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /knowledge/{id} [delete]
 func (h *KnowledgeHandler) Delete(c echo.Context) error {
@@ -220,8 +279,15 @@ func (h *KnowledgeHandler) Delete(c echo.Context) error {
 	err := h.deleteKnowledgeUseCase.Execute(knowledge.DeleteKnowledgeInput{
 		ID:       id,
 		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
 	})
 	if err != nil {
+		if errors.Is(err, knowledge.ErrKnowledgeNotFound) {
+			return appErrors.NotFound("Knowledge not found", err)
+		}
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to delete this knowledge", err)
+		}
 		return appErrors.InternalServerError("Failed to delete knowledge", err)
 	}
 
@@ -237,6 +303,7 @@ func (h *KnowledgeHandler) Delete(c echo.Context) error {
 // @Param query query string false "Search query"
 // @Param tag_ids query []string false "Tag IDs"
 // @Param author_id query string false "Author ID"
+// @Param space_id query string false "Space ID"
 // @Security ApiKeyAuth
 // @Success 200 {array} model.Knowledge
 // @Failure 400 {object} appErrors.ErrorResponse
@@ -261,6 +328,8 @@ func (h *KnowledgeHandler) Search(c echo.Context) error {
 		TenantID: claims.TenantID,
 		TagIDs:   req.TagIDs,
 		AuthorID: req.AuthorID,
+		SpaceID:  req.SpaceID,
+		Viewer:   authz.Viewer(claims.Subject()),
 	})
 	if err != nil {
 		return appErrors.InternalServerError("Failed to search knowledge", err)
@@ -269,12 +338,91 @@ func (h *KnowledgeHandler) Search(c echo.Context) error {
 	return appErrors.SendOK(c, knowledges)
 }
 
-// RegisterRoutes registers the knowledge routes
-func (h *KnowledgeHandler) RegisterRoutes(g *echo.Group) {
-	knowledge := g.Group("/knowledge")
-	knowledge.POST("", h.Create)
-	knowledge.GET("", h.Search)
-	knowledge.GET("/:id", h.Get)
-	knowledge.PUT("/:id", h.Update)
-	knowledge.DELETE("/:id", h.Delete)
+// Move handles moving a knowledge to another space
+// @Summary Move knowledge
+// @Description Move a knowledge to another space, or out of its space. The default tags of the new space are added.
+// @Tags knowledge
+// @Accept json
+// @Produce json
+// @Param id path string true "Knowledge ID"
+// @Param request body MoveKnowledgeRequest true "Target space"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Knowledge
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /knowledge/{id}/move [post]
+func (h *KnowledgeHandler) Move(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req MoveKnowledgeRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Move knowledge
+	moved, err := h.moveKnowledgeUseCase.Execute(knowledge.MoveKnowledgeInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+		SpaceID:  req.SpaceID,
+		Actor:    claims.Subject(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, knowledge.ErrKnowledgeNotFound):
+			return appErrors.NotFound("Knowledge not found", err)
+		case errors.Is(err, knowledge.ErrSpaceNotFound):
+			return appErrors.NewValidationError("Space not found", nil, err)
+		case errors.Is(err, authz.ErrForbidden):
+			return appErrors.Forbidden("You don't have permission to move this knowledge", err)
+		}
+		return appErrors.InternalServerError("Failed to move knowledge", err)
+	}
+
+	return appErrors.SendOK(c, moved)
+}
+
+// Blocks handles listing the anchorable blocks of a knowledge
+// @Summary List knowledge blocks
+// @Description List the headings and paragraphs of a knowledge that inline comments can be anchored to
+// @Tags knowledge
+// @Accept json
+// @Produce json
+// @Param id path string true "Knowledge ID"
+// @Security ApiKeyAuth
+// @Success 200 {array} anchor.Block
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Router /knowledge/{id}/blocks [get]
+func (h *KnowledgeHandler) Blocks(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get knowledge by ID directly from repository
+	knowledgeEntry, err := c.Get("repositories").(RepositoriesProvider).Knowledge().FindByID(id, claims.TenantID, authz.Viewer(claims.Subject()))
+	if err != nil {
+		return appErrors.NotFound("Knowledge not found", err)
+	}
+
+	return appErrors.SendOK(c, anchor.Blocks(knowledgeEntry.Content))
 }
\ No newline at end of file
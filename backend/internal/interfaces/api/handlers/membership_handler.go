@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/invitation"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/membership"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
+)
+
+type MembershipHandler struct {
+	listMembershipsUseCase membership.ListMembershipsUseCase
+	switchTenantUseCase    membership.SwitchTenantUseCase
+	joinTenantUseCase      membership.JoinTenantUseCase
+	createSessionUseCase   session.CreateSessionUseCase
+}
+
+func NewMembershipHandler(
+	listMembershipsUseCase membership.ListMembershipsUseCase,
+	switchTenantUseCase membership.SwitchTenantUseCase,
+	joinTenantUseCase membership.JoinTenantUseCase,
+	createSessionUseCase session.CreateSessionUseCase,
+) *MembershipHandler {
+	return &MembershipHandler{
+		listMembershipsUseCase: listMembershipsUseCase,
+		switchTenantUseCase:    switchTenantUseCase,
+		joinTenantUseCase:      joinTenantUseCase,
+		createSessionUseCase:   createSessionUseCase,
+	}
+}
+
+// SwitchTenantRequest represents the switch tenant request body
+type SwitchTenantRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+}
+
+// JoinTenantRequest represents the join tenant request body
+type JoinTenantRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// TenantMembershipResponse represents a tenant the current user belongs to
+type TenantMembershipResponse struct {
+	TenantID   string `json:"tenant_id"`
+	TenantName string `json:"tenant_name"`
+	Domain     string `json:"domain"`
+	UserID     string `json:"user_id"` // Account of the user in the tenant
+	Role       string `json:"role"`
+	Current    bool   `json:"current"` // Whether the request was made in the tenant
+}
+
+// newTenantMembershipResponse builds the response of a membership
+func newTenantMembershipResponse(m *membership.TenantMembership, currentTenantID string) TenantMembershipResponse {
+	return TenantMembershipResponse{
+		TenantID:   m.Tenant.ID,
+		TenantName: m.Tenant.Name,
+		Domain:     m.Tenant.Domain,
+		UserID:     m.User.ID,
+		Role:       m.User.Role,
+		Current:    m.Tenant.ID == currentTenantID,
+	}
+}
+
+// List handles listing the tenants the current user belongs to
+// @Summary List my tenants
+// @Description List the tenants the current user belongs to, with their role in each
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} TenantMembershipResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/me/tenants [get]
+func (h *MembershipHandler) List(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// List memberships
+	memberships, err := h.listMembershipsUseCase.Execute(membership.ListMembershipsInput{
+		UserID:   claims.UserID,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list tenants", err)
+	}
+
+	response := make([]TenantMembershipResponse, 0, len(memberships))
+	for _, m := range memberships {
+		response = append(response, newTenantMembershipResponse(m, claims.TenantID))
+	}
+
+	return appErrors.SendOK(c, response)
+}
+
+// Join handles joining another tenant with an invitation
+// @Summary Join tenant
+// @Description Accept an invitation to another tenant with the current user, who can then switch to it
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body JoinTenantRequest true "Invitation token"
+// @Security ApiKeyAuth
+// @Success 201 {object} TenantMembershipResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/me/tenants [post]
+func (h *MembershipHandler) Join(c echo.Context) error {
+	var req JoinTenantRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Join tenant
+	joined, err := h.joinTenantUseCase.Execute(membership.JoinTenantInput{
+		UserID:   claims.UserID,
+		TenantID: claims.TenantID,
+		Token:    req.Token,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, invitation.ErrInvalidInvitation):
+			return appErrors.BadRequest("Invalid invitation token", err)
+		case errors.Is(err, invitation.ErrInvitationExpired):
+			return appErrors.BadRequest("Invitation has expired", err)
+		case errors.Is(err, invitation.ErrInvitationNotPending):
+			return appErrors.BadRequest("Invitation has already been used or revoked", err)
+		case errors.Is(err, membership.ErrEmailMismatch):
+			return appErrors.Forbidden("Invitation was sent to another email address", err)
+		case errors.Is(err, membership.ErrAlreadyMember):
+			return appErrors.Conflict("You are already a member of this tenant", err)
+		}
+		return appErrors.InternalServerError("Failed to join tenant", err)
+	}
+
+	return appErrors.SendCreated(c, newTenantMembershipResponse(joined, claims.TenantID))
+}
+
+// Switch handles switching to another tenant of the current user
+// @Summary Switch tenant
+// @Description Start a session with the current user's account in another tenant they belong to and return its tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body SwitchTenantRequest true "Tenant to switch to"
+// @Security ApiKeyAuth
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/switch-tenant [post]
+func (h *MembershipHandler) Switch(c echo.Context) error {
+	var req SwitchTenantRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Find account in the tenant
+	account, err := h.switchTenantUseCase.Execute(membership.SwitchTenantInput{
+		UserID:         claims.UserID,
+		TenantID:       claims.TenantID,
+		TargetTenantID: req.TenantID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, membership.ErrNotMember):
+			return appErrors.NotFound("You are not a member of this tenant", err)
+		case errors.Is(err, membership.ErrAccountDeactivated):
+			return appErrors.Forbidden("Your account in this tenant has been deactivated", err)
+		case errors.Is(err, membership.ErrSignInRequired):
+			return appErrors.Forbidden("This tenant requires you to sign in to it directly", err)
+		}
+		return appErrors.InternalServerError("Failed to switch tenant", err)
+	}
+
+	// Start session
+	response, err := issueTokens(c, h.createSessionUseCase, account)
+	if err != nil {
+		return sessionError(err)
+	}
+
+	return appErrors.SendOK(c, response)
+}
+
+// RegisterRoutes registers the membership routes
+func (h *MembershipHandler) RegisterRoutes(g *echo.Group) {
+	g.GET("/auth/me/tenants", h.List)
+	g.POST("/auth/me/tenants", h.Join)
+	g.POST("/auth/switch-tenant", h.Switch)
+}
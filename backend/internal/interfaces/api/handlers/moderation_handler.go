@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/moderation"
+)
+
+type ModerationHandler struct {
+	reportCommentUseCase  moderation.ReportCommentUseCase
+	hideCommentUseCase    moderation.HideCommentUseCase
+	lockDiscussionUseCase moderation.LockDiscussionUseCase
+	dismissReportUseCase  moderation.DismissReportUseCase
+}
+
+func NewModerationHandler(
+	reportCommentUseCase moderation.ReportCommentUseCase,
+	hideCommentUseCase moderation.HideCommentUseCase,
+	lockDiscussionUseCase moderation.LockDiscussionUseCase,
+	dismissReportUseCase moderation.DismissReportUseCase,
+) *ModerationHandler {
+	return &ModerationHandler{
+		reportCommentUseCase:  reportCommentUseCase,
+		hideCommentUseCase:    hideCommentUseCase,
+		lockDiscussionUseCase: lockDiscussionUseCase,
+		dismissReportUseCase:  dismissReportUseCase,
+	}
+}
+
+// ReportCommentRequest represents the report comment request body
+type ReportCommentRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// ModerationRequest represents the request body of a moderation action
+type ModerationRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Report handles reporting a comment
+// @Summary Report comment
+// @Description Report a comment to the moderators
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param comment_id path string true "Comment ID"
+// @Param request body ReportCommentRequest true "Report data"
+// @Security ApiKeyAuth
+// @Success 201 {object} model.CommentReport
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /comments/{comment_id}/report [post]
+func (h *ModerationHandler) Report(c echo.Context) error {
+	commentID := c.Param("comment_id")
+	if commentID == "" {
+		return appErrors.NewValidationError("Comment ID is required", nil, nil)
+	}
+
+	var req ReportCommentRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Check if comment exists and is visible to the user
+	if _, err := findVisibleComment(c, commentID, claims); err != nil {
+		return err
+	}
+
+	// Report comment
+	report, err := h.reportCommentUseCase.Execute(moderation.ReportCommentInput{
+		CommentID:  commentID,
+		ReporterID: claims.UserID,
+		Reason:     req.Reason,
+		TenantID:   claims.TenantID,
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to report comment", err)
+	}
+
+	return appErrors.SendCreated(c, report)
+}
+
+// Hide handles hiding a comment
+// @Summary Hide comment
+// @Description Hide a comment from non-admin users
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param comment_id path string true "Comment ID"
+// @Param request body ModerationRequest true "Moderation reason"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Comment
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /comments/{comment_id}/hide [post]
+func (h *ModerationHandler) Hide(c echo.Context) error {
+	return h.setCommentHidden(c, true)
+}
+
+// Unhide handles making a hidden comment visible again
+// @Summary Unhide comment
+// @Description Make a hidden comment visible again
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param comment_id path string true "Comment ID"
+// @Param request body ModerationRequest false "Moderation reason"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Comment
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /comments/{comment_id}/unhide [post]
+func (h *ModerationHandler) Unhide(c echo.Context) error {
+	return h.setCommentHidden(c, false)
+}
+
+func (h *ModerationHandler) setCommentHidden(c echo.Context, hidden bool) error {
+	commentID := c.Param("comment_id")
+	if commentID == "" {
+		return appErrors.NewValidationError("Comment ID is required", nil, nil)
+	}
+
+	var req ModerationRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+	if hidden && req.Reason == "" {
+		return appErrors.NewValidationError("Validation failed", map[string]string{"reason": "reason is required"}, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Update comment visibility
+	comment, err := h.hideCommentUseCase.Execute(moderation.HideCommentInput{
+		CommentID: commentID,
+		Hidden:    hidden,
+		ActorID:   claims.UserID,
+		Reason:    req.Reason,
+		TenantID:  claims.TenantID,
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to update comment visibility", err)
+	}
+
+	return appErrors.SendOK(c, comment)
+}
+
+// Lock handles locking the discussion of a knowledge
+// @Summary Lock discussion
+// @Description Prevent new comments from being posted on a knowledge
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Knowledge ID"
+// @Param request body ModerationRequest true "Moderation reason"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Knowledge
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /knowledge/{id}/lock [post]
+func (h *ModerationHandler) Lock(c echo.Context) error {
+	return h.setDiscussionLocked(c, true)
+}
+
+// Unlock handles unlocking the discussion of a knowledge
+// @Summary Unlock discussion
+// @Description Allow new comments to be posted on a knowledge again
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Knowledge ID"
+// @Param request body ModerationRequest false "Moderation reason"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Knowledge
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /knowledge/{id}/unlock [post]
+func (h *ModerationHandler) Unlock(c echo.Context) error {
+	return h.setDiscussionLocked(c, false)
+}
+
+func (h *ModerationHandler) setDiscussionLocked(c echo.Context, locked bool) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req ModerationRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+	if locked && req.Reason == "" {
+		return appErrors.NewValidationError("Validation failed", map[string]string{"reason": "reason is required"}, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Update discussion state
+	knowledge, err := h.lockDiscussionUseCase.Execute(moderation.LockDiscussionInput{
+		KnowledgeID: id,
+		Locked:      locked,
+		ActorID:     claims.UserID,
+		Reason:      req.Reason,
+		TenantID:    claims.TenantID,
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to update discussion state", err)
+	}
+
+	return appErrors.SendOK(c, knowledge)
+}
+
+// Queue handles listing the open comment reports
+// @Summary Moderation queue
+// @Description List open comment reports, oldest first
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} model.CommentReport
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /moderation/queue [get]
+func (h *ModerationHandler) Queue(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Moderation()
+
+	// List open reports
+	reports, err := repo.FindOpenReports(claims.TenantID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list moderation queue", err)
+	}
+
+	return appErrors.SendOK(c, reports)
+}
+
+// DismissReport handles dismissing a comment report
+// @Summary Dismiss report
+// @Description Dismiss a comment report without acting on the comment
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param request body ModerationRequest false "Moderation reason"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.CommentReport
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /moderation/reports/{id}/dismiss [post]
+func (h *ModerationHandler) DismissReport(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req ModerationRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Dismiss report
+	report, err := h.dismissReportUseCase.Execute(moderation.DismissReportInput{
+		ReportID: id,
+		ActorID:  claims.UserID,
+		Reason:   req.Reason,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to dismiss report", err)
+	}
+
+	return appErrors.SendOK(c, report)
+}
+
+// Actions handles listing the moderation log
+// @Summary Moderation log
+// @Description List moderation actions with actor and reason, newest first
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} model.ModerationAction
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /moderation/actions [get]
+func (h *ModerationHandler) Actions(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Moderation()
+
+	// List moderation actions
+	actions, err := repo.FindActions(claims.TenantID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list moderation actions", err)
+	}
+
+	return appErrors.SendOK(c, actions)
+}
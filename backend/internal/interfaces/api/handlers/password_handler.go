@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/user"
+)
+
+type PasswordHandler struct {
+	requestPasswordResetUseCase user.RequestPasswordResetUseCase
+	resetPasswordUseCase        user.ResetPasswordUseCase
+	changePasswordUseCase       user.ChangePasswordUseCase
+	createSessionUseCase        session.CreateSessionUseCase
+}
+
+func NewPasswordHandler(
+	requestPasswordResetUseCase user.RequestPasswordResetUseCase,
+	resetPasswordUseCase user.ResetPasswordUseCase,
+	changePasswordUseCase user.ChangePasswordUseCase,
+	createSessionUseCase session.CreateSessionUseCase,
+) *PasswordHandler {
+	return &PasswordHandler{
+		requestPasswordResetUseCase: requestPasswordResetUseCase,
+		resetPasswordUseCase:        resetPasswordUseCase,
+		changePasswordUseCase:       changePasswordUseCase,
+		createSessionUseCase:        createSessionUseCase,
+	}
+}
+
+// ForgotPasswordRequest represents the forgot password request body
+type ForgotPasswordRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	TenantID string `json:"tenant_id"` // Optional, must match the tenant resolved from the host or X-Tenant header
+}
+
+// ResetPasswordRequest represents the reset password request body
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// ChangePasswordRequest represents the change password request body
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// ForgotPassword handles requesting a password reset email
+// @Summary Forgot password
+// @Description Send a password reset link to the email address if it belongs to an active user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Account email"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/forgot-password [post]
+func (h *PasswordHandler) ForgotPassword(c echo.Context) error {
+	var req ForgotPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Resolve tenant
+	tenantID, err := requestTenantID(c, req.TenantID)
+	if err != nil {
+		return err
+	}
+
+	// Request password reset
+	err = h.requestPasswordResetUseCase.Execute(user.RequestPasswordResetInput{
+		Email:    req.Email,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to request password reset", err)
+	}
+
+	return appErrors.SendAccepted(c, map[string]string{
+		"message": "If the email belongs to an account, a password reset link has been sent",
+	})
+}
+
+// ResetPassword handles setting a new password with a reset token
+// @Summary Reset password
+// @Description Set a new password using a reset token and sign out all existing sessions
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Reset token and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/reset-password [post]
+func (h *PasswordHandler) ResetPassword(c echo.Context) error {
+	var req ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Reset password
+	err := h.resetPasswordUseCase.Execute(user.ResetPasswordInput{
+		Token:    req.Token,
+		Password: req.Password,
+	})
+	if err != nil {
+		if policyErr := passwordPolicyError(err, "password"); policyErr != nil {
+			return policyErr
+		}
+		if errors.Is(err, user.ErrInvalidResetToken) {
+			return appErrors.BadRequest("Invalid or expired password reset token", err)
+		}
+		return appErrors.InternalServerError("Failed to reset password", err)
+	}
+
+	return appErrors.SendNoContent(c)
+}
+
+// ChangePassword handles changing the current user's password
+// @Summary Change password
+// @Description Change the current user's password and sign out all sessions. New tokens are returned for the current device.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ChangePasswordRequest true "Current and new password"
+// @Security ApiKeyAuth
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/change-password [post]
+func (h *PasswordHandler) ChangePassword(c echo.Context) error {
+	var req ChangePasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Change password
+	updatedUser, err := h.changePasswordUseCase.Execute(user.ChangePasswordInput{
+		ID:              claims.UserID,
+		TenantID:        claims.TenantID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		if policyErr := passwordPolicyError(err, "new_password"); policyErr != nil {
+			return policyErr
+		}
+		if errors.Is(err, user.ErrIncorrectPassword) {
+			return appErrors.NewValidationError("Current password is incorrect", map[string]string{
+				"current_password": "Current password is incorrect",
+			}, err)
+		}
+		return appErrors.InternalServerError("Failed to change password", err)
+	}
+
+	// Start a new session for the current device
+	response, err := issueTokens(c, h.createSessionUseCase, updatedUser)
+	if err != nil {
+		return sessionError(err)
+	}
+
+	return appErrors.SendOK(c, response)
+}
+
+// passwordPolicyError turns a password policy violation into a validation
+// error on the given field. It returns nil for other errors.
+func passwordPolicyError(err error, field string) error {
+	var policyErr *user.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+
+	message := "Password " + strings.Join(policyErr.Violations, ", ")
+	return appErrors.NewValidationError("Password does not meet the password policy", map[string]string{
+		field: message,
+	}, err)
+}
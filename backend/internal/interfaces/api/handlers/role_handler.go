@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/role"
+)
+
+type RoleHandler struct {
+	createRoleUseCase role.CreateRoleUseCase
+	updateRoleUseCase role.UpdateRoleUseCase
+	deleteRoleUseCase role.DeleteRoleUseCase
+}
+
+func NewRoleHandler(
+	createRoleUseCase role.CreateRoleUseCase,
+	updateRoleUseCase role.UpdateRoleUseCase,
+	deleteRoleUseCase role.DeleteRoleUseCase,
+) *RoleHandler {
+	return &RoleHandler{
+		createRoleUseCase: createRoleUseCase,
+		updateRoleUseCase: updateRoleUseCase,
+		deleteRoleUseCase: deleteRoleUseCase,
+	}
+}
+
+// CreateRoleRequest represents the create role request body
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+// UpdateRoleRequest represents the update role request body. Omitted fields are unchanged.
+type UpdateRoleRequest struct {
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	Permissions []string `json:"permissions" validate:"omitempty,dive,required"`
+}
+
+// roleError maps role use case errors to HTTP errors
+func roleError(err error, message string) error {
+	switch {
+	case errors.Is(err, role.ErrRoleNotFound):
+		return appErrors.NotFound("Role not found", err)
+	case errors.Is(err, role.ErrRoleNameTaken):
+		return appErrors.Conflict("A role with this name already exists", err)
+	case errors.Is(err, role.ErrInvalidPermission):
+		return appErrors.NewValidationError("Invalid permission", nil, err)
+	case errors.Is(err, role.ErrSystemRole):
+		return appErrors.Forbidden("The admin role can't be changed and default roles can't be deleted", err)
+	case errors.Is(err, role.ErrRoleInUse):
+		return appErrors.Conflict("The role is assigned to users or groups", err)
+	case errors.Is(err, authz.ErrForbidden):
+		return appErrors.Forbidden("You can't grant permissions you don't have", err)
+	}
+	return appErrors.InternalServerError(message, err)
+}
+
+// List handles listing the tenant's roles
+// @Summary List roles
+// @Description List the roles of the tenant with their permissions
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} model.TenantRole
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /roles [get]
+func (h *RoleHandler) List(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get roles repository
+	repo := c.Get("repositories").(RepositoriesProvider).Role()
+
+	// List roles
+	roles, err := repo.FindAll(claims.TenantID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list roles", err)
+	}
+
+	return appErrors.SendOK(c, roles)
+}
+
+// Permissions handles listing the permissions a role can grant
+// @Summary List permissions
+// @Description List the permissions a role can grant
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} string
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Router /roles/permissions [get]
+func (h *RoleHandler) Permissions(c echo.Context) error {
+	return appErrors.SendOK(c, model.Permissions)
+}
+
+// Create handles creating a custom role
+// @Summary Create role
+// @Description Create a role with a set of permissions
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param request body CreateRoleRequest true "Role data"
+// @Security ApiKeyAuth
+// @Success 201 {object} model.TenantRole
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /roles [post]
+func (h *RoleHandler) Create(c echo.Context) error {
+	var req CreateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Create role
+	newRole, err := h.createRoleUseCase.Execute(role.CreateRoleInput{
+		TenantID:    claims.TenantID,
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+		Actor:       claims.Subject(),
+	})
+	if err != nil {
+		return roleError(err, "Failed to create role")
+	}
+
+	return appErrors.SendCreated(c, newRole)
+}
+
+// Update handles updating a role
+// @Summary Update role
+// @Description Update the description and permissions of a role. Roles can't be renamed, and the admin role's permissions can't be changed.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param id path string true "Role ID"
+// @Param request body UpdateRoleRequest true "Role data"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.TenantRole
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /roles/{id} [put]
+func (h *RoleHandler) Update(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req UpdateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Update role
+	updatedRole, err := h.updateRoleUseCase.Execute(role.UpdateRoleInput{
+		ID:          id,
+		TenantID:    claims.TenantID,
+		Description: req.Description,
+		Permissions: req.Permissions,
+		Actor:       claims.Subject(),
+	})
+	if err != nil {
+		return roleError(err, "Failed to update role")
+	}
+
+	return appErrors.SendOK(c, updatedRole)
+}
+
+// Delete handles deleting a custom role
+// @Summary Delete role
+// @Description Delete a custom role that isn't assigned to any user
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param id path string true "Role ID"
+// @Security ApiKeyAuth
+// @Success 204 {object} nil
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /roles/{id} [delete]
+func (h *RoleHandler) Delete(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Delete role
+	err := h.deleteRoleUseCase.Execute(role.DeleteRoleInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		return roleError(err, "Failed to delete role")
+	}
+
+	return appErrors.SendNoContent(c)
+}
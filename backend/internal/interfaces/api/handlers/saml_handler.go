@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/sso"
+)
+
+type SAMLHandler struct {
+	getSAMLMetadataUseCase   sso.GetSAMLMetadataUseCase
+	startSAMLLoginUseCase    sso.StartSAMLLoginUseCase
+	completeSAMLLoginUseCase sso.CompleteSAMLLoginUseCase
+	createSessionUseCase     session.CreateSessionUseCase
+	appCallbackURL           string // Frontend page receiving the tokens
+}
+
+func NewSAMLHandler(
+	getSAMLMetadataUseCase sso.GetSAMLMetadataUseCase,
+	startSAMLLoginUseCase sso.StartSAMLLoginUseCase,
+	completeSAMLLoginUseCase sso.CompleteSAMLLoginUseCase,
+	createSessionUseCase session.CreateSessionUseCase,
+	appCallbackURL string,
+) *SAMLHandler {
+	return &SAMLHandler{
+		getSAMLMetadataUseCase:   getSAMLMetadataUseCase,
+		startSAMLLoginUseCase:    startSAMLLoginUseCase,
+		completeSAMLLoginUseCase: completeSAMLLoginUseCase,
+		createSessionUseCase:     createSessionUseCase,
+		appCallbackURL:           appCallbackURL,
+	}
+}
+
+// Metadata handles getting the SAML service provider metadata of a tenant
+// @Summary Get SAML metadata
+// @Description Get the service provider metadata to register with the tenant's SAML identity provider
+// @Tags auth
+// @Produce xml
+// @Param tenant_id path string true "Tenant ID"
+// @Success 200 {string} string "Service provider metadata"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/saml/{tenant_id}/metadata [get]
+func (h *SAMLHandler) Metadata(c echo.Context) error {
+	tenantID := c.Param("tenant_id")
+	if tenantID == "" {
+		return appErrors.NewValidationError("Tenant ID is required", nil, nil)
+	}
+
+	// Get metadata
+	metadata, err := h.getSAMLMetadataUseCase.Execute(tenantID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to get SAML metadata", err)
+	}
+
+	return c.Blob(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
+
+// Login handles sending the user to the tenant's SAML identity provider
+// @Summary Start SAML login
+// @Description Redirect to the tenant's SAML identity provider with an authentication request
+// @Tags auth
+// @Param tenant_id path string true "Tenant ID"
+// @Success 302 "Redirect to the identity provider"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/saml/{tenant_id}/login [get]
+func (h *SAMLHandler) Login(c echo.Context) error {
+	tenantID := c.Param("tenant_id")
+	if tenantID == "" {
+		return appErrors.NewValidationError("Tenant ID is required", nil, nil)
+	}
+
+	// Start SAML login
+	authURL, err := h.startSAMLLoginUseCase.Execute(tenantID)
+	if err != nil {
+		if errors.Is(err, sso.ErrSSONotEnabled) {
+			return appErrors.NotFound("SAML is not enabled for this tenant", err)
+		}
+		if errors.Is(err, sso.ErrTenantDisabled) {
+			return appErrors.Forbidden("This tenant is scheduled for deletion", err)
+		}
+		return appErrors.InternalServerError("Failed to start SAML login", err)
+	}
+
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// ACS handles the response posted by the identity provider
+// @Summary SAML assertion consumer service
+// @Description Validate the signed SAML response, provision the user on their first login and redirect to the app with a JWT access token and a refresh token in the URL fragment
+// @Tags auth
+// @Accept x-www-form-urlencoded
+// @Param tenant_id path string true "Tenant ID"
+// @Param SAMLResponse formData string true "Base64 encoded SAML response"
+// @Success 302 "Redirect to the app"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/saml/{tenant_id}/acs [post]
+func (h *SAMLHandler) ACS(c echo.Context) error {
+	tenantID := c.Param("tenant_id")
+	if tenantID == "" {
+		return appErrors.NewValidationError("Tenant ID is required", nil, nil)
+	}
+
+	// Complete SAML login
+	samlUser, err := h.completeSAMLLoginUseCase.Execute(sso.CompleteSAMLLoginInput{
+		TenantID:     tenantID,
+		SAMLResponse: c.FormValue("SAMLResponse"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sso.ErrInvalidState):
+			return appErrors.BadRequest("Invalid or expired SAML login, please try again", err)
+		case errors.Is(err, sso.ErrInvalidAssertion):
+			return appErrors.Unauthorized("Invalid SAML response", err)
+		case errors.Is(err, sso.ErrSSONotEnabled):
+			return appErrors.Forbidden("SAML is not enabled for this tenant", err)
+		case errors.Is(err, sso.ErrEmailNotVerified):
+			return appErrors.Forbidden("The identity provider did not provide an email address", err)
+		case errors.Is(err, sso.ErrEmailDomainNotAllowed):
+			return appErrors.Forbidden("Your email domain is not allowed to sign in", err)
+		case errors.Is(err, sso.ErrTenantDisabled):
+			return appErrors.Forbidden("This tenant is scheduled for deletion", err)
+		case errors.Is(err, sso.ErrUserDeactivated):
+			return appErrors.Forbidden("Your account has been deactivated", err)
+		}
+		return appErrors.InternalServerError("Failed to complete SAML login", err)
+	}
+
+	// Start session
+	response, err := issueTokens(c, h.createSessionUseCase, samlUser)
+	if err != nil {
+		return sessionError(err)
+	}
+
+	return redirectWithTokens(c, h.appCallbackURL, response)
+}
+
+// RegisterRoutes registers the public SAML routes
+func (h *SAMLHandler) RegisterRoutes(g *echo.Group) {
+	samlGroup := g.Group("/auth/saml/:tenant_id")
+	samlGroup.GET("/metadata", h.Metadata)
+	samlGroup.GET("/login", h.Login)
+	samlGroup.POST("/acs", h.ACS)
+}
@@ -0,0 +1,684 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/group"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/scim"
+)
+
+// SCIMHandler serves the SCIM 2.0 endpoints the tenant's identity provider
+// uses to provision and deprovision users and groups. Requests are
+// authenticated with a SCIM token by middleware.SCIMAuthMiddleware.
+type SCIMHandler struct {
+	provisionUserUseCase         scim.ProvisionUserUseCase
+	updateProvisionedUserUseCase scim.UpdateProvisionedUserUseCase
+	createGroupUseCase           group.CreateGroupUseCase
+	updateGroupUseCase           group.UpdateGroupUseCase
+	deleteGroupUseCase           group.DeleteGroupUseCase
+	updateGroupMembersUseCase    group.UpdateGroupMembersUseCase
+	baseURL                      string
+}
+
+func NewSCIMHandler(
+	provisionUserUseCase scim.ProvisionUserUseCase,
+	updateProvisionedUserUseCase scim.UpdateProvisionedUserUseCase,
+	createGroupUseCase group.CreateGroupUseCase,
+	updateGroupUseCase group.UpdateGroupUseCase,
+	deleteGroupUseCase group.DeleteGroupUseCase,
+	updateGroupMembersUseCase group.UpdateGroupMembersUseCase,
+	baseURL string,
+) *SCIMHandler {
+	return &SCIMHandler{
+		provisionUserUseCase:         provisionUserUseCase,
+		updateProvisionedUserUseCase: updateProvisionedUserUseCase,
+		createGroupUseCase:           createGroupUseCase,
+		updateGroupUseCase:           updateGroupUseCase,
+		deleteGroupUseCase:           deleteGroupUseCase,
+		updateGroupMembersUseCase:    updateGroupMembersUseCase,
+		baseURL:                      baseURL,
+	}
+}
+
+// ServiceProviderConfig handles describing the supported SCIM features
+// @Summary SCIM service provider configuration
+// @Description Describe the SCIM features supported for provisioning
+// @Tags scim
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /scim/v2/ServiceProviderConfig [get]
+func (h *SCIMHandler) ServiceProviderConfig(c echo.Context) error {
+	supported := func(ok bool) map[string]interface{} {
+		return map[string]interface{}{"supported": ok}
+	}
+	return sendSCIM(c, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{scimProviderConfigSchema},
+		"patch":          supported(true),
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": scimMaxCount},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "SCIM token",
+			"description": "A SCIM token created by a tenant admin, sent as a bearer token",
+			"primary":     true,
+		}},
+	})
+}
+
+// ListUsers handles listing users
+// @Summary List SCIM users
+// @Description List the tenant's users. Supports `userName eq`, `emails.value eq` and `externalId eq` filters.
+// @Tags scim
+// @Produce json
+// @Param filter query string false "Filter, e.g. userName eq \"jane@example.com\""
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Page size"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Users [get]
+func (h *SCIMHandler) ListUsers(c echo.Context) error {
+	claims := getUserClaims(c)
+	filter, err := parseSCIMFilter(c.QueryParam("filter"))
+	if err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidFilter", "Only equality filters on userName, emails.value and externalId are supported")
+	}
+	startIndex, count := scimPagination(c)
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).User()
+
+	var users []*model.User
+	var total int64
+	if filter == nil {
+		users, total, err = repo.Search("", claims.TenantID, startIndex-1, count)
+		if err != nil {
+			return SCIMError(c, http.StatusInternalServerError, "", "Failed to list users")
+		}
+	} else {
+		var user *model.User
+		switch filter.Attribute {
+		case "username", "emails.value", "emails":
+			user, err = repo.FindByEmail(filter.Value, claims.TenantID)
+		case "externalid":
+			user, err = repo.FindByExternalID(filter.Value, claims.TenantID)
+		default:
+			return SCIMError(c, http.StatusBadRequest, "invalidFilter", "Unsupported filter attribute "+filter.Attribute)
+		}
+		if err == nil && user != nil {
+			total = 1
+			if startIndex == 1 && count > 0 {
+				users = append(users, user)
+			}
+		}
+	}
+
+	resources := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, toSCIMUser(user, nil, h.baseURL))
+	}
+	return sendSCIM(c, http.StatusOK, &scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// GetUser handles getting a user
+// @Summary Get SCIM user
+// @Description Get a user of the tenant
+// @Tags scim
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /scim/v2/Users/{id} [get]
+func (h *SCIMHandler) GetUser(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).User()
+
+	// Get user
+	user, err := repo.FindByID(c.Param("id"), claims.TenantID)
+	if err != nil || user == nil {
+		return SCIMError(c, http.StatusNotFound, "", "User not found")
+	}
+
+	return h.sendUser(c, http.StatusOK, user)
+}
+
+// CreateUser handles provisioning a user
+// @Summary Create SCIM user
+// @Description Provision a user without a password. userName is the user's email unless emails has a primary email.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param request body map[string]interface{} true "SCIM user"
+// @Security ApiKeyAuth
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Users [post]
+func (h *SCIMHandler) CreateUser(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	var req scimUserRequest
+	if err := decodeSCIM(c, &req); err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+	if !isEmail(req.email()) {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "userName or a primary email must be an email address")
+	}
+
+	active := req.Active == nil || *req.Active
+	externalID := ""
+	if req.ExternalID != nil {
+		externalID = *req.ExternalID
+	}
+
+	// Provision user
+	user, err := h.provisionUserUseCase.Execute(scim.ProvisionUserInput{
+		TenantID:   claims.TenantID,
+		Email:      req.email(),
+		Name:       req.displayName(),
+		ExternalID: externalID,
+		Role:       primaryValue(req.Roles),
+		Active:     active,
+	})
+	if err != nil {
+		return h.userError(c, err)
+	}
+
+	return h.sendUser(c, http.StatusCreated, user)
+}
+
+// ReplaceUser handles replacing a user's attributes
+// @Summary Replace SCIM user
+// @Description Replace a user's attributes. Setting active to false deactivates the user and signs them out.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body map[string]interface{} true "SCIM user"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Users/{id} [put]
+func (h *SCIMHandler) ReplaceUser(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	var req scimUserRequest
+	if err := decodeSCIM(c, &req); err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+	email := req.email()
+	if !isEmail(email) {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "userName or a primary email must be an email address")
+	}
+	name := req.displayName()
+
+	input := scim.UpdateProvisionedUserInput{
+		ID:         c.Param("id"),
+		TenantID:   claims.TenantID,
+		Email:      &email,
+		Name:       &name,
+		ExternalID: req.ExternalID,
+		Active:     req.Active,
+	}
+	if role := primaryValue(req.Roles); role != "" {
+		input.Role = &role
+	}
+
+	// Update user
+	user, err := h.updateProvisionedUserUseCase.Execute(input)
+	if err != nil {
+		return h.userError(c, err)
+	}
+
+	return h.sendUser(c, http.StatusOK, user)
+}
+
+// PatchUser handles updating some of a user's attributes
+// @Summary Patch SCIM user
+// @Description Apply SCIM PATCH operations to a user. Replacing active with false deactivates the user and signs them out.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body map[string]interface{} true "SCIM PatchOp"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Users/{id} [patch]
+func (h *SCIMHandler) PatchUser(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	var req scimPatchRequest
+	if err := decodeSCIM(c, &req); err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).User()
+
+	// Get user
+	user, err := repo.FindByID(c.Param("id"), claims.TenantID)
+	if err != nil || user == nil {
+		return SCIMError(c, http.StatusNotFound, "", "User not found")
+	}
+
+	// Apply operations
+	patch := newSCIMUserPatch(user)
+	if err := patch.apply(req.Operations); err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "Invalid patch operation")
+	}
+	if !isEmail(patch.email) {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "userName must be an email address")
+	}
+	name := patch.fullName()
+	if name == "" {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "Name is required")
+	}
+
+	// Update user
+	updatedUser, err := h.updateProvisionedUserUseCase.Execute(scim.UpdateProvisionedUserInput{
+		ID:         user.ID,
+		TenantID:   claims.TenantID,
+		Email:      &patch.email,
+		Name:       &name,
+		ExternalID: &patch.externalID,
+		Role:       &patch.role,
+		Active:     &patch.active,
+	})
+	if err != nil {
+		return h.userError(c, err)
+	}
+
+	return h.sendUser(c, http.StatusOK, updatedUser)
+}
+
+// DeleteUser handles deprovisioning a user
+// @Summary Delete SCIM user
+// @Description Deprovision a user. The user is deactivated and signed out rather than deleted, so their content is kept.
+// @Tags scim
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Users/{id} [delete]
+func (h *SCIMHandler) DeleteUser(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	// Deactivate user
+	active := false
+	_, err := h.updateProvisionedUserUseCase.Execute(scim.UpdateProvisionedUserInput{
+		ID:       c.Param("id"),
+		TenantID: claims.TenantID,
+		Active:   &active,
+	})
+	if err != nil {
+		return h.userError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListGroups handles listing groups
+// @Summary List SCIM groups
+// @Description List the tenant's groups. Supports `displayName eq` and `externalId eq` filters.
+// @Tags scim
+// @Produce json
+// @Param filter query string false "Filter, e.g. displayName eq \"Engineering\""
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Page size"
+// @Param excludedAttributes query string false "members to leave out the members"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Groups [get]
+func (h *SCIMHandler) ListGroups(c echo.Context) error {
+	claims := getUserClaims(c)
+	filter, err := parseSCIMFilter(c.QueryParam("filter"))
+	if err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidFilter", "Only equality filters on displayName and externalId are supported")
+	}
+	startIndex, count := scimPagination(c)
+	withMembers := !strings.Contains(strings.ToLower(c.QueryParam("excludedAttributes")), "members")
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Group()
+
+	var groups []*model.Group
+	var total int64
+	if filter == nil {
+		groups, total, err = repo.Search("", claims.TenantID, startIndex-1, count)
+		if err != nil {
+			return SCIMError(c, http.StatusInternalServerError, "", "Failed to list groups")
+		}
+	} else {
+		var found *model.Group
+		switch filter.Attribute {
+		case "displayname":
+			found, err = repo.FindByName(filter.Value, claims.TenantID)
+		case "externalid":
+			found, err = repo.FindByExternalID(filter.Value, claims.TenantID)
+		default:
+			return SCIMError(c, http.StatusBadRequest, "invalidFilter", "Unsupported filter attribute "+filter.Attribute)
+		}
+		if err == nil && found != nil {
+			total = 1
+			if startIndex == 1 && count > 0 {
+				groups = append(groups, found)
+			}
+		}
+	}
+
+	resources := make([]interface{}, 0, len(groups))
+	for _, g := range groups {
+		var members []*model.User
+		if withMembers {
+			members, err = repo.FindMembers(g.ID, claims.TenantID)
+			if err != nil {
+				return SCIMError(c, http.StatusInternalServerError, "", "Failed to list group members")
+			}
+		}
+		resources = append(resources, toSCIMGroup(g, members, h.baseURL))
+	}
+	return sendSCIM(c, http.StatusOK, &scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// GetGroup handles getting a group
+// @Summary Get SCIM group
+// @Description Get a group of the tenant with its members
+// @Tags scim
+// @Produce json
+// @Param id path string true "Group ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /scim/v2/Groups/{id} [get]
+func (h *SCIMHandler) GetGroup(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Group()
+
+	// Get group
+	found, err := repo.FindByID(c.Param("id"), claims.TenantID)
+	if err != nil || found == nil {
+		return SCIMError(c, http.StatusNotFound, "", "Group not found")
+	}
+
+	return h.sendGroup(c, http.StatusOK, found)
+}
+
+// CreateGroup handles provisioning a group
+// @Summary Create SCIM group
+// @Description Provision a group with its members
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param request body map[string]interface{} true "SCIM group"
+// @Security ApiKeyAuth
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Groups [post]
+func (h *SCIMHandler) CreateGroup(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	var req scimGroupRequest
+	if err := decodeSCIM(c, &req); err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+	if req.DisplayName == "" {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+	externalID := ""
+	if req.ExternalID != nil {
+		externalID = *req.ExternalID
+	}
+
+	// Create group
+	createdGroup, err := h.createGroupUseCase.Execute(group.CreateGroupInput{
+		TenantID:   claims.TenantID,
+		Name:       req.DisplayName,
+		ExternalID: externalID,
+		MemberIDs:  req.memberIDs(),
+		Actor:      claims.Subject(),
+	})
+	if err != nil {
+		return h.groupError(c, err)
+	}
+
+	return h.sendGroup(c, http.StatusCreated, createdGroup)
+}
+
+// ReplaceGroup handles replacing a group's attributes and members
+// @Summary Replace SCIM group
+// @Description Replace a group's name and members
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param request body map[string]interface{} true "SCIM group"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Groups/{id} [put]
+func (h *SCIMHandler) ReplaceGroup(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	var req scimGroupRequest
+	if err := decodeSCIM(c, &req); err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+	if req.DisplayName == "" {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+
+	return h.updateGroup(c, claims.TenantID, &scimGroupPatch{
+		name:       &req.DisplayName,
+		externalID: req.ExternalID,
+		add:        req.memberIDs(),
+		replace:    true,
+	})
+}
+
+// PatchGroup handles updating a group's attributes and members
+// @Summary Patch SCIM group
+// @Description Apply SCIM PATCH operations to a group, e.g. to add or remove members
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param request body map[string]interface{} true "SCIM PatchOp"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Groups/{id} [patch]
+func (h *SCIMHandler) PatchGroup(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	var req scimPatchRequest
+	if err := decodeSCIM(c, &req); err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+
+	// Apply operations
+	patch := &scimGroupPatch{}
+	if err := patch.apply(req.Operations); err != nil {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "Invalid patch operation")
+	}
+	if patch.name != nil && *patch.name == "" {
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+
+	return h.updateGroup(c, claims.TenantID, patch)
+}
+
+// DeleteGroup handles deprovisioning a group
+// @Summary Delete SCIM group
+// @Description Delete a group. Its members are kept.
+// @Tags scim
+// @Param id path string true "Group ID"
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /scim/v2/Groups/{id} [delete]
+func (h *SCIMHandler) DeleteGroup(c echo.Context) error {
+	claims := getUserClaims(c)
+
+	// Delete group
+	err := h.deleteGroupUseCase.Execute(group.DeleteGroupInput{
+		ID:       c.Param("id"),
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		return h.groupError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// updateGroup applies changes to the group named in the path and responds with it
+func (h *SCIMHandler) updateGroup(c echo.Context, tenantID string, patch *scimGroupPatch) error {
+	id := c.Param("id")
+	claims := getUserClaims(c)
+
+	// Update attributes
+	updatedGroup, err := h.updateGroupUseCase.Execute(group.UpdateGroupInput{
+		ID:         id,
+		TenantID:   tenantID,
+		Name:       patch.name,
+		ExternalID: patch.externalID,
+		Actor:      claims.Subject(),
+	})
+	if err != nil {
+		return h.groupError(c, err)
+	}
+
+	// Update members
+	if len(patch.add) > 0 || len(patch.remove) > 0 || patch.replace {
+		err = h.updateGroupMembersUseCase.Execute(group.UpdateGroupMembersInput{
+			GroupID:  id,
+			TenantID: tenantID,
+			Add:      patch.add,
+			Remove:   patch.remove,
+			Replace:  patch.replace,
+			Actor:    claims.Subject(),
+		})
+		if err != nil {
+			return h.groupError(c, err)
+		}
+	}
+
+	return h.sendGroup(c, http.StatusOK, updatedGroup)
+}
+
+// sendUser responds with a user and the groups they belong to
+func (h *SCIMHandler) sendUser(c echo.Context, status int, user *model.User) error {
+	groups, err := c.Get("repositories").(RepositoriesProvider).Group().FindByUserID(user.ID, user.TenantID)
+	if err != nil {
+		return SCIMError(c, http.StatusInternalServerError, "", "Failed to get user groups")
+	}
+	return sendSCIM(c, status, toSCIMUser(user, groups, h.baseURL))
+}
+
+// sendGroup responds with a group and its members
+func (h *SCIMHandler) sendGroup(c echo.Context, status int, g *model.Group) error {
+	members, err := c.Get("repositories").(RepositoriesProvider).Group().FindMembers(g.ID, g.TenantID)
+	if err != nil {
+		return SCIMError(c, http.StatusInternalServerError, "", "Failed to get group members")
+	}
+	return sendSCIM(c, status, toSCIMGroup(g, members, h.baseURL))
+}
+
+// userError responds with the SCIM error for a user use case error
+func (h *SCIMHandler) userError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, scim.ErrUserNotFound):
+		return SCIMError(c, http.StatusNotFound, "", "User not found")
+	case errors.Is(err, scim.ErrUserExists):
+		return SCIMError(c, http.StatusConflict, "uniqueness", "A user with this userName already exists")
+	case errors.Is(err, scim.ErrInvalidRole):
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "Role must be one of admin, editor or viewer")
+	default:
+		c.Logger().Errorf("SCIM user request failed: %v", err)
+		return SCIMError(c, http.StatusInternalServerError, "", "Failed to save user")
+	}
+}
+
+// groupError responds with the SCIM error for a group use case error
+func (h *SCIMHandler) groupError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, group.ErrGroupNotFound):
+		return SCIMError(c, http.StatusNotFound, "", "Group not found")
+	case errors.Is(err, group.ErrGroupNameTaken):
+		return SCIMError(c, http.StatusConflict, "uniqueness", "A group with this displayName already exists")
+	case errors.Is(err, group.ErrMemberNotFound):
+		return SCIMError(c, http.StatusBadRequest, "invalidValue", "Members must be users of the tenant")
+	case errors.Is(err, authz.ErrForbidden):
+		return SCIMError(c, http.StatusForbidden, "", "The group's role grants permissions the SCIM token's user doesn't have")
+	default:
+		c.Logger().Errorf("SCIM group request failed: %v", err)
+		return SCIMError(c, http.StatusInternalServerError, "", "Failed to save group")
+	}
+}
+
+// isEmail reports whether the value looks like an email address
+func isEmail(value string) bool {
+	local, domain, ok := strings.Cut(value, "@")
+	return ok && local != "" && strings.Contains(domain, ".") && !strings.ContainsAny(value, " \t\r\n")
+}
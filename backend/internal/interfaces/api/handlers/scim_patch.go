@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// errInvalidPatch is returned for PATCH operations that can't be applied
+var errInvalidPatch = errors.New("invalid patch operation")
+
+// scimMemberPathPattern matches a path selecting a single member, e.g. members[value eq "id"]
+var scimMemberPathPattern = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+("(?:[^"\\]|\\.)*")\s*\]$`)
+
+// scimUserPatch holds the attributes of a user while PATCH operations are applied
+type scimUserPatch struct {
+	email        string
+	name         string
+	givenName    string
+	familyName   string
+	externalID   string
+	role         string
+	active       bool
+	nameChanged  bool
+	partsChanged bool
+}
+
+// newSCIMUserPatch starts a patch from the user's current attributes
+func newSCIMUserPatch(user *model.User) *scimUserPatch {
+	given, family := splitName(user.Name)
+	return &scimUserPatch{
+		email:      user.Email,
+		name:       user.Name,
+		givenName:  given,
+		familyName: family,
+		externalID: user.ExternalID,
+		role:       user.Role,
+		active:     user.IsActive(),
+	}
+}
+
+// apply applies the operations in order. Attributes that aren't stored are
+// ignored, as identity providers send many of them.
+func (p *scimUserPatch) apply(operations []scimPatchOperation) error {
+	for _, op := range operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			var value interface{}
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return errInvalidPatch
+			}
+			if err := p.set(op.Path, value); err != nil {
+				return err
+			}
+		case "remove":
+			if strings.EqualFold(op.Path, "externalId") {
+				p.externalID = ""
+			}
+		default:
+			return errInvalidPatch
+		}
+	}
+	return nil
+}
+
+// set sets the attribute at the path. Without a path the value is an object
+// of attributes.
+func (p *scimUserPatch) set(path string, value interface{}) error {
+	lower := strings.ToLower(path)
+	switch {
+	case lower == "":
+		attributes, ok := value.(map[string]interface{})
+		if !ok {
+			return errInvalidPatch
+		}
+		for key, v := range attributes {
+			if err := p.set(key, v); err != nil {
+				return err
+			}
+		}
+	case lower == "active":
+		active, ok := scimBool(value)
+		if !ok {
+			return errInvalidPatch
+		}
+		p.active = active
+	case lower == "username":
+		p.email = scimString(value)
+	case lower == "displayname", lower == "name.formatted":
+		p.name = scimString(value)
+		p.nameChanged = true
+	case lower == "name.givenname":
+		p.givenName = scimString(value)
+		p.partsChanged = true
+	case lower == "name.familyname":
+		p.familyName = scimString(value)
+		p.partsChanged = true
+	case lower == "name":
+		parts, ok := value.(map[string]interface{})
+		if !ok {
+			return errInvalidPatch
+		}
+		for key, v := range parts {
+			if err := p.set("name."+key, v); err != nil {
+				return err
+			}
+		}
+	case lower == "externalid":
+		p.externalID = scimString(value)
+	case lower == "emails", lower == "roles":
+		values, err := scimMultiValues(value)
+		if err != nil {
+			return err
+		}
+		if v := primaryValue(values); v != "" {
+			if lower == "emails" {
+				p.email = v
+			} else {
+				p.role = v
+			}
+		}
+	case strings.HasPrefix(lower, "emails[") && strings.HasSuffix(lower, "].value"):
+		p.email = scimString(value)
+	case strings.HasPrefix(lower, "roles[") && strings.HasSuffix(lower, "].value"):
+		p.role = scimString(value)
+	}
+	return nil
+}
+
+// fullName returns the patched name
+func (p *scimUserPatch) fullName() string {
+	if p.partsChanged && !p.nameChanged {
+		return strings.TrimSpace(p.givenName + " " + p.familyName)
+	}
+	return p.name
+}
+
+// scimGroupPatch holds the changes to a group while PATCH operations are applied
+type scimGroupPatch struct {
+	name       *string
+	externalID *string
+	add        []string
+	remove     []string
+	replace    bool
+}
+
+// apply applies the operations in order
+func (p *scimGroupPatch) apply(operations []scimPatchOperation) error {
+	for _, op := range operations {
+		lower := strings.ToLower(op.Path)
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			replace := strings.EqualFold(op.Op, "replace")
+			switch lower {
+			case "members":
+				if err := p.setMembers(op.Value, replace); err != nil {
+					return err
+				}
+			case "displayname", "externalid":
+				var value string
+				if err := json.Unmarshal(op.Value, &value); err != nil {
+					return errInvalidPatch
+				}
+				p.setAttribute(lower, value)
+			case "":
+				var attributes map[string]json.RawMessage
+				if err := json.Unmarshal(op.Value, &attributes); err != nil {
+					return errInvalidPatch
+				}
+				for key, raw := range attributes {
+					key = strings.ToLower(key)
+					if key == "members" {
+						if err := p.setMembers(raw, replace); err != nil {
+							return err
+						}
+						continue
+					}
+					var value string
+					if json.Unmarshal(raw, &value) == nil {
+						p.setAttribute(key, value)
+					}
+				}
+			default:
+				return errInvalidPatch
+			}
+		case "remove":
+			if match := scimMemberPathPattern.FindStringSubmatch(op.Path); match != nil {
+				id, err := strconv.Unquote(match[1])
+				if err != nil {
+					return errInvalidPatch
+				}
+				p.removeMembers([]string{id})
+				continue
+			}
+			if lower != "members" {
+				return errInvalidPatch
+			}
+			if len(op.Value) == 0 || string(op.Value) == "null" {
+				// Removing the attribute removes all members
+				p.add = nil
+				p.remove = nil
+				p.replace = true
+				continue
+			}
+			var values []scimMultiValue
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return errInvalidPatch
+			}
+			ids := make([]string, 0, len(values))
+			for _, v := range values {
+				ids = append(ids, v.Value)
+			}
+			p.removeMembers(ids)
+		default:
+			return errInvalidPatch
+		}
+	}
+	return nil
+}
+
+// setAttribute sets a single-valued group attribute. Unknown attributes are ignored.
+func (p *scimGroupPatch) setAttribute(key string, value string) {
+	switch key {
+	case "displayname":
+		p.name = &value
+	case "externalid":
+		p.externalID = &value
+	}
+}
+
+// setMembers adds members, or replaces all members
+func (p *scimGroupPatch) setMembers(raw json.RawMessage, replace bool) error {
+	var values []scimMultiValue
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return errInvalidPatch
+	}
+	if replace {
+		p.add = nil
+		p.remove = nil
+		p.replace = true
+	}
+	for _, v := range values {
+		p.add = append(p.add, v.Value)
+	}
+	return nil
+}
+
+// removeMembers removes members, including ones added by earlier operations
+func (p *scimGroupPatch) removeMembers(ids []string) {
+	removed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+	}
+	add := p.add[:0]
+	for _, id := range p.add {
+		if !removed[id] {
+			add = append(add, id)
+		}
+	}
+	p.add = add
+	p.remove = append(p.remove, ids...)
+}
+
+// scimString returns a string value
+func scimString(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// scimBool returns a boolean value. Some identity providers send booleans as strings.
+func scimBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	default:
+		return false, false
+	}
+}
+
+// scimMultiValues converts a decoded multi-valued attribute. A single item
+// is accepted in place of a list, and primary may be sent as a string.
+func scimMultiValues(value interface{}) ([]scimMultiValue, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	values := make([]scimMultiValue, 0, len(items))
+	for _, item := range items {
+		attributes, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errInvalidPatch
+		}
+		primary, _ := scimBool(attributes["primary"])
+		values = append(values, scimMultiValue{
+			Value:   scimString(attributes["value"]),
+			Type:    scimString(attributes["type"]),
+			Primary: primary,
+		})
+	}
+	return values, nil
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// SCIM schema URNs (RFC 7643, RFC 7644)
+const (
+	scimUserSchema           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimListResponseSchema   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimErrorSchema          = "urn:ietf:params:scim:api:messages:2.0:Error"
+	scimProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// scimContentType is the media type of SCIM requests and responses
+const scimContentType = "application/scim+json"
+
+// SCIM list pagination
+const (
+	scimDefaultCount = 100
+	scimMaxCount     = 200
+)
+
+// scimMeta is the resource metadata of a SCIM resource
+type scimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+// scimName is the name of a SCIM user
+type scimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// scimMultiValue is an item of a multi-valued SCIM attribute such as emails or members
+type scimMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// scimUser is the SCIM representation of a user
+type scimUser struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id"`
+	ExternalID  string           `json:"externalId,omitempty"`
+	UserName    string           `json:"userName"`
+	Name        scimName         `json:"name"`
+	DisplayName string           `json:"displayName"`
+	Emails      []scimMultiValue `json:"emails"`
+	Active      bool             `json:"active"`
+	Roles       []scimMultiValue `json:"roles"`
+	Groups      []scimMultiValue `json:"groups,omitempty"`
+	Meta        scimMeta         `json:"meta"`
+}
+
+// scimGroup is the SCIM representation of a group
+type scimGroup struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id"`
+	ExternalID  string           `json:"externalId,omitempty"`
+	DisplayName string           `json:"displayName"`
+	Members     []scimMultiValue `json:"members,omitempty"`
+	Meta        scimMeta         `json:"meta"`
+}
+
+// scimListResponse is a page of SCIM resources
+type scimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int64         `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// scimErrorResponse is a SCIM error
+type scimErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// scimUserRequest is the body of a SCIM user create or replace request
+type scimUserRequest struct {
+	ExternalID  *string          `json:"externalId"`
+	UserName    string           `json:"userName"`
+	Name        *scimName        `json:"name"`
+	DisplayName string           `json:"displayName"`
+	Emails      []scimMultiValue `json:"emails"`
+	Active      *bool            `json:"active"`
+	Roles       []scimMultiValue `json:"roles"`
+}
+
+// email returns the primary email, falling back to the user name
+func (r *scimUserRequest) email() string {
+	if email := primaryValue(r.Emails); email != "" {
+		return email
+	}
+	return r.UserName
+}
+
+// displayName returns the name to store for the user
+func (r *scimUserRequest) displayName() string {
+	if r.DisplayName != "" {
+		return r.DisplayName
+	}
+	if r.Name != nil {
+		if r.Name.Formatted != "" {
+			return r.Name.Formatted
+		}
+		if name := strings.TrimSpace(r.Name.GivenName + " " + r.Name.FamilyName); name != "" {
+			return name
+		}
+	}
+	return r.UserName
+}
+
+// scimGroupRequest is the body of a SCIM group create or replace request
+type scimGroupRequest struct {
+	ExternalID  *string          `json:"externalId"`
+	DisplayName string           `json:"displayName"`
+	Members     []scimMultiValue `json:"members"`
+}
+
+// memberIDs returns the user IDs of the members
+func (r *scimGroupRequest) memberIDs() []string {
+	ids := make([]string, 0, len(r.Members))
+	for _, member := range r.Members {
+		ids = append(ids, member.Value)
+	}
+	return ids
+}
+
+// scimPatchRequest is the body of a SCIM PATCH request
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+// scimPatchOperation is a single PATCH operation
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// scimFilter is a parsed `attribute eq "value"` filter, the only form identity
+// providers use for provisioning lookups
+type scimFilter struct {
+	Attribute string
+	Value     string
+}
+
+// scimFilterPattern matches an equality filter
+var scimFilterPattern = regexp.MustCompile(`(?i)^\s*([a-z][\w.]*)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// errUnsupportedFilter is returned for filters other than a single equality
+var errUnsupportedFilter = errors.New("unsupported filter")
+
+// parseSCIMFilter parses the filter query parameter. It returns nil when the
+// request has no filter.
+func parseSCIMFilter(filter string) (*scimFilter, error) {
+	if filter == "" {
+		return nil, nil
+	}
+	match := scimFilterPattern.FindStringSubmatch(filter)
+	if match == nil {
+		return nil, errUnsupportedFilter
+	}
+	value, err := strconv.Unquote(match[2])
+	if err != nil {
+		return nil, errUnsupportedFilter
+	}
+	return &scimFilter{Attribute: strings.ToLower(match[1]), Value: value}, nil
+}
+
+// scimPagination returns the 1-based start index and page size of a list request
+func scimPagination(c echo.Context) (int, int) {
+	startIndex, err := strconv.Atoi(c.QueryParam("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(c.QueryParam("count"))
+	if err != nil || count < 0 {
+		count = scimDefaultCount
+	}
+	if count > scimMaxCount {
+		count = scimMaxCount
+	}
+	return startIndex, count
+}
+
+// primaryValue returns the value of the primary item, or of the first item
+func primaryValue(values []scimMultiValue) string {
+	for _, v := range values {
+		if v.Primary && v.Value != "" {
+			return v.Value
+		}
+	}
+	for _, v := range values {
+		if v.Value != "" {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+// splitName splits a stored name into given and family name
+func splitName(name string) (string, string) {
+	given, family, _ := strings.Cut(name, " ")
+	return given, family
+}
+
+// toSCIMUser converts a user to its SCIM representation
+func toSCIMUser(user *model.User, groups []*model.Group, baseURL string) *scimUser {
+	given, family := splitName(user.Name)
+	resource := &scimUser{
+		Schemas:     []string{scimUserSchema},
+		ID:          user.ID,
+		ExternalID:  user.ExternalID,
+		UserName:    user.Email,
+		Name:        scimName{Formatted: user.Name, GivenName: given, FamilyName: family},
+		DisplayName: user.Name,
+		Emails:      []scimMultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      user.IsActive(),
+		Roles:       []scimMultiValue{{Value: user.Role, Primary: true}},
+		Meta: scimMeta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     baseURL + "/Users/" + user.ID,
+		},
+	}
+	for _, group := range groups {
+		resource.Groups = append(resource.Groups, scimMultiValue{Value: group.ID, Display: group.Name})
+	}
+	return resource
+}
+
+// toSCIMGroup converts a group to its SCIM representation
+func toSCIMGroup(group *model.Group, members []*model.User, baseURL string) *scimGroup {
+	resource := &scimGroup{
+		Schemas:     []string{scimGroupSchema},
+		ID:          group.ID,
+		ExternalID:  group.ExternalID,
+		DisplayName: group.Name,
+		Meta: scimMeta{
+			ResourceType: "Group",
+			Created:      group.CreatedAt,
+			LastModified: group.UpdatedAt,
+			Location:     baseURL + "/Groups/" + group.ID,
+		},
+	}
+	for _, member := range members {
+		resource.Members = append(resource.Members, scimMultiValue{Value: member.ID, Display: member.Name})
+	}
+	return resource
+}
+
+// decodeSCIM decodes a SCIM request body. Echo's binder only accepts
+// application/json, while identity providers send application/scim+json.
+func decodeSCIM(c echo.Context, v interface{}) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// sendSCIM sends a SCIM response
+func sendSCIM(c echo.Context, status int, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Blob(status, scimContentType, body)
+}
+
+// SCIMError sends an error in the SCIM error format. The scimType is optional.
+func SCIMError(c echo.Context, status int, scimType string, detail string) error {
+	return sendSCIM(c, status, &scimErrorResponse{
+		Schemas:  []string{scimErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/knowledge"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/space"
+)
+
+type SpaceHandler struct {
+	createSpaceUseCase     space.CreateSpaceUseCase
+	updateSpaceUseCase     space.UpdateSpaceUseCase
+	deleteSpaceUseCase     space.DeleteSpaceUseCase
+	searchKnowledgeUseCase knowledge.SearchKnowledgeUseCase
+}
+
+func NewSpaceHandler(
+	createSpaceUseCase space.CreateSpaceUseCase,
+	updateSpaceUseCase space.UpdateSpaceUseCase,
+	deleteSpaceUseCase space.DeleteSpaceUseCase,
+	searchKnowledgeUseCase knowledge.SearchKnowledgeUseCase,
+) *SpaceHandler {
+	return &SpaceHandler{
+		createSpaceUseCase:     createSpaceUseCase,
+		updateSpaceUseCase:     updateSpaceUseCase,
+		deleteSpaceUseCase:     deleteSpaceUseCase,
+		searchKnowledgeUseCase: searchKnowledgeUseCase,
+	}
+}
+
+// CreateSpaceRequest represents the create space request body
+type CreateSpaceRequest struct {
+	Name          string   `json:"name" validate:"required,max=255"`
+	Slug          string   `json:"slug" validate:"max=100"`
+	Description   string   `json:"description"`
+	Icon          string   `json:"icon" validate:"max=100"`
+	DefaultTagIDs []string `json:"default_tag_ids"`
+	Private       bool     `json:"private"` // Only the creator is granted access to a private space
+}
+
+// UpdateSpaceRequest represents the update space request body. Omitted fields are unchanged.
+type UpdateSpaceRequest struct {
+	Name          *string  `json:"name" validate:"omitempty,max=255"`
+	Slug          *string  `json:"slug" validate:"omitempty,max=100"`
+	Description   *string  `json:"description"`
+	Icon          *string  `json:"icon" validate:"omitempty,max=100"`
+	DefaultTagIDs []string `json:"default_tag_ids"`
+}
+
+// SearchSpaceKnowledgeRequest represents the search space knowledge request query
+type SearchSpaceKnowledgeRequest struct {
+	Query    string   `query:"query"`
+	TagIDs   []string `query:"tag_ids"`
+	AuthorID string   `query:"author_id"`
+}
+
+// spaceError maps space use case errors to HTTP errors
+func spaceError(err error, message string) error {
+	switch {
+	case errors.Is(err, space.ErrSpaceNotFound):
+		return appErrors.NotFound("Space not found", err)
+	case errors.Is(err, space.ErrInvalidSlug):
+		return appErrors.NewValidationError("Slug must be lowercase letters and digits separated by hyphens", nil, err)
+	case errors.Is(err, space.ErrSlugTaken):
+		return appErrors.Conflict("A space with this slug already exists", err)
+	case errors.Is(err, space.ErrTagNotFound):
+		return appErrors.NewValidationError("Tag not found", nil, err)
+	case errors.Is(err, space.ErrSpaceNotEmpty):
+		return appErrors.Conflict("Move the knowledge out of the space before deleting it", err)
+	case errors.Is(err, authz.ErrForbidden):
+		return appErrors.Forbidden("You don't have permission to manage spaces", err)
+	}
+	return appErrors.InternalServerError(message, err)
+}
+
+// findSpace returns the space with the ID or slug if the user can see it
+func findSpace(c echo.Context, idOrSlug string, claims *Claims) (*model.Space, error) {
+	repo := c.Get("repositories").(RepositoriesProvider).Space()
+	viewerID := authz.ViewerID(claims.Subject())
+	if found, err := repo.FindByID(idOrSlug, claims.TenantID, viewerID); err == nil && found != nil {
+		return found, nil
+	}
+	return repo.FindBySlug(idOrSlug, claims.TenantID, viewerID)
+}
+
+// List handles listing the tenant's spaces
+// @Summary List spaces
+// @Description List the spaces of the tenant visible to the user
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} model.Space
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /spaces [get]
+func (h *SpaceHandler) List(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get spaces repository
+	repo := c.Get("repositories").(RepositoriesProvider).Space()
+
+	// List spaces
+	spaces, err := repo.FindAll(claims.TenantID, authz.ViewerID(claims.Subject()))
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list spaces", err)
+	}
+
+	return appErrors.SendOK(c, spaces)
+}
+
+// Get handles getting a space by ID or slug
+// @Summary Get space
+// @Description Get a space by ID or slug
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID or slug"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Space
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Router /spaces/{id} [get]
+func (h *SpaceHandler) Get(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get space
+	found, err := findSpace(c, id, claims)
+	if err != nil {
+		return appErrors.NotFound("Space not found", err)
+	}
+
+	return appErrors.SendOK(c, found)
+}
+
+// Knowledge handles searching the knowledge of a space
+// @Summary Search space knowledge
+// @Description List or search the knowledge of a space
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID or slug"
+// @Param query query string false "Search query"
+// @Param tag_ids query []string false "Tag IDs"
+// @Param author_id query string false "Author ID"
+// @Security ApiKeyAuth
+// @Success 200 {array} model.Knowledge
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /spaces/{id}/knowledge [get]
+func (h *SpaceHandler) Knowledge(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req SearchSpaceKnowledgeRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request parameters", nil, err)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get space
+	found, err := findSpace(c, id, claims)
+	if err != nil {
+		return appErrors.NotFound("Space not found", err)
+	}
+
+	// Search knowledge in the space
+	knowledges, err := h.searchKnowledgeUseCase.Execute(knowledge.SearchKnowledgeInput{
+		Query:    req.Query,
+		TenantID: claims.TenantID,
+		TagIDs:   req.TagIDs,
+		AuthorID: req.AuthorID,
+		SpaceID:  found.ID,
+		Viewer:   authz.Viewer(claims.Subject()),
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to search knowledge", err)
+	}
+
+	return appErrors.SendOK(c, knowledges)
+}
+
+// Create handles creating a space
+// @Summary Create space
+// @Description Create a space. The slug is derived from the name if omitted.
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param request body CreateSpaceRequest true "Space data"
+// @Security ApiKeyAuth
+// @Success 201 {object} model.Space
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /spaces [post]
+func (h *SpaceHandler) Create(c echo.Context) error {
+	var req CreateSpaceRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Create space
+	created, err := h.createSpaceUseCase.Execute(space.CreateSpaceInput{
+		TenantID:      claims.TenantID,
+		Name:          req.Name,
+		Slug:          req.Slug,
+		Description:   req.Description,
+		Icon:          req.Icon,
+		DefaultTagIDs: req.DefaultTagIDs,
+		Private:       req.Private,
+		Actor:         claims.Subject(),
+	})
+	if err != nil {
+		return spaceError(err, "Failed to create space")
+	}
+
+	return appErrors.SendCreated(c, created)
+}
+
+// Update handles updating a space
+// @Summary Update space
+// @Description Update a space. Changing the default tags doesn't change the tags of knowledge already in the space.
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID"
+// @Param request body UpdateSpaceRequest true "Space data"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Space
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /spaces/{id} [put]
+func (h *SpaceHandler) Update(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req UpdateSpaceRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Update space
+	updated, err := h.updateSpaceUseCase.Execute(space.UpdateSpaceInput{
+		ID:            id,
+		TenantID:      claims.TenantID,
+		Name:          req.Name,
+		Slug:          req.Slug,
+		Description:   req.Description,
+		Icon:          req.Icon,
+		DefaultTagIDs: req.DefaultTagIDs,
+		Actor:         claims.Subject(),
+	})
+	if err != nil {
+		return spaceError(err, "Failed to update space")
+	}
+
+	return appErrors.SendOK(c, updated)
+}
+
+// Delete handles deleting a space
+// @Summary Delete space
+// @Description Delete a space that doesn't contain knowledge
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID"
+// @Security ApiKeyAuth
+// @Success 204 {object} nil
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /spaces/{id} [delete]
+func (h *SpaceHandler) Delete(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Delete space
+	err := h.deleteSpaceUseCase.Execute(space.DeleteSpaceInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
+	})
+	if err != nil {
+		return spaceError(err, "Failed to delete space")
+	}
+
+	return appErrors.SendNoContent(c)
+}
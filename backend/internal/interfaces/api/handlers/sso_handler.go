@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/sso"
+)
+
+type SSOHandler struct {
+	startLoginUseCase    sso.StartLoginUseCase
+	completeLoginUseCase sso.CompleteLoginUseCase
+	createSessionUseCase session.CreateSessionUseCase
+	callbackURL          string // Callback registered with the identity providers
+	appCallbackURL       string // Frontend page receiving the tokens
+}
+
+func NewSSOHandler(
+	startLoginUseCase sso.StartLoginUseCase,
+	completeLoginUseCase sso.CompleteLoginUseCase,
+	createSessionUseCase session.CreateSessionUseCase,
+	callbackURL string,
+	appCallbackURL string,
+) *SSOHandler {
+	return &SSOHandler{
+		startLoginUseCase:    startLoginUseCase,
+		completeLoginUseCase: completeLoginUseCase,
+		createSessionUseCase: createSessionUseCase,
+		callbackURL:          callbackURL,
+		appCallbackURL:       appCallbackURL,
+	}
+}
+
+// Login handles sending the user to the tenant's identity provider
+// @Summary Start SSO login
+// @Description Redirect to the tenant's OpenID Connect provider using the authorization code flow with PKCE
+// @Tags auth
+// @Param tenant_id path string true "Tenant ID"
+// @Success 302 "Redirect to the identity provider"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Failure 503 {object} appErrors.ErrorResponse
+// @Router /auth/sso/{tenant_id}/login [get]
+func (h *SSOHandler) Login(c echo.Context) error {
+	tenantID := c.Param("tenant_id")
+	if tenantID == "" {
+		return appErrors.NewValidationError("Tenant ID is required", nil, nil)
+	}
+
+	// Start SSO login
+	authURL, err := h.startLoginUseCase.Execute(sso.StartLoginInput{
+		TenantID:    tenantID,
+		RedirectURI: h.callbackURL,
+	})
+	if err != nil {
+		if errors.Is(err, sso.ErrSSONotEnabled) {
+			return appErrors.NotFound("SSO is not enabled for this tenant", err)
+		}
+		if errors.Is(err, sso.ErrTenantDisabled) {
+			return appErrors.Forbidden("This tenant is scheduled for deletion", err)
+		}
+		if errors.Is(err, sso.ErrProviderFailed) {
+			return appErrors.ServiceUnavailable("Failed to reach the identity provider", err)
+		}
+		return appErrors.InternalServerError("Failed to start SSO login", err)
+	}
+
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback handles the identity provider returning the user
+// @Summary Complete SSO login
+// @Description Exchange the authorization code, provision the user on their first login and redirect to the app with a JWT access token and a refresh token in the URL fragment. Second factors are left to the identity provider.
+// @Tags auth
+// @Param code query string true "Authorization code"
+// @Param state query string true "State"
+// @Success 302 "Redirect to the app"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/sso/callback [get]
+func (h *SSOHandler) Callback(c echo.Context) error {
+	// The identity provider reports failures such as a cancelled login as an error parameter
+	if providerError := c.QueryParam("error"); providerError != "" {
+		return appErrors.BadRequest("SSO login failed: "+providerError, nil)
+	}
+
+	// Complete SSO login
+	ssoUser, err := h.completeLoginUseCase.Execute(sso.CompleteLoginInput{
+		State:       c.QueryParam("state"),
+		Code:        c.QueryParam("code"),
+		RedirectURI: h.callbackURL,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sso.ErrInvalidState):
+			return appErrors.BadRequest("Invalid or expired SSO login, please try again", err)
+		case errors.Is(err, sso.ErrSSONotEnabled):
+			return appErrors.Forbidden("SSO is not enabled for this tenant", err)
+		case errors.Is(err, sso.ErrEmailNotVerified):
+			return appErrors.Forbidden("Your email address is not verified by the identity provider", err)
+		case errors.Is(err, sso.ErrEmailDomainNotAllowed):
+			return appErrors.Forbidden("Your email domain is not allowed to sign in", err)
+		case errors.Is(err, sso.ErrTenantDisabled):
+			return appErrors.Forbidden("This tenant is scheduled for deletion", err)
+		case errors.Is(err, sso.ErrUserDeactivated):
+			return appErrors.Forbidden("Your account has been deactivated", err)
+		case errors.Is(err, sso.ErrProviderFailed):
+			return appErrors.Unauthorized("The identity provider rejected the login", err)
+		}
+		return appErrors.InternalServerError("Failed to complete SSO login", err)
+	}
+
+	// Start session
+	response, err := issueTokens(c, h.createSessionUseCase, ssoUser)
+	if err != nil {
+		return sessionError(err)
+	}
+
+	return redirectWithTokens(c, h.appCallbackURL, response)
+}
+
+// redirectWithTokens sends the browser back to the app with the tokens in the
+// URL fragment, which browsers don't send to servers
+func redirectWithTokens(c echo.Context, appCallbackURL string, response *TokenResponse) error {
+	fragment := url.Values{}
+	fragment.Set("token", response.Token)
+	fragment.Set("expires_at", strconv.FormatInt(response.ExpiresAt, 10))
+	fragment.Set("refresh_token", response.RefreshToken)
+
+	return c.Redirect(http.StatusFound, appCallbackURL+"#"+fragment.Encode())
+}
+
+// RegisterRoutes registers the public SSO routes
+func (h *SSOHandler) RegisterRoutes(g *echo.Group) {
+	ssoGroup := g.Group("/auth/sso")
+	ssoGroup.GET("/callback", h.Callback)
+	ssoGroup.GET("/:tenant_id/login", h.Login)
+}
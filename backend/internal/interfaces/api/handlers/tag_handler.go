@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/labstack/echo/v4"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/tag"
 )
@@ -46,6 +49,7 @@ type UpdateTagRequest struct {
 // @Success 201 {object} model.Tag
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /tags [post]
 func (h *TagHandler) Create(c echo.Context) error {
@@ -68,8 +72,12 @@ func (h *TagHandler) Create(c echo.Context) error {
 	tag, err := h.createTagUseCase.Execute(tag.CreateTagInput{
 		Name:     req.Name,
 		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
 	})
 	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to create tags", err)
+		}
 		return appErrors.InternalServerError("Failed to create tag", err)
 	}
 
@@ -88,6 +96,7 @@ func (h *TagHandler) Create(c echo.Context) error {
 // @Success 200 {object} model.Tag
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
 // @Failure 404 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /tags/{id} [put]
@@ -117,8 +126,12 @@ func (h *TagHandler) Update(c echo.Context) error {
 		ID:       id,
 		Name:     req.Name,
 		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
 	})
 	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to rename tags", err)
+		}
 		return appErrors.InternalServerError("Failed to update tag", err)
 	}
 
@@ -136,6 +149,7 @@ func (h *TagHandler) Update(c echo.Context) error {
 // @Success 204 {object} nil
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /tags/{id} [delete]
 func (h *TagHandler) Delete(c echo.Context) error {
@@ -154,8 +168,12 @@ func (h *TagHandler) Delete(c echo.Context) error {
 	err := h.deleteTagUseCase.Execute(tag.DeleteTagInput{
 		ID:       id,
 		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
 	})
 	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You don't have permission to delete tags", err)
+		}
 		return appErrors.InternalServerError("Failed to delete tag", err)
 	}
 
@@ -190,13 +208,4 @@ func (h *TagHandler) List(c echo.Context) error {
 	}
 
 	return appErrors.SendOK(c, tags)
-}
-
-// RegisterRoutes registers the tag routes
-func (h *TagHandler) RegisterRoutes(g *echo.Group) {
-	tags := g.Group("/tags")
-	tags.POST("", h.Create)
-	tags.GET("", h.List)
-	tags.PUT("/:id", h.Update)
-	tags.DELETE("/:id", h.Delete)
 }
\ No newline at end of file
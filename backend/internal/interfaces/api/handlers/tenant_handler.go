@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/labstack/echo/v4"
 
 	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
@@ -12,24 +14,28 @@ type TenantHandler struct {
 	createTenantUseCase        tenant.CreateTenantUseCase
 	updateTenantSettingsUseCase tenant.UpdateTenantSettingsUseCase
 	deleteTenantUseCase        tenant.DeleteTenantUseCase
+	cancelTenantDeletionUseCase tenant.CancelTenantDeletionUseCase
 }
 
 func NewTenantHandler(
 	createTenantUseCase tenant.CreateTenantUseCase,
 	updateTenantSettingsUseCase tenant.UpdateTenantSettingsUseCase,
 	deleteTenantUseCase tenant.DeleteTenantUseCase,
+	cancelTenantDeletionUseCase tenant.CancelTenantDeletionUseCase,
 ) *TenantHandler {
 	return &TenantHandler{
 		createTenantUseCase:        createTenantUseCase,
 		updateTenantSettingsUseCase: updateTenantSettingsUseCase,
 		deleteTenantUseCase:        deleteTenantUseCase,
+		cancelTenantDeletionUseCase: cancelTenantDeletionUseCase,
 	}
 }
 
 // CreateTenantRequest represents the create tenant request body
 type CreateTenantRequest struct {
-	Name   string `json:"name" validate:"required"`
-	Domain string `json:"domain" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+	Domain     string `json:"domain" validate:"required"`
+	AdminEmail string `json:"admin_email" validate:"omitempty,email"` // Defaults to the creator's email
 	Settings struct {
 		Theme struct {
 			PrimaryColor   string `json:"primary_color" validate:"required,hexcolor"`
@@ -40,6 +46,9 @@ type CreateTenantRequest struct {
 			Tags     bool `json:"tags"`
 			Ratings  bool `json:"ratings"`
 		} `json:"features" validate:"required"`
+		Registration   RegistrationSettingsRequest `json:"registration"`
+		Security       *SecuritySettingsRequest    `json:"security"`
+		PasswordPolicy *PasswordPolicyRequest      `json:"password_policy"`
 	} `json:"settings" validate:"required"`
 }
 
@@ -55,12 +64,137 @@ type UpdateTenantSettingsRequest struct {
 			Tags     bool `json:"tags"`
 			Ratings  bool `json:"ratings"`
 		} `json:"features" validate:"required"`
+		Registration   RegistrationSettingsRequest `json:"registration"`
+		Security       *SecuritySettingsRequest    `json:"security"`
+		PasswordPolicy *PasswordPolicyRequest      `json:"password_policy"`
+		SSO            *SSOSettingsRequest         `json:"sso"`
+		SAML           *SAMLSettingsRequest        `json:"saml"`
 	} `json:"settings" validate:"required"`
 }
 
+// RegistrationSettingsRequest represents the registration policy of a tenant
+type RegistrationSettingsRequest struct {
+	Mode           string   `json:"mode" validate:"omitempty,oneof=closed invite_only open"`
+	AllowedDomains []string `json:"allowed_domains" validate:"omitempty,dive,fqdn"`
+}
+
+// SecuritySettingsRequest represents the authentication requirements of a tenant
+type SecuritySettingsRequest struct {
+	RequireTwoFactor bool `json:"require_two_factor"`
+	MaxFailedLogins  int  `json:"max_failed_logins" validate:"omitempty,min=3,max=100"`
+	LockoutMinutes   int  `json:"lockout_minutes" validate:"omitempty,min=1,max=1440"`
+}
+
+// toModel converts the request to security settings, returning nil when it was omitted
+func (r *SecuritySettingsRequest) toModel() *model.Security {
+	if r == nil {
+		return nil
+	}
+	return &model.Security{
+		RequireTwoFactor: r.RequireTwoFactor,
+		MaxFailedLogins:  r.MaxFailedLogins,
+		LockoutMinutes:   r.LockoutMinutes,
+	}
+}
+
+// PasswordPolicyRequest represents the password policy of a tenant
+type PasswordPolicyRequest struct {
+	MinLength            int  `json:"min_length" validate:"omitempty,min=8,max=128"`
+	RequireUppercase     bool `json:"require_uppercase"`
+	RequireLowercase     bool `json:"require_lowercase"`
+	RequireDigit         bool `json:"require_digit"`
+	RequireSymbol        bool `json:"require_symbol"`
+	DisallowPersonalInfo bool `json:"disallow_personal_info"`
+	DisallowCommon       bool `json:"disallow_common"`
+	HistorySize          int  `json:"history_size" validate:"omitempty,min=0,max=24"`
+}
+
+// toModel converts the request to a password policy, returning nil when it was omitted
+func (r *PasswordPolicyRequest) toModel() *model.PasswordPolicy {
+	if r == nil {
+		return nil
+	}
+	return &model.PasswordPolicy{
+		MinLength:            r.MinLength,
+		RequireUppercase:     r.RequireUppercase,
+		RequireLowercase:     r.RequireLowercase,
+		RequireDigit:         r.RequireDigit,
+		RequireSymbol:        r.RequireSymbol,
+		DisallowPersonalInfo: r.DisallowPersonalInfo,
+		DisallowCommon:       r.DisallowCommon,
+		HistorySize:          r.HistorySize,
+	}
+}
+
+// SSOSettingsRequest represents the OpenID Connect provider of a tenant
+type SSOSettingsRequest struct {
+	Enabled              bool              `json:"enabled"`
+	Issuer               string            `json:"issuer" validate:"required_if=Enabled true,omitempty,url"`
+	ClientID             string            `json:"client_id" validate:"required_if=Enabled true"`
+	ClientSecret         string            `json:"client_secret"` // Keeps the current secret when empty
+	AllowedDomains       []string          `json:"allowed_domains" validate:"omitempty,dive,fqdn"`
+	RoleClaim            string            `json:"role_claim"`
+	RoleMapping          map[string]string `json:"role_mapping" validate:"omitempty,dive,max=50"`
+	DefaultRole          string            `json:"default_role" validate:"omitempty,max=50"`
+	DisablePasswordLogin bool              `json:"disable_password_login"`
+}
+
+// toModel converts the request to an SSO configuration, returning nil when it was omitted
+func (r *SSOSettingsRequest) toModel() *model.SSO {
+	if r == nil {
+		return nil
+	}
+	return &model.SSO{
+		Enabled:              r.Enabled,
+		Issuer:               r.Issuer,
+		ClientID:             r.ClientID,
+		ClientSecret:         r.ClientSecret,
+		AllowedDomains:       r.AllowedDomains,
+		RoleClaim:            r.RoleClaim,
+		RoleMapping:          r.RoleMapping,
+		DefaultRole:          r.DefaultRole,
+		DisablePasswordLogin: r.DisablePasswordLogin,
+	}
+}
+
+// SAMLSettingsRequest represents the SAML identity provider of a tenant
+type SAMLSettingsRequest struct {
+	Enabled              bool              `json:"enabled"`
+	IdPEntityID          string            `json:"idp_entity_id" validate:"required_if=Enabled true"`
+	IdPSSOURL            string            `json:"idp_sso_url" validate:"required_if=Enabled true,omitempty,url"`
+	IdPCertificate       string            `json:"idp_certificate" validate:"required_if=Enabled true"`
+	EmailAttribute       string            `json:"email_attribute"`
+	NameAttribute        string            `json:"name_attribute"`
+	AllowedDomains       []string          `json:"allowed_domains" validate:"omitempty,dive,fqdn"`
+	RoleAttribute        string            `json:"role_attribute"`
+	RoleMapping          map[string]string `json:"role_mapping" validate:"omitempty,dive,max=50"`
+	DefaultRole          string            `json:"default_role" validate:"omitempty,max=50"`
+	DisablePasswordLogin bool              `json:"disable_password_login"`
+}
+
+// toModel converts the request to a SAML configuration, returning nil when it was omitted
+func (r *SAMLSettingsRequest) toModel() *model.SAML {
+	if r == nil {
+		return nil
+	}
+	return &model.SAML{
+		Enabled:              r.Enabled,
+		IdPEntityID:          r.IdPEntityID,
+		IdPSSOURL:            r.IdPSSOURL,
+		IdPCertificate:       r.IdPCertificate,
+		EmailAttribute:       r.EmailAttribute,
+		NameAttribute:        r.NameAttribute,
+		AllowedDomains:       r.AllowedDomains,
+		RoleAttribute:        r.RoleAttribute,
+		RoleMapping:          r.RoleMapping,
+		DefaultRole:          r.DefaultRole,
+		DisablePasswordLogin: r.DisablePasswordLogin,
+	}
+}
+
 // Create handles creating a new tenant
 // @Summary Create tenant
-// @Description Create a new tenant
+// @Description Create a new tenant and invite its first admin, who is the creator unless admin_email is given
 // @Tags tenants
 // @Accept json
 // @Produce json
@@ -81,15 +215,41 @@ func (h *TenantHandler) Create(c echo.Context) error {
 		return err
 	}
 
-	// Create tenant
-	tenant, err := h.createTenantUseCase.Execute(tenant.CreateTenantInput{
-		Name:   req.Name,
-		Domain: req.Domain,
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// The creator becomes the first admin unless someone else is named
+	adminEmail := req.AdminEmail
+	if adminEmail == "" {
+		adminEmail = claims.Email
+	}
+
+	input := tenant.CreateTenantInput{
+		Name:       req.Name,
+		Domain:     req.Domain,
+		AdminEmail: adminEmail,
+		CreatedBy:  claims.UserID,
 		Theme: model.Theme{
 			PrimaryColor:   req.Settings.Theme.PrimaryColor,
 			SecondaryColor: req.Settings.Theme.SecondaryColor,
 		},
-	})
+		Registration: model.Registration{
+			Mode:           req.Settings.Registration.Mode,
+			AllowedDomains: req.Settings.Registration.AllowedDomains,
+		},
+		PasswordPolicy: req.Settings.PasswordPolicy.toModel(),
+	}
+
+	// Security settings are optional when creating a tenant
+	if security := req.Settings.Security.toModel(); security != nil {
+		input.Security = *security
+	}
+
+	// Create tenant
+	tenant, err := h.createTenantUseCase.Execute(input)
 	if err != nil {
 		return appErrors.InternalServerError("Failed to create tenant", err)
 	}
@@ -134,9 +294,9 @@ func (h *TenantHandler) UpdateSettings(c echo.Context) error {
 		return appErrors.Unauthorized("Authentication required", nil)
 	}
 
-	// Check if user is admin
-	if claims.Role != "admin" {
-		return appErrors.Forbidden("Only admins can update tenant settings", nil)
+	// Check if user can manage the tenant
+	if !claims.HasPermission(model.PermissionTenantManage) {
+		return appErrors.Forbidden("You don't have permission to update tenant settings", nil)
 	}
 
 	// Check if user belongs to the tenant
@@ -145,7 +305,7 @@ func (h *TenantHandler) UpdateSettings(c echo.Context) error {
 	}
 
 	// Update tenant settings
-	tenant, err := h.updateTenantSettingsUseCase.Execute(tenant.UpdateTenantSettingsInput{
+	updatedTenant, err := h.updateTenantSettingsUseCase.Execute(tenant.UpdateTenantSettingsInput{
 		ID: id,
 		Settings: model.Settings{
 			Theme: model.Theme{
@@ -157,27 +317,39 @@ func (h *TenantHandler) UpdateSettings(c echo.Context) error {
 				Tags:     req.Settings.Features.Tags,
 				Ratings:  req.Settings.Features.Ratings,
 			},
+			Registration: model.Registration{
+				Mode:           req.Settings.Registration.Mode,
+				AllowedDomains: req.Settings.Registration.AllowedDomains,
+			},
 		},
+		Security:       req.Settings.Security.toModel(),
+		PasswordPolicy: req.Settings.PasswordPolicy.toModel(),
+		SSO:            req.Settings.SSO.toModel(),
+		SAML:           req.Settings.SAML.toModel(),
 	})
 	if err != nil {
+		if errors.Is(err, tenant.ErrInvalidSSOConfiguration) {
+			return appErrors.NewValidationError(err.Error(), nil, err)
+		}
 		return appErrors.InternalServerError("Failed to update tenant settings", err)
 	}
 
-	return appErrors.SendOK(c, tenant)
+	return appErrors.SendOK(c, updatedTenant)
 }
 
 // Delete handles deleting a tenant
 // @Summary Delete tenant
-// @Description Delete a tenant
+// @Description Disable a tenant and schedule its data to be purged. The deletion can be canceled during a grace period of 7 days, and a report is emailed to the requester once the data is purged. Other users are signed out right away.
 // @Tags tenants
 // @Accept json
 // @Produce json
 // @Param id path string true "Tenant ID"
 // @Security ApiKeyAuth
-// @Success 204 {object} nil
+// @Success 202 {object} model.TenantDeletion
 // @Failure 400 {object} appErrors.ErrorResponse
 // @Failure 401 {object} appErrors.ErrorResponse
 // @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
 // @Failure 500 {object} appErrors.ErrorResponse
 // @Router /tenants/{id} [delete]
 func (h *TenantHandler) Delete(c echo.Context) error {
@@ -192,9 +364,9 @@ func (h *TenantHandler) Delete(c echo.Context) error {
 		return appErrors.Unauthorized("Authentication required", nil)
 	}
 
-	// Check if user is admin
-	if claims.Role != "admin" {
-		return appErrors.Forbidden("Only admins can delete tenants", nil)
+	// Check if user can manage the tenant
+	if !claims.HasPermission(model.PermissionTenantManage) {
+		return appErrors.Forbidden("You don't have permission to delete tenants", nil)
 	}
 
 	// Check if user belongs to the tenant
@@ -202,13 +374,117 @@ func (h *TenantHandler) Delete(c echo.Context) error {
 		return appErrors.Forbidden("You can only delete your own tenant", nil)
 	}
 
-	// Delete tenant
-	err := h.deleteTenantUseCase.Execute(id)
+	// Schedule tenant deletion
+	deletion, err := h.deleteTenantUseCase.Execute(tenant.DeleteTenantInput{
+		ID:          id,
+		RequestedBy: claims.UserID,
+		SessionID:   claims.ID,
+	})
 	if err != nil {
+		if errors.Is(err, tenant.ErrDeletionAlreadyScheduled) {
+			return appErrors.Conflict("Tenant deletion is already scheduled", err)
+		}
 		return appErrors.InternalServerError("Failed to delete tenant", err)
 	}
 
-	return appErrors.SendNoContent(c)
+	return appErrors.SendAccepted(c, deletion)
+}
+
+// GetDeletion handles getting the deletion of a tenant
+// @Summary Get tenant deletion
+// @Description Get the latest deletion of a tenant and its progress
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.TenantDeletion
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /tenants/{id}/deletion [get]
+func (h *TenantHandler) GetDeletion(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Check if user belongs to the tenant
+	if claims.TenantID != id {
+		return appErrors.Forbidden("You can only view the deletion of your own tenant", nil)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).TenantDeletion()
+
+	// Get deletion
+	deletion, err := repo.FindLatestByTenantID(id)
+	if err != nil {
+		return appErrors.NotFound("Tenant deletion not found", err)
+	}
+
+	return appErrors.SendOK(c, deletion)
+}
+
+// CancelDeletion handles canceling the deletion of a tenant
+// @Summary Cancel tenant deletion
+// @Description Cancel the scheduled deletion of a tenant during its grace period and enable the tenant again
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.TenantDeletion
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /tenants/{id}/deletion/cancel [post]
+func (h *TenantHandler) CancelDeletion(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Check if user can manage the tenant
+	if !claims.HasPermission(model.PermissionTenantManage) {
+		return appErrors.Forbidden("You don't have permission to cancel tenant deletions", nil)
+	}
+
+	// Check if user belongs to the tenant
+	if claims.TenantID != id {
+		return appErrors.Forbidden("You can only cancel the deletion of your own tenant", nil)
+	}
+
+	// Cancel deletion
+	deletion, err := h.cancelTenantDeletionUseCase.Execute(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, tenant.ErrNoDeletionScheduled):
+			return appErrors.NotFound("No tenant deletion is scheduled", err)
+		case errors.Is(err, tenant.ErrDeletionNotCancelable):
+			return appErrors.Conflict("Tenant deletion can no longer be canceled", err)
+		}
+		return appErrors.InternalServerError("Failed to cancel tenant deletion", err)
+	}
+
+	return appErrors.SendOK(c, deletion)
 }
 
 // Get handles getting a tenant
@@ -287,4 +563,6 @@ func (h *TenantHandler) RegisterRoutes(g *echo.Group) {
 	tenants.GET("/domain/:domain", h.GetByDomain)
 	tenants.PUT("/:id/settings", h.UpdateSettings)
 	tenants.DELETE("/:id", h.Delete)
+	tenants.GET("/:id/deletion", h.GetDeletion)
+	tenants.POST("/:id/deletion/cancel", h.CancelDeletion)
 }
\ No newline at end of file
@@ -0,0 +1,471 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/twofactor"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/user"
+)
+
+// twoFactorChallengeAudience marks tokens that only prove the password step of a login
+const twoFactorChallengeAudience = "two_factor_challenge"
+
+// twoFactorChallengeTTL is how long the second step of a login can be completed
+const twoFactorChallengeTTL = 5 * time.Minute
+
+type TwoFactorHandler struct {
+	enrollUseCase                  twofactor.EnrollUseCase
+	activateUseCase                twofactor.ActivateUseCase
+	verifyUseCase                  twofactor.VerifyUseCase
+	disableUseCase                 twofactor.DisableUseCase
+	regenerateRecoveryCodesUseCase twofactor.RegenerateRecoveryCodesUseCase
+	createSessionUseCase           session.CreateSessionUseCase
+}
+
+func NewTwoFactorHandler(
+	enrollUseCase twofactor.EnrollUseCase,
+	activateUseCase twofactor.ActivateUseCase,
+	verifyUseCase twofactor.VerifyUseCase,
+	disableUseCase twofactor.DisableUseCase,
+	regenerateRecoveryCodesUseCase twofactor.RegenerateRecoveryCodesUseCase,
+	createSessionUseCase session.CreateSessionUseCase,
+) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		enrollUseCase:                  enrollUseCase,
+		activateUseCase:                activateUseCase,
+		verifyUseCase:                  verifyUseCase,
+		disableUseCase:                 disableUseCase,
+		regenerateRecoveryCodesUseCase: regenerateRecoveryCodesUseCase,
+		createSessionUseCase:           createSessionUseCase,
+	}
+}
+
+// TwoFactorChallengeResponse is returned by login when a second factor is needed
+type TwoFactorChallengeResponse struct {
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresAt         int64  `json:"expires_at"`
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	SetupRequired     bool   `json:"setup_required"` // The tenant requires 2FA but the user has not enrolled yet
+}
+
+// TwoFactorSetupRequest represents the setup request body sent during login
+type TwoFactorSetupRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+// TwoFactorVerifyRequest represents the second step of a login
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"omitempty,len=6,numeric"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+// TwoFactorCodeRequest represents a request confirmed with a TOTP code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// DisableTwoFactorRequest represents the disable request body
+type DisableTwoFactorRequest struct {
+	Code         string `json:"code" validate:"omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// RecoveryCodesResponse contains recovery codes, which are only shown once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorTokenResponse represents the tokens issued after the second step of a login
+type TwoFactorTokenResponse struct {
+	*TokenResponse
+	RecoveryCodes []string `json:"recovery_codes,omitempty"` // Only set when 2FA was just activated
+}
+
+// Setup handles starting the enrollment required by the tenant during login
+// @Summary Set up two-factor authentication during login
+// @Description Start enrolling an authenticator app with a login challenge token when the tenant requires two-factor authentication
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorSetupRequest true "Login challenge token"
+// @Success 200 {object} twofactor.Enrollment
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/2fa/setup [post]
+func (h *TwoFactorHandler) Setup(c echo.Context) error {
+	var req TwoFactorSetupRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Check challenge
+	challenge, err := parseTwoFactorChallenge(req.ChallengeToken)
+	if err != nil {
+		return appErrors.Unauthorized("Invalid or expired challenge token", err)
+	}
+
+	// Start enrollment
+	enrollment, err := h.enrollUseCase.Execute(twofactor.EnrollInput{
+		UserID:   challenge.UserID,
+		TenantID: challenge.TenantID,
+	})
+	if err != nil {
+		if errors.Is(err, twofactor.ErrAlreadyEnabled) {
+			return appErrors.Conflict("Two-factor authentication is already enabled", err)
+		}
+		return appErrors.InternalServerError("Failed to start two-factor enrollment", err)
+	}
+
+	return appErrors.SendOK(c, enrollment)
+}
+
+// Verify handles the second step of a login
+// @Summary Verify two-factor login
+// @Description Complete a login with a TOTP code or a recovery code. If the enrollment was started during login, it is activated and recovery codes are returned. After five wrong codes the challenge is invalidated and the login has to be started again.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorVerifyRequest true "Login challenge token and code"
+// @Success 200 {object} TwoFactorTokenResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 429 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/2fa/verify [post]
+func (h *TwoFactorHandler) Verify(c echo.Context) error {
+	var req TwoFactorVerifyRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Check challenge
+	challenge, err := parseTwoFactorChallenge(req.ChallengeToken)
+	if err != nil || challenge.ID == "" {
+		return appErrors.Unauthorized("Invalid or expired challenge token", err)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).User()
+
+	// Find user
+	challengedUser, err := repo.FindByID(challenge.UserID, challenge.TenantID)
+	if err != nil || challengedUser == nil || !challengedUser.IsActive() {
+		return appErrors.Unauthorized("Invalid or expired challenge token", err)
+	}
+
+	// Activate an enrollment started during login, otherwise check the code
+	var recoveryCodes []string
+	if !challengedUser.TwoFactorEnabled {
+		recoveryCodes, err = h.activateUseCase.Execute(twofactor.ActivateInput{
+			UserID:      challengedUser.ID,
+			TenantID:    challengedUser.TenantID,
+			Code:        req.Code,
+			ChallengeID: challenge.ID,
+			IPAddress:   c.RealIP(),
+		})
+	} else {
+		challengedUser, err = h.verifyUseCase.Execute(twofactor.VerifyInput{
+			UserID:       challenge.UserID,
+			TenantID:     challenge.TenantID,
+			Code:         req.Code,
+			RecoveryCode: req.RecoveryCode,
+			ChallengeID:  challenge.ID,
+			IPAddress:    c.RealIP(),
+		})
+	}
+	if err != nil {
+		if throttledErr := loginThrottledError(c, err); throttledErr != nil {
+			return throttledErr
+		}
+		switch {
+		case errors.Is(err, user.ErrChallengeExhausted):
+			return appErrors.Unauthorized("Too many wrong codes, please log in again", err)
+		case errors.Is(err, twofactor.ErrInvalidCode):
+			return appErrors.Unauthorized("Invalid two-factor code", err)
+		case errors.Is(err, twofactor.ErrNotEnrolled):
+			return appErrors.BadRequest("Two-factor enrollment has not been started", err)
+		}
+		return appErrors.InternalServerError("Failed to verify two-factor code", err)
+	}
+
+	// Start session
+	response, err := issueTokens(c, h.createSessionUseCase, challengedUser)
+	if err != nil {
+		return sessionError(err)
+	}
+
+	return appErrors.SendOK(c, TwoFactorTokenResponse{
+		TokenResponse: response,
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// Enroll handles starting two-factor enrollment for the current user
+// @Summary Enroll in two-factor authentication
+// @Description Generate a TOTP secret for the current user. It takes effect once activated with a code.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} twofactor.Enrollment
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/2fa/enroll [post]
+func (h *TwoFactorHandler) Enroll(c echo.Context) error {
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Start enrollment
+	enrollment, err := h.enrollUseCase.Execute(twofactor.EnrollInput{
+		UserID:   claims.UserID,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		if errors.Is(err, twofactor.ErrAlreadyEnabled) {
+			return appErrors.Conflict("Two-factor authentication is already enabled", err)
+		}
+		return appErrors.InternalServerError("Failed to start two-factor enrollment", err)
+	}
+
+	return appErrors.SendOK(c, enrollment)
+}
+
+// Activate handles confirming the current user's enrollment
+// @Summary Activate two-factor authentication
+// @Description Enable two-factor authentication with a code from the enrolled authenticator app and return recovery codes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorCodeRequest true "TOTP code"
+// @Security ApiKeyAuth
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 409 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/2fa/activate [post]
+func (h *TwoFactorHandler) Activate(c echo.Context) error {
+	var req TwoFactorCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Activate two-factor authentication
+	recoveryCodes, err := h.activateUseCase.Execute(twofactor.ActivateInput{
+		UserID:   claims.UserID,
+		TenantID: claims.TenantID,
+		Code:     req.Code,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, twofactor.ErrInvalidCode):
+			return appErrors.NewValidationError("Invalid two-factor code", map[string]string{
+				"code": "Invalid two-factor code",
+			}, err)
+		case errors.Is(err, twofactor.ErrNotEnrolled):
+			return appErrors.BadRequest("Two-factor enrollment has not been started", err)
+		case errors.Is(err, twofactor.ErrAlreadyEnabled):
+			return appErrors.Conflict("Two-factor authentication is already enabled", err)
+		}
+		return appErrors.InternalServerError("Failed to activate two-factor authentication", err)
+	}
+
+	return appErrors.SendOK(c, RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// Disable handles turning off two-factor authentication for the current user
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication with a current TOTP code or a recovery code. Not allowed when the tenant requires it.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body DisableTwoFactorRequest true "TOTP code or recovery code"
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(c echo.Context) error {
+	var req DisableTwoFactorRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Disable two-factor authentication
+	err := h.disableUseCase.Execute(twofactor.DisableInput{
+		UserID:       claims.UserID,
+		TenantID:     claims.TenantID,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, twofactor.ErrRequiredByTenant):
+			return appErrors.Forbidden("Two-factor authentication is required by your organization", err)
+		case errors.Is(err, twofactor.ErrNotEnabled):
+			return appErrors.BadRequest("Two-factor authentication is not enabled", err)
+		case errors.Is(err, twofactor.ErrInvalidCode):
+			return appErrors.NewValidationError("Invalid two-factor code", map[string]string{
+				"code": "Invalid two-factor code",
+			}, err)
+		}
+		return appErrors.InternalServerError("Failed to disable two-factor authentication", err)
+	}
+
+	return appErrors.SendNoContent(c)
+}
+
+// RegenerateRecoveryCodes handles replacing the current user's recovery codes
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes of the current user after checking a TOTP code
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorCodeRequest true "TOTP code"
+// @Security ApiKeyAuth
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /auth/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	var req TwoFactorCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Regenerate recovery codes
+	recoveryCodes, err := h.regenerateRecoveryCodesUseCase.Execute(twofactor.RegenerateRecoveryCodesInput{
+		UserID:   claims.UserID,
+		TenantID: claims.TenantID,
+		Code:     req.Code,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, twofactor.ErrNotEnabled):
+			return appErrors.BadRequest("Two-factor authentication is not enabled", err)
+		case errors.Is(err, twofactor.ErrInvalidCode):
+			return appErrors.NewValidationError("Invalid two-factor code", map[string]string{
+				"code": "Invalid two-factor code",
+			}, err)
+		}
+		return appErrors.InternalServerError("Failed to regenerate recovery codes", err)
+	}
+
+	return appErrors.SendOK(c, RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// RegisterRoutes registers the public two-factor routes used during login
+func (h *TwoFactorHandler) RegisterRoutes(g *echo.Group) {
+	twoFactor := g.Group("/auth/2fa")
+	twoFactor.POST("/setup", h.Setup)
+	twoFactor.POST("/verify", h.Verify)
+}
+
+// newTwoFactorChallenge creates the token that lets a user finish logging in
+// with a second factor. Its ID only counts wrong codes and names no session,
+// and parseToken rejects its audience, so it is never accepted as an access
+// token.
+func newTwoFactorChallenge(u *model.User, setupRequired bool) (*TwoFactorChallengeResponse, error) {
+	now := time.Now()
+	expiresAt := now.Add(twoFactorChallengeTTL)
+
+	// Create claims
+	claims := &Claims{
+		UserID:   u.ID,
+		TenantID: u.TenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "knowledge-hub",
+			Subject:   u.ID,
+			Audience:  jwt.ClaimStrings{twoFactorChallengeAudience},
+			ID:        uuid.New().String(), // Wrong codes are counted per challenge
+		},
+	}
+
+	// Sign token
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(security.Secret())
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorChallengeResponse{
+		ChallengeToken:    token,
+		ExpiresAt:         expiresAt.Unix(),
+		TwoFactorRequired: true,
+		SetupRequired:     setupRequired,
+	}, nil
+}
+
+// parseTwoFactorChallenge validates a challenge token and returns its claims
+func parseTwoFactorChallenge(challengeToken string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(challengeToken, &Claims{}, func(t *jwt.Token) (interface{}, error) {
+		return security.Secret(), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(twoFactorChallengeAudience),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return token.Claims.(*Claims), nil
+}
@@ -0,0 +1,462 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/user"
+)
+
+const (
+	defaultUsersPerPage = 20
+	maxUsersPerPage     = 100
+)
+
+type UserHandler struct {
+	updateUserUseCase         user.UpdateUserUseCase
+	setUserActiveUseCase      user.SetUserActiveUseCase
+	deleteUserUseCase         user.DeleteUserUseCase
+	revokeUserSessionsUseCase session.RevokeUserSessionsUseCase
+	unlockUserUseCase         user.UnlockUserUseCase
+}
+
+func NewUserHandler(
+	updateUserUseCase user.UpdateUserUseCase,
+	setUserActiveUseCase user.SetUserActiveUseCase,
+	deleteUserUseCase user.DeleteUserUseCase,
+	revokeUserSessionsUseCase session.RevokeUserSessionsUseCase,
+	unlockUserUseCase user.UnlockUserUseCase,
+) *UserHandler {
+	return &UserHandler{
+		updateUserUseCase:         updateUserUseCase,
+		setUserActiveUseCase:      setUserActiveUseCase,
+		deleteUserUseCase:         deleteUserUseCase,
+		revokeUserSessionsUseCase: revokeUserSessionsUseCase,
+		unlockUserUseCase:         unlockUserUseCase,
+	}
+}
+
+// ListUsersRequest represents the list users request query
+type ListUsersRequest struct {
+	Query   string `query:"query"`
+	Page    int    `query:"page" validate:"omitempty,min=1"`
+	PerPage int    `query:"per_page" validate:"omitempty,min=1,max=100"`
+}
+
+// UpdateUserRequest represents the update user request body
+type UpdateUserRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email" validate:"omitempty,email"`
+	Role  string `json:"role" validate:"omitempty,max=50"`
+}
+
+// UserListResponse represents a page of users
+type UserListResponse struct {
+	Users   []*model.User `json:"users"`
+	Total   int64         `json:"total"`
+	Page    int           `json:"page"`
+	PerPage int           `json:"per_page"`
+}
+
+// List handles listing users
+// @Summary List users
+// @Description List users of the tenant, optionally filtered by name or email
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param query query string false "Search query"
+// @Param page query int false "Page number (starting at 1)"
+// @Param per_page query int false "Users per page (max 100)"
+// @Security ApiKeyAuth
+// @Success 200 {object} UserListResponse
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /users [get]
+func (h *UserHandler) List(c echo.Context) error {
+	var req ListUsersRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request parameters", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Apply pagination defaults
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.PerPage == 0 {
+		req.PerPage = defaultUsersPerPage
+	}
+	if req.PerPage > maxUsersPerPage {
+		req.PerPage = maxUsersPerPage
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).User()
+
+	// Search users
+	users, total, err := repo.Search(req.Query, claims.TenantID, (req.Page-1)*req.PerPage, req.PerPage)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list users", err)
+	}
+
+	return appErrors.SendOK(c, UserListResponse{
+		Users:   users,
+		Total:   total,
+		Page:    req.Page,
+		PerPage: req.PerPage,
+	})
+}
+
+// Get handles getting a user by ID
+// @Summary Get user
+// @Description Get a user by ID
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.User
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 404 {object} appErrors.ErrorResponse
+// @Router /users/{id} [get]
+func (h *UserHandler) Get(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).User()
+
+	// Get user
+	user, err := repo.FindByID(id, claims.TenantID)
+	if err != nil {
+		return appErrors.UserNotFound(err)
+	}
+
+	return appErrors.SendOK(c, user)
+}
+
+// Update handles updating a user
+// @Summary Update user
+// @Description Update a user's profile or role
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body UpdateUserRequest true "User data"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.User
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /users/{id} [put]
+func (h *UserHandler) Update(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	var req UpdateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return appErrors.NewValidationError("Invalid request body", nil, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Only user managers can change roles, and not their own
+	if req.Role != "" && !claims.HasPermission(model.PermissionUsersManage) {
+		return appErrors.Forbidden("You don't have permission to change roles", nil)
+	}
+	if req.Role != "" && id == claims.UserID && req.Role != claims.Role {
+		return appErrors.Forbidden("You can't change your own role", nil)
+	}
+
+	// Update user
+	updated, err := h.updateUserUseCase.Execute(user.UpdateUserInput{
+		ID:       id,
+		Name:     req.Name,
+		Email:    req.Email,
+		TenantID: claims.TenantID,
+		Role:     req.Role,
+		Actor:    claims.Subject(),
+	})
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidRole) {
+			return appErrors.NewValidationError("Invalid role", nil, err)
+		}
+		if errors.Is(err, user.ErrEmailChangeNotAllowed) {
+			return appErrors.Forbidden("You can't change the email of another user", err)
+		}
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You can't change a user or grant a role with permissions you don't have", err)
+		}
+		return appErrors.InternalServerError("Failed to update user", err)
+	}
+
+	return appErrors.SendOK(c, updated)
+}
+
+// Deactivate handles deactivating a user
+// @Summary Deactivate user
+// @Description Block a user from logging in without deleting their content
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.User
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /users/{id}/deactivate [post]
+func (h *UserHandler) Deactivate(c echo.Context) error {
+	return h.setActive(c, false)
+}
+
+// Reactivate handles reactivating a user
+// @Summary Reactivate user
+// @Description Allow a deactivated user to log in again
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.User
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /users/{id}/reactivate [post]
+func (h *UserHandler) Reactivate(c echo.Context) error {
+	return h.setActive(c, true)
+}
+
+func (h *UserHandler) setActive(c echo.Context, active bool) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Admins can't lock themselves out
+	if !active && id == claims.UserID {
+		return appErrors.Forbidden("You can't deactivate yourself", nil)
+	}
+
+	// Update activation state
+	user, err := h.setUserActiveUseCase.Execute(user.SetUserActiveInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+		Active:   active,
+		Actor:    claims.Subject(),
+	})
+	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You can't change a user with permissions you don't have", err)
+		}
+		return appErrors.InternalServerError("Failed to update user", err)
+	}
+
+	return appErrors.SendOK(c, user)
+}
+
+// Unlock handles lifting a user's login lockout
+// @Summary Unlock user
+// @Description Clear a user's failed logins so that they can log in again right away
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.User
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /users/{id}/unlock [post]
+func (h *UserHandler) Unlock(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Unlock user
+	unlockedUser, err := h.unlockUserUseCase.Execute(user.UnlockUserInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to unlock user", err)
+	}
+
+	return appErrors.SendOK(c, unlockedUser)
+}
+
+// Delete handles deleting a user
+// @Summary Delete user
+// @Description Delete a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 204 {object} nil
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /users/{id} [delete]
+func (h *UserHandler) Delete(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Admins can't delete themselves
+	if id == claims.UserID {
+		return appErrors.Forbidden("You can't delete yourself", nil)
+	}
+
+	// Delete user
+	err := h.deleteUserUseCase.Execute(user.DeleteUserInput{
+		ID:       id,
+		TenantID: claims.TenantID,
+		Actor:    claims.Subject(),
+	})
+	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return appErrors.Forbidden("You can't delete a user with permissions you don't have", err)
+		}
+		return appErrors.InternalServerError("Failed to delete user", err)
+	}
+
+	return appErrors.SendNoContent(c)
+}
+
+// Sessions handles listing a user's active sessions
+// @Summary List user sessions
+// @Description List the devices a user is signed in on
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 200 {array} model.Session
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /users/{id}/sessions [get]
+func (h *UserHandler) Sessions(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Get repositories
+	repo := c.Get("repositories").(RepositoriesProvider).Session()
+
+	// Get sessions
+	sessions, err := repo.FindActiveByUserID(id, claims.TenantID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to list sessions", err)
+	}
+
+	return appErrors.SendOK(c, sessions)
+}
+
+// RevokeSessions handles signing a user out of all devices
+// @Summary Revoke user sessions
+// @Description Terminate all sessions of a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 204 {object} nil
+// @Failure 400 {object} appErrors.ErrorResponse
+// @Failure 401 {object} appErrors.ErrorResponse
+// @Failure 403 {object} appErrors.ErrorResponse
+// @Failure 500 {object} appErrors.ErrorResponse
+// @Router /users/{id}/sessions [delete]
+func (h *UserHandler) RevokeSessions(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return appErrors.NewValidationError("ID is required", nil, nil)
+	}
+
+	// Get user claims from context
+	claims := getUserClaims(c)
+	if claims == nil {
+		return appErrors.Unauthorized("Authentication required", nil)
+	}
+
+	// Revoke sessions
+	err := h.revokeUserSessionsUseCase.Execute(session.RevokeUserSessionsInput{
+		UserID:   id,
+		TenantID: claims.TenantID,
+	})
+	if err != nil {
+		return appErrors.InternalServerError("Failed to revoke sessions", err)
+	}
+
+	return appErrors.SendNoContent(c)
+}
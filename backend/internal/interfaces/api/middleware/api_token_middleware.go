@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+)
+
+// APITokenContextKey is the context key of the API token a request was authenticated with
+const APITokenContextKey = "api_token"
+
+// apiTokenScopeRule names the scopes needed to read and write the routes under a path
+type apiTokenScopeRule struct {
+	prefix string
+	read   string
+	write  string
+}
+
+// apiTokenScopeRules are checked in order and the first matching rule applies.
+// Routes without a rule, or whose rule names no scope, can only be used with a
+// JWT.
+var apiTokenScopeRules = []apiTokenScopeRule{
+	// Changing who can see knowledge, directly or by moving it to another
+	// space, is left to users rather than their tokens
+	{prefix: "/api/knowledge/:id/access"},
+	{prefix: "/api/knowledge/:id/move"},
+	{prefix: "/api/knowledge/:knowledge_id/comments", read: model.ScopeCommentsRead, write: model.ScopeCommentsWrite},
+	{prefix: "/api/comments", read: model.ScopeCommentsRead, write: model.ScopeCommentsWrite},
+	{prefix: "/api/knowledge", read: model.ScopeKnowledgeRead, write: model.ScopeKnowledgeWrite},
+	{prefix: "/api/tags", read: model.ScopeTagsRead, write: model.ScopeTagsWrite},
+}
+
+// APITokenScopeMiddleware returns a middleware that checks requests made with
+// an API token against the token's scopes. Requests made with a JWT pass through.
+func APITokenScopeMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			apiToken, ok := c.Get(APITokenContextKey).(*model.APIToken)
+			if !ok {
+				return next(c)
+			}
+
+			scope := requiredScope(c.Path(), c.Request().Method)
+			if scope == "" {
+				return appErrors.Forbidden("This endpoint can't be used with an API token", nil)
+			}
+			if !apiToken.HasScope(scope) {
+				return appErrors.Forbidden("API token is missing the "+scope+" scope", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requiredScope returns the scope needed for the route, or an empty string
+// if API tokens may not use it
+func requiredScope(path string, method string) string {
+	for _, rule := range apiTokenScopeRules {
+		if path != rule.prefix && !strings.HasPrefix(path, rule.prefix+"/") {
+			continue
+		}
+		if method == http.MethodGet || method == http.MethodHead {
+			return rule.read
+		}
+		return rule.write
+	}
+	return ""
+}
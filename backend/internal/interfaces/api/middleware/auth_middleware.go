@@ -1,52 +1,201 @@
 package middleware
 
 import (
-	"os"
+	"errors"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/interfaces/api/handlers"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/apitoken"
 )
 
-// JWTConfig returns the JWT middleware configuration
-func JWTConfig() echojwt.Config {
-	// Get JWT secret from environment variable
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "default-secret-key" // Default secret for development
-	}
+var (
+	// errSessionRevoked is returned when the token's session was revoked or has expired
+	errSessionRevoked = errors.New("session has been revoked")
 
+	// errTwoFactorChallenge is returned for a two-factor challenge, which can't be used as an access token
+	errTwoFactorChallenge = errors.New("two-factor challenge is not an access token")
+
+	// errTenantMismatch is returned when the token was issued for another tenant than the request's
+	errTenantMismatch = errors.New("token was issued for another tenant")
+)
+
+// JWTConfig returns the JWT middleware configuration. Besides checking the
+// signature, tokens are rejected when the session named by their ID (jti) is
+// no longer active, or when they belong to another tenant than the one
+// resolved from the request. API tokens are accepted in place of a JWT as well.
+func JWTConfig() echojwt.Config {
 	return echojwt.Config{
-		SigningKey: []byte(jwtSecret),
-		NewClaimsFunc: func(c echo.Context) jwt.Claims {
-			return &handlers.Claims{}
-		},
+		ParseTokenFunc: parseToken,
 		ErrorHandler: func(c echo.Context, err error) error {
+			if errors.Is(err, errTenantMismatch) {
+				return appErrors.Unauthorized("Token is not valid for this tenant", err)
+			}
 			return appErrors.Unauthorized("Invalid or expired token", err)
 		},
 	}
 }
 
-// RoleMiddleware returns a middleware that checks if the user has the required role
-func RoleMiddleware(roles ...string) echo.MiddlewareFunc {
+// parseToken validates the token and checks its session has not been revoked
+// and its user is still active
+func parseToken(c echo.Context, auth string) (interface{}, error) {
+	if apitoken.IsAPIToken(auth) {
+		return parseAPIToken(c, auth)
+	}
+
+	token, err := jwt.ParseWithClaims(auth, &handlers.Claims{}, func(t *jwt.Token) (interface{}, error) {
+		return security.Secret(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	claims := token.Claims.(*handlers.Claims)
+	if claims.IsTwoFactorChallenge() {
+		return nil, errTwoFactorChallenge
+	}
+	if claims.ID == "" {
+		return nil, errSessionRevoked
+	}
+	if !matchesRequestTenant(c, claims.TenantID) {
+		return nil, errTenantMismatch
+	}
+
+	// Check session
+	repos := c.Get("repositories").(handlers.RepositoriesProvider)
+	repo := repos.Session()
+	session, err := repo.FindByID(claims.ID, claims.TenantID)
+	if err != nil || session == nil || !session.IsActive() || session.UserID != claims.UserID {
+		return nil, errSessionRevoked
+	}
+
+	// Record activity for the session list, at most once per interval
+	if session.NeedsTouch(time.Now()) {
+		if err := repo.Touch(session, c.RealIP()); err != nil {
+			c.Logger().Warnf("failed to record session activity: %v", err)
+		}
+	}
+
+	// Check user. The role is read from the user row rather than the token,
+	// so that a role change applies to the user's existing sessions.
+	user, err := repos.User().FindByID(claims.UserID, claims.TenantID)
+	if err != nil || user == nil || !user.IsActive() {
+		return nil, errSessionRevoked
+	}
+	claims.Role = user.Role
+	claims.Permissions = userPermissions(c, user.ID, user.Role, user.TenantID)
+	return token, nil
+}
+
+// parseAPIToken validates an API token and represents it as a JWT with the
+// claims of its user, so that handlers treat both the same way. The token
+// itself is kept in the context for APITokenScopeMiddleware.
+func parseAPIToken(c echo.Context, auth string) (interface{}, error) {
+	repos := c.Get("repositories").(handlers.RepositoriesProvider)
+	signer := security.NewTokenSigner(security.Secret(), apitoken.TokenPurpose)
+
+	// Check API token
+	authenticated, err := apitoken.NewAuthenticateAPITokenUseCase(repos.APIToken(), repos.User(), signer).Execute(auth)
+	if err != nil {
+		return nil, err
+	}
+	if !matchesRequestTenant(c, authenticated.User.TenantID) {
+		return nil, errTenantMismatch
+	}
+	apiToken := authenticated.APIToken
+	token := userToken(c, authenticated.User)
+
+	// Service keys act as the admin who created them, so they stop working
+	// when the admin can no longer manage the tenant's keys
+	if apiToken.Kind == model.APITokenKindService && !token.Claims.(*handlers.Claims).HasPermission(model.PermissionTenantManage) {
+		return nil, apitoken.ErrInvalidAPIToken
+	}
+
+	// Record usage, at most once per interval
+	if apiToken.NeedsTouch(time.Now()) {
+		if err := repos.APIToken().Touch(apiToken); err != nil {
+			c.Logger().Warnf("failed to record API token usage: %v", err)
+		}
+	}
+
+	c.Set(APITokenContextKey, apiToken)
+	return token, nil
+}
+
+// userToken represents a user authenticated by other means as a JWT with the
+// user's claims
+func userToken(c echo.Context, user *model.User) *jwt.Token {
+	return &jwt.Token{
+		Valid: true,
+		Claims: &handlers.Claims{
+			UserID:      user.ID,
+			Email:       user.Email,
+			Role:        user.Role,
+			TenantID:    user.TenantID,
+			Permissions: userPermissions(c, user.ID, user.Role, user.TenantID),
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject: user.ID,
+			},
+		},
+	}
+}
+
+// userPermissions returns the permissions of the user's role and of the roles
+// of the groups they are a member of
+func userPermissions(c echo.Context, userID string, role string, tenantID string) []string {
+	permissions := append([]string{}, rolePermissions(c, role, tenantID)...)
+
+	repo := c.Get("repositories").(handlers.RepositoriesProvider).Group()
+	groups, err := repo.FindByUserID(userID, tenantID)
+	if err != nil {
+		c.Logger().Warnf("failed to load group roles: %v", err)
+		return permissions
+	}
+
+	granted := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		granted[p] = true
+	}
+	for _, g := range groups {
+		if g.Role == "" || g.Role == role {
+			continue
+		}
+		for _, p := range rolePermissions(c, g.Role, tenantID) {
+			if !granted[p] {
+				granted[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	return permissions
+}
+
+// rolePermissions returns the permissions of the role in the tenant. The
+// default roles keep their default permissions if the tenant's roles haven't
+// been seeded.
+func rolePermissions(c echo.Context, role string, tenantID string) []string {
+	repo := c.Get("repositories").(handlers.RepositoriesProvider).Role()
+	tenantRole, err := repo.FindByName(role, tenantID)
+	if err != nil || tenantRole == nil {
+		return model.DefaultRolePermissions()[model.Role(role)]
+	}
+	return tenantRole.Permissions
+}
+
+// PermissionMiddleware returns a middleware that checks if the user's role grants the permission
+func PermissionMiddleware(permission string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			user := c.Get("user").(*jwt.Token)
 			claims := user.Claims.(*handlers.Claims)
 
-			// Check if user has one of the required roles
-			hasRole := false
-			for _, role := range roles {
-				if claims.Role == role {
-					hasRole = true
-					break
-				}
-			}
-
-			if !hasRole {
+			if !claims.HasPermission(permission) {
 				return appErrors.Forbidden("Insufficient permissions", nil)
 			}
 
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/interfaces/api/handlers"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/apitoken"
+)
+
+// SCIMAuthMiddleware returns a middleware that authenticates SCIM requests
+// with a tenant SCIM token. The request acts as the admin who created the
+// token, within the token's tenant. Errors use the SCIM error format.
+func SCIMAuthMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			auth, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok || !apitoken.IsSCIMToken(auth) {
+				return handlers.SCIMError(c, http.StatusUnauthorized, "", "A SCIM token is required")
+			}
+
+			repos := c.Get("repositories").(handlers.RepositoriesProvider)
+			signer := security.NewTokenSigner(security.Secret(), apitoken.TokenPurpose)
+
+			// Check SCIM token
+			authenticated, err := apitoken.NewAuthenticateAPITokenUseCase(repos.APIToken(), repos.User(), signer).Execute(auth)
+			if err != nil || authenticated.APIToken.Kind != model.APITokenKindSCIM {
+				return handlers.SCIMError(c, http.StatusUnauthorized, "", "Invalid or expired SCIM token")
+			}
+			apiToken := authenticated.APIToken
+
+			// Record usage, at most once per interval
+			if apiToken.NeedsTouch(time.Now()) {
+				if err := repos.APIToken().Touch(apiToken); err != nil {
+					c.Logger().Warnf("failed to record SCIM token usage: %v", err)
+				}
+			}
+
+			c.Set(APITokenContextKey, apiToken)
+			c.Set("user", userToken(c, authenticated.User))
+			return next(c)
+		}
+	}
+}
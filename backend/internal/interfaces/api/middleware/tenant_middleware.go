@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	appErrors "github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/errors"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/interfaces/api/handlers"
+)
+
+// TenantHeader is the request header naming the tenant by domain or ID, for
+// clients that can't use a tenant's own host
+const TenantHeader = "X-Tenant"
+
+// ResolveTenantMiddleware returns a middleware that resolves the tenant of the
+// request and puts it in the context. The tenant is named by the X-Tenant
+// header, or by the Host header: either a tenant's custom domain, or a
+// subdomain of baseDomain such as acme.example.com for the tenant with domain
+// "acme". Requests on other hosts have no tenant.
+func ResolveTenantMiddleware(baseDomain string) echo.MiddlewareFunc {
+	baseDomain = strings.ToLower(strings.TrimPrefix(baseDomain, "."))
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			repo := c.Get("repositories").(handlers.RepositoriesProvider).Tenant()
+
+			// Named explicitly by the client
+			if name := strings.TrimSpace(c.Request().Header.Get(TenantHeader)); name != "" {
+				tenant := findTenant(repo, name)
+				if tenant == nil {
+					return appErrors.NotFound("Tenant not found", nil)
+				}
+				c.Set(handlers.TenantContextKey, tenant)
+				return next(c)
+			}
+
+			// Named by the host
+			if tenant := tenantOfHost(repo, c.Request().Host, baseDomain); tenant != nil {
+				c.Set(handlers.TenantContextKey, tenant)
+			}
+			return next(c)
+		}
+	}
+}
+
+// disabledTenantRoutes are the routes still available to the users of a
+// tenant that is scheduled for deletion, so that the deletion can be followed
+// and canceled
+var disabledTenantRoutes = map[string]bool{
+	"GET /api/auth/me":                      true,
+	"POST /api/auth/logout":                 true,
+	"GET /api/auth/me/tenants":              true,
+	"POST /api/auth/switch-tenant":          true,
+	"GET /api/tenants/:id":                  true,
+	"GET /api/tenants/:id/deletion":         true,
+	"POST /api/tenants/:id/deletion/cancel": true,
+}
+
+// ActiveTenantMiddleware returns a middleware that rejects requests of users
+// and tokens of a tenant that is scheduled for deletion, except on the routes
+// needed to cancel the deletion
+func ActiveTenantMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := c.Get("user").(*jwt.Token)
+			if !ok {
+				return next(c)
+			}
+			claims, ok := user.Claims.(*handlers.Claims)
+			if !ok || claims.TenantID == "" {
+				return next(c)
+			}
+
+			repo := c.Get("repositories").(handlers.RepositoriesProvider).Tenant()
+			tenant, err := repo.FindByID(claims.TenantID)
+			if err != nil || tenant == nil {
+				return appErrors.NotFound("Tenant not found", err)
+			}
+			if tenant.IsDisabled() && !disabledTenantRoutes[c.Request().Method+" "+c.Path()] {
+				return appErrors.Forbidden("This tenant is scheduled for deletion", nil)
+			}
+			return next(c)
+		}
+	}
+}
+
+// tenantOfHost returns the tenant whose custom domain is the host or whose
+// domain is its subdomain of baseDomain, or nil
+func tenantOfHost(repo repository.TenantRepository, host string, baseDomain string) *model.Tenant {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.ToLower(host)
+	if host == "" || host == baseDomain {
+		return nil
+	}
+
+	// Custom domain
+	if tenant, err := repo.FindByDomain(host); err == nil && tenant != nil {
+		return tenant
+	}
+
+	// Subdomain of the base domain
+	if baseDomain == "" {
+		return nil
+	}
+	subdomain, ok := strings.CutSuffix(host, "."+baseDomain)
+	if !ok || strings.Contains(subdomain, ".") {
+		return nil
+	}
+	if tenant, err := repo.FindByDomain(subdomain); err == nil && tenant != nil {
+		return tenant
+	}
+	return nil
+}
+
+// findTenant returns the tenant with the domain or ID, or nil
+func findTenant(repo repository.TenantRepository, name string) *model.Tenant {
+	if tenant, err := repo.FindByDomain(strings.ToLower(name)); err == nil && tenant != nil {
+		return tenant
+	}
+	if tenant, err := repo.FindByID(name); err == nil && tenant != nil {
+		return tenant
+	}
+	return nil
+}
+
+// matchesRequestTenant reports whether a token of the tenant may be used for
+// the request, i.e. the request has no tenant or the same one
+func matchesRequestTenant(c echo.Context, tenantID string) bool {
+	tenant, ok := c.Get(handlers.TenantContextKey).(*model.Tenant)
+	return !ok || tenant.ID == tenantID
+}
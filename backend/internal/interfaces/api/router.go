@@ -1,16 +1,37 @@
 package api
 
 import (
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	echojwt "github.com/labstack/echo-jwt/v4"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/oidc"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/persistence"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/interfaces/api/handlers"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/interfaces/api/middleware"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/access"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/apitoken"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/comment"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/group"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/invitation"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/knowledge"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/membership"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/moderation"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/notification"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/role"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/scim"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/session"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/space"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/sso"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/tag"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/tenant"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/twofactor"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/user"
 )
 
@@ -18,14 +39,40 @@ import (
 type Router struct {
 	e           *echo.Echo
 	repositories *persistence.Repositories
+	mailer      mail.Mailer
 }
 
 // NewRouter creates a new router
-func NewRouter(e *echo.Echo, repositories *persistence.Repositories) *Router {
+func NewRouter(e *echo.Echo, repositories *persistence.Repositories, mailer mail.Mailer) *Router {
 	return &Router{
 		e:           e,
 		repositories: repositories,
+		mailer:      mailer,
+	}
+}
+
+// appURL returns the base URL of the frontend used in links sent by email
+func appURL() string {
+	url := os.Getenv("APP_URL")
+	if url == "" {
+		url = "http://localhost:3000"
 	}
+	return url
+}
+
+// apiURL returns the public base URL of the API used in callbacks from identity providers
+func apiURL() string {
+	url := os.Getenv("API_URL")
+	if url == "" {
+		url = "http://localhost:8080"
+	}
+	return url
+}
+
+// tenantBaseDomain returns the domain whose subdomains name tenants, e.g.
+// acme.example.com for the tenant with domain "acme" when it is example.com
+func tenantBaseDomain() string {
+	return os.Getenv("TENANT_BASE_DOMAIN")
 }
 
 // SetupRoutes sets up the API routes
@@ -34,18 +81,98 @@ func (r *Router) SetupRoutes() {
 	api := r.e.Group("/api")
 
 	// Set repositories in context
-	api.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			c.Set("repositories", r.repositories)
-			return next(c)
-		}
-	})
+	api.Use(r.repositoriesMiddleware)
+
+	// Resolve the tenant from the host or the X-Tenant header
+	api.Use(middleware.ResolveTenantMiddleware(tenantBaseDomain()))
 
 	// Public routes
 	r.setupPublicRoutes(api)
 
 	// Protected routes
 	r.setupProtectedRoutes(api)
+
+	// SCIM provisioning routes
+	r.setupSCIMRoutes()
+}
+
+// repositoriesMiddleware sets the repositories in the context
+func (r *Router) repositoriesMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Set("repositories", r.repositories)
+		return next(c)
+	}
+}
+
+// tenantScopeMiddleware runs the rest of an authenticated request in a
+// transaction in which the repositories in the context are limited to the
+// user's tenant by row-level security. The transaction is rolled back when
+// the handler fails.
+func (r *Router) tenantScopeMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := c.Get("user").(*jwt.Token)
+		if !ok {
+			return next(c)
+		}
+		claims, ok := token.Claims.(*handlers.Claims)
+		if !ok || claims.TenantID == "" {
+			return next(c)
+		}
+
+		var handlerErr error
+		err := r.repositories.WithTenant(claims.TenantID, func(scoped *persistence.Repositories) error {
+			c.Set("repositories", scoped)
+			handlerErr = next(c)
+			return handlerErr
+		})
+		if handlerErr != nil {
+			return handlerErr
+		}
+		return err
+	}
+}
+
+// scoped adapts methods of the handler built by newHandler to handler funcs
+// which build it for each request from the repositories in the context.
+// Behind tenantScopeMiddleware those are limited to the user's tenant by
+// row-level security, which the router's own repositories bypass as they
+// connect as the tables' owner.
+func scoped[H any](r *Router, newHandler func(r *Router) H) func(method func(H, echo.Context) error) echo.HandlerFunc {
+	return func(method func(H, echo.Context) error) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			repositories := c.Get("repositories").(*persistence.Repositories)
+			return method(newHandler(r.withRepositories(repositories)), c)
+		}
+	}
+}
+
+// withRepositories returns a copy of the router building use cases with the repositories
+func (r *Router) withRepositories(repositories *persistence.Repositories) *Router {
+	return &Router{
+		e:            r.e,
+		repositories: repositories,
+		mailer:       r.mailer,
+	}
+}
+
+// setupSCIMRoutes sets up the SCIM 2.0 routes used by identity providers,
+// authenticated with a tenant SCIM token instead of a JWT
+func (r *Router) setupSCIMRoutes() {
+	scimGroup := r.e.Group("/scim/v2", r.repositoriesMiddleware, middleware.SCIMAuthMiddleware(), r.tenantScopeMiddleware, middleware.ActiveTenantMiddleware())
+	scimHandler := scoped(r, (*Router).newSCIMHandler)
+	scimGroup.GET("/ServiceProviderConfig", scimHandler((*handlers.SCIMHandler).ServiceProviderConfig))
+	scimGroup.GET("/Users", scimHandler((*handlers.SCIMHandler).ListUsers))
+	scimGroup.POST("/Users", scimHandler((*handlers.SCIMHandler).CreateUser))
+	scimGroup.GET("/Users/:id", scimHandler((*handlers.SCIMHandler).GetUser))
+	scimGroup.PUT("/Users/:id", scimHandler((*handlers.SCIMHandler).ReplaceUser))
+	scimGroup.PATCH("/Users/:id", scimHandler((*handlers.SCIMHandler).PatchUser))
+	scimGroup.DELETE("/Users/:id", scimHandler((*handlers.SCIMHandler).DeleteUser))
+	scimGroup.GET("/Groups", scimHandler((*handlers.SCIMHandler).ListGroups))
+	scimGroup.POST("/Groups", scimHandler((*handlers.SCIMHandler).CreateGroup))
+	scimGroup.GET("/Groups/:id", scimHandler((*handlers.SCIMHandler).GetGroup))
+	scimGroup.PUT("/Groups/:id", scimHandler((*handlers.SCIMHandler).ReplaceGroup))
+	scimGroup.PATCH("/Groups/:id", scimHandler((*handlers.SCIMHandler).PatchGroup))
+	scimGroup.DELETE("/Groups/:id", scimHandler((*handlers.SCIMHandler).DeleteGroup))
 }
 
 // setupPublicRoutes sets up the public API routes
@@ -56,69 +183,392 @@ func (r *Router) setupPublicRoutes(api *echo.Group) {
 	})
 
 	// Auth handler (public routes)
-	authHandler := handlers.NewAuthHandler(
-		user.NewAuthenticateUserUseCase(r.repositories.User(), r.repositories.Tenant()),
-		user.NewRegisterUserUseCase(r.repositories.User(), r.repositories.Tenant()),
-	)
+	authHandler := r.newAuthHandler()
 	authHandler.RegisterRoutes(api)
 
-	// Tenant handler (public endpoints)
-	tenantHandler := handlers.NewTenantHandler(
-		tenant.NewCreateTenantUseCase(r.repositories.Tenant()),
-		tenant.NewUpdateTenantSettingsUseCase(r.repositories.Tenant()),
-		tenant.NewDeleteTenantUseCase(r.repositories.Tenant()),
+	// Two-factor handler (second step of login)
+	twoFactorHandler := r.newTwoFactorHandler()
+	twoFactorHandler.RegisterRoutes(api)
+
+	// SSO handler (login through the tenant's identity provider)
+	ssoStateSigner := security.NewTokenSigner(security.Secret(), sso.StatePurpose)
+	oidcClient := oidc.NewClient(nil)
+	ssoHandler := handlers.NewSSOHandler(
+		sso.NewStartLoginUseCase(r.repositories.Tenant(), r.repositories.SSOState(), oidcClient, ssoStateSigner),
+		sso.NewCompleteLoginUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Tenant(), r.repositories.SSOState(), oidcClient, ssoStateSigner),
+		r.newCreateSessionUseCase(),
+		apiURL()+"/api/auth/sso/callback",
+		appURL()+"/sso/callback",
+	)
+	ssoHandler.RegisterRoutes(api)
+
+	// SAML handler (login through the tenant's SAML identity provider)
+	samlBaseURL := apiURL() + "/api/auth/saml"
+	samlHandler := handlers.NewSAMLHandler(
+		sso.NewGetSAMLMetadataUseCase(r.repositories.Tenant(), samlBaseURL),
+		sso.NewStartSAMLLoginUseCase(r.repositories.Tenant(), r.repositories.SSOState(), samlBaseURL),
+		sso.NewCompleteSAMLLoginUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Tenant(), r.repositories.SSOState(), samlBaseURL),
+		r.newCreateSessionUseCase(),
+		appURL()+"/sso/callback",
 	)
+	samlHandler.RegisterRoutes(api)
+
+	// Invitation handler (public endpoints)
+	invitationHandler := r.newInvitationHandler()
+	api.POST("/auth/accept-invite", invitationHandler.Accept)
+
+	// Password handler (public endpoints)
+	passwordHandler := r.newPasswordHandler()
+	api.POST("/auth/forgot-password", passwordHandler.ForgotPassword)
+	api.POST("/auth/reset-password", passwordHandler.ResetPassword)
+
+	// Tenant handler (public endpoints)
+	tenantHandler := r.newTenantHandler()
 	api.GET("/tenants/domain/:domain", tenantHandler.GetByDomain)
 }
 
-// setupProtectedRoutes sets up the protected API routes
+// setupProtectedRoutes sets up the protected API routes. Their handlers are
+// built for each request from the repositories limited to the user's tenant,
+// except the few which work across tenants.
 func (r *Router) setupProtectedRoutes(api *echo.Group) {
-	// JWT middleware
+	// JWT middleware, which also accepts API tokens limited to their scopes
 	jwtMiddleware := echojwt.WithConfig(middleware.JWTConfig())
-	protected := api.Group("", jwtMiddleware)
+	protected := api.Group("", jwtMiddleware, middleware.APITokenScopeMiddleware(), r.tenantScopeMiddleware, middleware.ActiveTenantMiddleware())
 
 	// Auth handler (protected routes)
-	authHandler := handlers.NewAuthHandler(
-		user.NewAuthenticateUserUseCase(r.repositories.User(), r.repositories.Tenant()),
-		user.NewRegisterUserUseCase(r.repositories.User(), r.repositories.Tenant()),
-	)
-	authHandler.RegisterProtectedRoutes(protected)
+	authHandler := scoped(r, (*Router).newAuthHandler)
+	authGroup := protected.Group("/auth")
+	authGroup.GET("/me", authHandler((*handlers.AuthHandler).Me))
+	authGroup.PUT("/me", authHandler((*handlers.AuthHandler).UpdateMe))
+	authGroup.POST("/logout", authHandler((*handlers.AuthHandler).Logout))
+	authGroup.GET("/sessions", authHandler((*handlers.AuthHandler).ListSessions))
+	authGroup.DELETE("/sessions/:id", authHandler((*handlers.AuthHandler).RevokeSession))
+
+	// Two-factor handler (protected routes)
+	twoFactorHandler := scoped(r, (*Router).newTwoFactorHandler)
+	twoFactorGroup := protected.Group("/auth/2fa")
+	twoFactorGroup.POST("/enroll", twoFactorHandler((*handlers.TwoFactorHandler).Enroll))
+	twoFactorGroup.POST("/activate", twoFactorHandler((*handlers.TwoFactorHandler).Activate))
+	twoFactorGroup.POST("/disable", twoFactorHandler((*handlers.TwoFactorHandler).Disable))
+	twoFactorGroup.POST("/recovery-codes", twoFactorHandler((*handlers.TwoFactorHandler).RegenerateRecoveryCodes))
+
+	// Password handler (protected endpoints)
+	passwordHandler := scoped(r, (*Router).newPasswordHandler)
+	protected.POST("/auth/change-password", passwordHandler((*handlers.PasswordHandler).ChangePassword))
 
-	// Tenant handler (protected endpoints)
-	tenantHandler := handlers.NewTenantHandler(
-		tenant.NewCreateTenantUseCase(r.repositories.Tenant()),
-		tenant.NewUpdateTenantSettingsUseCase(r.repositories.Tenant()),
-		tenant.NewDeleteTenantUseCase(r.repositories.Tenant()),
+	// Membership handler (tenants of the current user). Listing, switching
+	// and joining tenants works across tenants, limited to the user's account
+	// by the use cases, so it isn't scoped to the current tenant.
+	membershipHandler := handlers.NewMembershipHandler(
+		membership.NewListMembershipsUseCase(r.repositories.Membership(), r.repositories.User(), r.repositories.Tenant()),
+		membership.NewSwitchTenantUseCase(r.repositories.Membership(), r.repositories.User(), r.repositories.Tenant()),
+		membership.NewJoinTenantUseCase(r.repositories.Membership(), r.repositories.Invitation(), r.repositories.User(), r.repositories.Tenant(), security.NewTokenSigner(security.Secret(), invitation.TokenPurpose)),
+		r.newCreateSessionUseCase(),
 	)
+	membershipHandler.RegisterRoutes(protected)
+
+	// Tenant handler (protected endpoints). Creating a tenant adds rows to
+	// a tenant other than the user's, so it isn't scoped to the current tenant.
+	tenantHandler := scoped(r, (*Router).newTenantHandler)
 	tenantGroup := protected.Group("/tenants")
-	tenantGroup.POST("", tenantHandler.Create, middleware.RoleMiddleware("admin"))
-	tenantGroup.GET("/:id", tenantHandler.Get)
-	tenantGroup.PUT("/:id/settings", tenantHandler.UpdateSettings, middleware.RoleMiddleware("admin"))
-	tenantGroup.DELETE("/:id", tenantHandler.Delete, middleware.RoleMiddleware("admin"))
+	tenantGroup.POST("", r.newTenantHandler().Create, middleware.PermissionMiddleware(model.PermissionTenantManage))
+	tenantGroup.GET("/:id", tenantHandler((*handlers.TenantHandler).Get))
+	tenantGroup.PUT("/:id/settings", tenantHandler((*handlers.TenantHandler).UpdateSettings), middleware.PermissionMiddleware(model.PermissionTenantManage))
+	tenantGroup.DELETE("/:id", tenantHandler((*handlers.TenantHandler).Delete), middleware.PermissionMiddleware(model.PermissionTenantManage))
+	tenantGroup.GET("/:id/deletion", tenantHandler((*handlers.TenantHandler).GetDeletion), middleware.PermissionMiddleware(model.PermissionTenantManage))
+	tenantGroup.POST("/:id/deletion/cancel", tenantHandler((*handlers.TenantHandler).CancelDeletion), middleware.PermissionMiddleware(model.PermissionTenantManage))
+
+	// User handler
+	userHandler := scoped(r, (*Router).newUserHandler)
+	userGroup := protected.Group("/users", middleware.PermissionMiddleware(model.PermissionUsersManage))
+	userGroup.GET("", userHandler((*handlers.UserHandler).List))
+	userGroup.GET("/:id", userHandler((*handlers.UserHandler).Get))
+	userGroup.PUT("/:id", userHandler((*handlers.UserHandler).Update))
+	userGroup.DELETE("/:id", userHandler((*handlers.UserHandler).Delete))
+	userGroup.POST("/:id/deactivate", userHandler((*handlers.UserHandler).Deactivate))
+	userGroup.POST("/:id/reactivate", userHandler((*handlers.UserHandler).Reactivate))
+	userGroup.POST("/:id/unlock", userHandler((*handlers.UserHandler).Unlock))
+	userGroup.GET("/:id/sessions", userHandler((*handlers.UserHandler).Sessions))
+	userGroup.DELETE("/:id/sessions", userHandler((*handlers.UserHandler).RevokeSessions))
+
+	// API token handler (personal tokens for everyone, service keys for tenant managers)
+	apiTokenHandler := scoped(r, (*Router).newAPITokenHandler)
+	protected.GET("/auth/tokens", apiTokenHandler((*handlers.APITokenHandler).ListPersonal))
+	protected.POST("/auth/tokens", apiTokenHandler((*handlers.APITokenHandler).CreatePersonal))
+	protected.DELETE("/auth/tokens/:id", apiTokenHandler((*handlers.APITokenHandler).RevokePersonal))
+	apiKeyGroup := protected.Group("/api-keys", middleware.PermissionMiddleware(model.PermissionTenantManage))
+	apiKeyGroup.GET("", apiTokenHandler((*handlers.APITokenHandler).ListService))
+	apiKeyGroup.POST("", apiTokenHandler((*handlers.APITokenHandler).CreateService))
+	apiKeyGroup.DELETE("/:id", apiTokenHandler((*handlers.APITokenHandler).RevokeService))
+	scimTokenGroup := protected.Group("/scim-tokens", middleware.PermissionMiddleware(model.PermissionTenantManage))
+	scimTokenGroup.GET("", apiTokenHandler((*handlers.APITokenHandler).ListSCIM))
+	scimTokenGroup.POST("", apiTokenHandler((*handlers.APITokenHandler).CreateSCIM))
+	scimTokenGroup.DELETE("/:id", apiTokenHandler((*handlers.APITokenHandler).RevokeSCIM))
+
+	// Role handler (everyone can list roles, managing them requires tenant.manage)
+	roleHandler := scoped(r, (*Router).newRoleHandler)
+	roleGroup := protected.Group("/roles")
+	roleGroup.GET("", roleHandler((*handlers.RoleHandler).List))
+	roleGroup.GET("/permissions", roleHandler((*handlers.RoleHandler).Permissions))
+	roleGroup.POST("", roleHandler((*handlers.RoleHandler).Create), middleware.PermissionMiddleware(model.PermissionTenantManage))
+	roleGroup.PUT("/:id", roleHandler((*handlers.RoleHandler).Update), middleware.PermissionMiddleware(model.PermissionTenantManage))
+	roleGroup.DELETE("/:id", roleHandler((*handlers.RoleHandler).Delete), middleware.PermissionMiddleware(model.PermissionTenantManage))
+
+	// Invitation handler
+	invitationHandler := scoped(r, (*Router).newInvitationHandler)
+	invitationGroup := protected.Group("/invitations", middleware.PermissionMiddleware(model.PermissionUsersInvite))
+	invitationGroup.POST("", invitationHandler((*handlers.InvitationHandler).Create))
+	invitationGroup.GET("", invitationHandler((*handlers.InvitationHandler).List))
+	invitationGroup.DELETE("/:id", invitationHandler((*handlers.InvitationHandler).Revoke))
+
+	// Group handler (everyone can list groups, managing them requires users.manage)
+	groupHandler := scoped(r, (*Router).newGroupHandler)
+	groupGroup := protected.Group("/groups")
+	groupGroup.GET("", groupHandler((*handlers.GroupHandler).List))
+	groupGroup.GET("/:id", groupHandler((*handlers.GroupHandler).Get))
+	groupGroup.GET("/:id/members", groupHandler((*handlers.GroupHandler).Members))
+	groupGroup.POST("", groupHandler((*handlers.GroupHandler).Create), middleware.PermissionMiddleware(model.PermissionUsersManage))
+	groupGroup.PUT("/:id", groupHandler((*handlers.GroupHandler).Update), middleware.PermissionMiddleware(model.PermissionUsersManage))
+	groupGroup.DELETE("/:id", groupHandler((*handlers.GroupHandler).Delete), middleware.PermissionMiddleware(model.PermissionUsersManage))
+	groupGroup.POST("/:id/members", groupHandler((*handlers.GroupHandler).AddMembers), middleware.PermissionMiddleware(model.PermissionUsersManage))
+	groupGroup.DELETE("/:id/members/:user_id", groupHandler((*handlers.GroupHandler).RemoveMember), middleware.PermissionMiddleware(model.PermissionUsersManage))
 
 	// Knowledge handler
-	knowledgeHandler := handlers.NewKnowledgeHandler(
-		knowledge.NewCreateKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.User(), r.repositories.Tag(), r.repositories.Tenant()),
-		knowledge.NewUpdateKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.Tag(), r.repositories.Tenant()),
-		knowledge.NewDeleteKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.Tenant()),
+	knowledgeHandler := scoped(r, (*Router).newKnowledgeHandler)
+	knowledgeGroup := protected.Group("/knowledge")
+	knowledgeGroup.POST("", knowledgeHandler((*handlers.KnowledgeHandler).Create))
+	knowledgeGroup.GET("", knowledgeHandler((*handlers.KnowledgeHandler).Search))
+	knowledgeGroup.GET("/:id", knowledgeHandler((*handlers.KnowledgeHandler).Get))
+	knowledgeGroup.GET("/:id/blocks", knowledgeHandler((*handlers.KnowledgeHandler).Blocks))
+	knowledgeGroup.PUT("/:id", knowledgeHandler((*handlers.KnowledgeHandler).Update))
+	knowledgeGroup.POST("/:id/move", knowledgeHandler((*handlers.KnowledgeHandler).Move))
+	knowledgeGroup.DELETE("/:id", knowledgeHandler((*handlers.KnowledgeHandler).Delete))
+
+	// Space handler
+	spaceHandler := scoped(r, (*Router).newSpaceHandler)
+	spaceGroup := protected.Group("/spaces")
+	spaceGroup.POST("", spaceHandler((*handlers.SpaceHandler).Create))
+	spaceGroup.GET("", spaceHandler((*handlers.SpaceHandler).List))
+	spaceGroup.GET("/:id", spaceHandler((*handlers.SpaceHandler).Get))
+	spaceGroup.GET("/:id/knowledge", spaceHandler((*handlers.SpaceHandler).Knowledge))
+	spaceGroup.PUT("/:id", spaceHandler((*handlers.SpaceHandler).Update))
+	spaceGroup.DELETE("/:id", spaceHandler((*handlers.SpaceHandler).Delete))
+
+	// Access control handler
+	accessHandler := scoped(r, (*Router).newAccessHandler)
+	spaceGroup.GET("/:id/access", accessHandler((*handlers.AccessHandler).GetSpaceAccess))
+	spaceGroup.PUT("/:id/access", accessHandler((*handlers.AccessHandler).UpdateSpaceAccess))
+	knowledgeGroup.GET("/:id/access", accessHandler((*handlers.AccessHandler).GetKnowledgeAccess))
+	knowledgeGroup.PUT("/:id/access", accessHandler((*handlers.AccessHandler).UpdateKnowledgeAccess))
+
+	// Tag handler
+	tagHandler := scoped(r, (*Router).newTagHandler)
+	tagGroup := protected.Group("/tags")
+	tagGroup.POST("", tagHandler((*handlers.TagHandler).Create))
+	tagGroup.GET("", tagHandler((*handlers.TagHandler).List))
+	tagGroup.PUT("/:id", tagHandler((*handlers.TagHandler).Update))
+	tagGroup.DELETE("/:id", tagHandler((*handlers.TagHandler).Delete))
+
+	// Comment handler
+	commentHandler := scoped(r, (*Router).newCommentHandler)
+	knowledgeCommentGroup := protected.Group("/knowledge/:knowledge_id/comments")
+	knowledgeCommentGroup.POST("", commentHandler((*handlers.CommentHandler).Create))
+	knowledgeCommentGroup.GET("", commentHandler((*handlers.CommentHandler).List))
+	knowledgeCommentGroup.PUT("/:comment_id", commentHandler((*handlers.CommentHandler).Update))
+	knowledgeCommentGroup.DELETE("/:comment_id", commentHandler((*handlers.CommentHandler).Delete))
+	knowledgeCommentGroup.GET("/:comment_id/history", commentHandler((*handlers.CommentHandler).History))
+	commentGroup := protected.Group("/comments")
+	commentGroup.POST("/:comment_id/resolve", commentHandler((*handlers.CommentHandler).Resolve))
+	commentGroup.POST("/:comment_id/unresolve", commentHandler((*handlers.CommentHandler).Unresolve))
+
+	// Moderation handler
+	moderationHandler := scoped(r, (*Router).newModerationHandler)
+	commentGroup.POST("/:comment_id/report", moderationHandler((*handlers.ModerationHandler).Report))
+	commentGroup.POST("/:comment_id/hide", moderationHandler((*handlers.ModerationHandler).Hide), middleware.PermissionMiddleware(model.PermissionCommentsModerate))
+	commentGroup.POST("/:comment_id/unhide", moderationHandler((*handlers.ModerationHandler).Unhide), middleware.PermissionMiddleware(model.PermissionCommentsModerate))
+	knowledgeGroup.POST("/:id/lock", moderationHandler((*handlers.ModerationHandler).Lock), middleware.PermissionMiddleware(model.PermissionCommentsModerate))
+	knowledgeGroup.POST("/:id/unlock", moderationHandler((*handlers.ModerationHandler).Unlock), middleware.PermissionMiddleware(model.PermissionCommentsModerate))
+	moderationGroup := protected.Group("/moderation", middleware.PermissionMiddleware(model.PermissionCommentsModerate))
+	moderationGroup.GET("/queue", moderationHandler((*handlers.ModerationHandler).Queue))
+	moderationGroup.GET("/actions", moderationHandler((*handlers.ModerationHandler).Actions))
+	moderationGroup.POST("/reports/:id/dismiss", moderationHandler((*handlers.ModerationHandler).DismissReport))
+}
+
+// newAuthHandler creates the auth handler shared by the public and protected routes
+func (r *Router) newAuthHandler() *handlers.AuthHandler {
+	signer := security.NewTokenSigner(security.Secret(), session.RefreshTokenPurpose)
+	return handlers.NewAuthHandler(
+		user.NewAuthenticateUserUseCase(r.repositories.User(), r.repositories.LoginThrottle(), r.repositories.Tenant()),
+		user.NewRegisterUserUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+		user.NewUpdateUserUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+		session.NewCreateSessionUseCase(r.repositories.Session(), r.repositories.Tenant(), signer),
+		session.NewRefreshSessionUseCase(r.repositories.Session(), r.repositories.User(), signer),
+		session.NewRevokeSessionUseCase(r.repositories.Session()),
+	)
+}
+
+// newCreateSessionUseCase creates the use case starting a session after a successful login
+func (r *Router) newCreateSessionUseCase() session.CreateSessionUseCase {
+	signer := security.NewTokenSigner(security.Secret(), session.RefreshTokenPurpose)
+	return session.NewCreateSessionUseCase(r.repositories.Session(), r.repositories.Tenant(), signer)
+}
+
+// newInvitationHandler creates the invitation handler shared by the public and protected routes
+func (r *Router) newInvitationHandler() *handlers.InvitationHandler {
+	signer := security.NewTokenSigner(security.Secret(), invitation.TokenPurpose)
+	return handlers.NewInvitationHandler(
+		invitation.NewCreateInvitationUseCase(r.repositories.Invitation(), r.repositories.User(), r.repositories.Role(), r.repositories.Tenant(), r.mailer, signer, appURL()+"/accept-invite"),
+		invitation.NewRevokeInvitationUseCase(r.repositories.Invitation(), r.repositories.Tenant()),
+		invitation.NewAcceptInvitationUseCase(r.repositories.Invitation(), user.NewRegisterUserUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()), signer),
+		r.newCreateSessionUseCase(),
+	)
+}
+
+// newPasswordHandler creates the password handler shared by the public and protected routes
+func (r *Router) newPasswordHandler() *handlers.PasswordHandler {
+	signer := security.NewTokenSigner(security.Secret(), user.PasswordResetTokenPurpose)
+	return handlers.NewPasswordHandler(
+		user.NewRequestPasswordResetUseCase(r.repositories.User(), r.repositories.PasswordReset(), r.repositories.Tenant(), r.mailer, signer, appURL()+"/reset-password"),
+		user.NewResetPasswordUseCase(r.repositories.User(), r.repositories.PasswordReset(), r.repositories.PasswordHistory(), r.repositories.Session(), r.repositories.Tenant(), signer),
+		user.NewChangePasswordUseCase(r.repositories.User(), r.repositories.PasswordReset(), r.repositories.PasswordHistory(), r.repositories.Session(), r.repositories.Tenant()),
+		r.newCreateSessionUseCase(),
+	)
+}
+
+// newTwoFactorHandler creates the two-factor handler shared by the public and protected routes
+func (r *Router) newTwoFactorHandler() *handlers.TwoFactorHandler {
+	return handlers.NewTwoFactorHandler(
+		twofactor.NewEnrollUseCase(r.repositories.User()),
+		twofactor.NewActivateUseCase(r.repositories.User(), r.repositories.RecoveryCode(), r.repositories.LoginThrottle(), r.repositories.Tenant(), time.Now),
+		twofactor.NewVerifyUseCase(r.repositories.User(), r.repositories.RecoveryCode(), r.repositories.LoginThrottle(), r.repositories.Tenant(), time.Now),
+		twofactor.NewDisableUseCase(r.repositories.User(), r.repositories.RecoveryCode(), r.repositories.Tenant(), time.Now),
+		twofactor.NewRegenerateRecoveryCodesUseCase(r.repositories.User(), r.repositories.RecoveryCode(), time.Now),
+		r.newCreateSessionUseCase(),
+	)
+}
+
+// newTenantHandler creates the tenant handler shared by the public and protected routes
+func (r *Router) newTenantHandler() *handlers.TenantHandler {
+	return handlers.NewTenantHandler(
+		tenant.NewCreateTenantUseCase(r.repositories.Tenant(), r.repositories.Role(), r.repositories.Invitation(), r.mailer, security.NewTokenSigner(security.Secret(), invitation.TokenPurpose), appURL()+"/accept-invite"),
+		tenant.NewUpdateTenantSettingsUseCase(r.repositories.Tenant(), r.repositories.Role()),
+		tenant.NewDeleteTenantUseCase(r.repositories.Tenant(), r.repositories.TenantDeletion(), r.repositories.User(), r.repositories.Session()),
+		tenant.NewCancelTenantDeletionUseCase(r.repositories.Tenant(), r.repositories.TenantDeletion()),
+	)
+}
+
+// newUserHandler creates the user handler
+func (r *Router) newUserHandler() *handlers.UserHandler {
+	return handlers.NewUserHandler(
+		user.NewUpdateUserUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+		user.NewSetUserActiveUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Session(), r.repositories.Tenant()),
+		user.NewDeleteUserUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+		session.NewRevokeUserSessionsUseCase(r.repositories.Session(), r.repositories.User(), r.repositories.Tenant()),
+		user.NewUnlockUserUseCase(r.repositories.User(), r.repositories.LoginThrottle(), r.repositories.Tenant()),
+	)
+}
+
+// newAPITokenHandler creates the API token handler
+func (r *Router) newAPITokenHandler() *handlers.APITokenHandler {
+	signer := security.NewTokenSigner(security.Secret(), apitoken.TokenPurpose)
+	return handlers.NewAPITokenHandler(
+		apitoken.NewCreateAPITokenUseCase(r.repositories.APIToken(), r.repositories.User(), r.repositories.Tenant(), signer),
+		apitoken.NewRevokeAPITokenUseCase(r.repositories.APIToken()),
+	)
+}
+
+// newRoleHandler creates the role handler
+func (r *Router) newRoleHandler() *handlers.RoleHandler {
+	return handlers.NewRoleHandler(
+		role.NewCreateRoleUseCase(r.repositories.Role(), r.repositories.Tenant()),
+		role.NewUpdateRoleUseCase(r.repositories.Role(), r.repositories.Tenant()),
+		role.NewDeleteRoleUseCase(r.repositories.Role(), r.repositories.Tenant()),
+	)
+}
+
+// newGroupHandler creates the group handler
+func (r *Router) newGroupHandler() *handlers.GroupHandler {
+	return handlers.NewGroupHandler(
+		group.NewCreateGroupUseCase(r.repositories.Group(), r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+		group.NewUpdateGroupUseCase(r.repositories.Group(), r.repositories.Role(), r.repositories.Tenant()),
+		group.NewDeleteGroupUseCase(r.repositories.Group(), r.repositories.Tenant()),
+		group.NewUpdateGroupMembersUseCase(r.repositories.Group(), r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+	)
+}
+
+// newNotifyMentionsUseCase creates the use case notifying the members of groups mentioned in knowledge and comments
+func (r *Router) newNotifyMentionsUseCase() notification.NotifyMentionsUseCase {
+	return notification.NewNotifyMentionsUseCase(r.repositories.Knowledge(), r.repositories.Group(), r.repositories.User(), r.repositories.Tenant(), r.mailer, appURL()+"/knowledge")
+}
+
+// newKnowledgeHandler creates the knowledge handler
+func (r *Router) newKnowledgeHandler() *handlers.KnowledgeHandler {
+	return handlers.NewKnowledgeHandler(
+		knowledge.NewCreateKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.User(), r.repositories.Tag(), r.repositories.Space(), r.repositories.Access(), r.repositories.Tenant()),
+		knowledge.NewUpdateKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.Tag(), r.repositories.Comment(), r.repositories.Access(), r.repositories.Tenant()),
+		knowledge.NewDeleteKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.Access(), r.repositories.Tenant()),
 		knowledge.NewSearchKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.Tag(), r.repositories.Tenant()),
-		r.repositories.Knowledge(),
+		knowledge.NewMoveKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.Space(), r.repositories.Access(), r.repositories.Tenant()),
+		r.newNotifyMentionsUseCase(),
 	)
-	knowledgeHandler.RegisterRoutes(protected)
+}
 
-	// Tag handler
-	tagHandler := handlers.NewTagHandler(
+// newSpaceHandler creates the space handler
+func (r *Router) newSpaceHandler() *handlers.SpaceHandler {
+	return handlers.NewSpaceHandler(
+		space.NewCreateSpaceUseCase(r.repositories.Space(), r.repositories.Tag(), r.repositories.Access(), r.repositories.Tenant()),
+		space.NewUpdateSpaceUseCase(r.repositories.Space(), r.repositories.Tag(), r.repositories.Access(), r.repositories.Tenant()),
+		space.NewDeleteSpaceUseCase(r.repositories.Space(), r.repositories.Knowledge(), r.repositories.Access(), r.repositories.Tenant()),
+		knowledge.NewSearchKnowledgeUseCase(r.repositories.Knowledge(), r.repositories.Tag(), r.repositories.Tenant()),
+	)
+}
+
+// newAccessHandler creates the access control handler
+func (r *Router) newAccessHandler() *handlers.AccessHandler {
+	return handlers.NewAccessHandler(
+		access.NewUpdateAccessUseCase(r.repositories.Access(), r.repositories.Knowledge(), r.repositories.Space(), r.repositories.User(), r.repositories.Group(), r.repositories.Tenant()),
+	)
+}
+
+// newTagHandler creates the tag handler
+func (r *Router) newTagHandler() *handlers.TagHandler {
+	return handlers.NewTagHandler(
 		tag.NewCreateTagUseCase(r.repositories.Tag(), r.repositories.Tenant()),
 		tag.NewUpdateTagUseCase(r.repositories.Tag(), r.repositories.Tenant()),
 		tag.NewDeleteTagUseCase(r.repositories.Tag(), r.repositories.Tenant()),
 	)
-	tagHandler.RegisterRoutes(protected)
+}
 
-	// Comment handler
-	commentHandler := handlers.NewCommentHandler(
+// newCommentHandler creates the comment handler
+func (r *Router) newCommentHandler() *handlers.CommentHandler {
+	return handlers.NewCommentHandler(
 		comment.NewCreateCommentUseCase(r.repositories.Comment(), r.repositories.Knowledge(), r.repositories.User(), r.repositories.Tenant()),
-		comment.NewUpdateCommentUseCase(r.repositories.Comment(), r.repositories.Tenant()),
+		comment.NewUpdateCommentUseCase(r.repositories.Comment(), r.repositories.Knowledge(), r.repositories.Tenant()),
 		comment.NewDeleteCommentUseCase(r.repositories.Comment(), r.repositories.Tenant()),
+		comment.NewResolveCommentUseCase(r.repositories.Comment(), r.repositories.Knowledge(), r.repositories.Tenant()),
+		r.newNotifyMentionsUseCase(),
 	)
-	commentHandler.RegisterRoutes(protected)
-}
\ No newline at end of file
+}
+
+// newModerationHandler creates the moderation handler
+func (r *Router) newModerationHandler() *handlers.ModerationHandler {
+	return handlers.NewModerationHandler(
+		moderation.NewReportCommentUseCase(r.repositories.Moderation(), r.repositories.Comment(), r.repositories.Tenant()),
+		moderation.NewHideCommentUseCase(r.repositories.Moderation(), r.repositories.Comment(), r.repositories.Tenant()),
+		moderation.NewLockDiscussionUseCase(r.repositories.Moderation(), r.repositories.Knowledge(), r.repositories.Tenant()),
+		moderation.NewDismissReportUseCase(r.repositories.Moderation(), r.repositories.Tenant()),
+	)
+}
+
+// newSCIMHandler creates the SCIM handler
+func (r *Router) newSCIMHandler() *handlers.SCIMHandler {
+	return handlers.NewSCIMHandler(
+		scim.NewProvisionUserUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+		scim.NewUpdateProvisionedUserUseCase(r.repositories.User(), r.repositories.Role(), r.repositories.Session(), r.repositories.Tenant()),
+		group.NewCreateGroupUseCase(r.repositories.Group(), r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+		group.NewUpdateGroupUseCase(r.repositories.Group(), r.repositories.Role(), r.repositories.Tenant()),
+		group.NewDeleteGroupUseCase(r.repositories.Group(), r.repositories.Tenant()),
+		group.NewUpdateGroupMembersUseCase(r.repositories.Group(), r.repositories.User(), r.repositories.Role(), r.repositories.Tenant()),
+		apiURL()+"/scim/v2",
+	)
+}
@@ -0,0 +1,69 @@
+// Package worker runs the background jobs of the API server.
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/persistence"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/tenant"
+)
+
+// tenantPurgeInterval is how often tenants whose deletion grace period has
+// passed are looked for
+const tenantPurgeInterval = time.Minute
+
+// TenantPurgeWorker purges the data of deleted tenants once their grace
+// period has passed. Several servers can run it at once, as every deletion
+// is leased to one of them.
+type TenantPurgeWorker struct {
+	purgeTenantsUseCase tenant.PurgeTenantsUseCase
+	logger              echo.Logger
+}
+
+// NewTenantPurgeWorker creates a new TenantPurgeWorker
+func NewTenantPurgeWorker(repositories *persistence.Repositories, mailer mail.Mailer, logger echo.Logger) *TenantPurgeWorker {
+	return &TenantPurgeWorker{
+		purgeTenantsUseCase: tenant.NewPurgeTenantsUseCase(repositories.Tenant(), repositories.TenantDeletion(), mailer),
+		logger:              logger,
+	}
+}
+
+// Run purges due tenants every interval until the context is done
+func (w *TenantPurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(tenantPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		w.purge()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge purges the tenants due now and logs the outcome of each deletion
+func (w *TenantPurgeWorker) purge() {
+	deletions, err := w.purgeTenantsUseCase.Execute(time.Now())
+	for _, deletion := range deletions {
+		switch deletion.Status {
+		case model.TenantDeletionStatusCompleted:
+			w.logger.Infof("Purged tenant %s (deletion %s): %v", deletion.TenantID, deletion.ID, deletion.Report)
+			if deletion.Error != "" {
+				w.logger.Warnf("Tenant deletion %s: %s", deletion.ID, deletion.Error)
+			}
+		case model.TenantDeletionStatusFailed:
+			w.logger.Errorf("Failed to purge tenant %s (deletion %s), will retry: %s", deletion.TenantID, deletion.ID, deletion.Error)
+		}
+	}
+	if err != nil {
+		w.logger.Errorf("Failed to purge deleted tenants: %v", err)
+	}
+}
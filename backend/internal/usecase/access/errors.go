@@ -0,0 +1,17 @@
+package access
+
+import "errors"
+
+var (
+	// ErrResourceNotFound is returned when the space or knowledge does not exist or is not visible to the actor
+	ErrResourceNotFound = errors.New("resource not found")
+
+	// ErrInvalidResourceType is returned for resources without access control
+	ErrInvalidResourceType = errors.New("invalid resource type")
+
+	// ErrInvalidAccessLevel is returned for unknown access levels
+	ErrInvalidAccessLevel = errors.New("invalid access level")
+
+	// ErrPrincipalNotFound is returned when access is granted to a user or group that is not in the tenant
+	ErrPrincipalNotFound = errors.New("principal not found")
+)
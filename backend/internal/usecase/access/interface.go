@@ -0,0 +1,29 @@
+package access
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// UpdateAccessUseCase defines the interface for changing the access control of a space or knowledge
+type UpdateAccessUseCase interface {
+	Execute(input UpdateAccessInput) (*model.AccessList, error)
+}
+
+// UpdateAccessInput contains the data needed to change the access control of
+// a space or knowledge. Fields left nil are unchanged.
+type UpdateAccessInput struct {
+	ResourceType string // model.AccessResourceSpace or model.AccessResourceKnowledge
+	ResourceID   string
+	TenantID     string
+	Private      *bool
+	Entries      []EntryInput // Replaces the access entries when not nil
+	Actor        authz.Subject
+}
+
+// EntryInput grants a user or a group access to the resource
+type EntryInput struct {
+	PrincipalType string // model.PrincipalUser or model.PrincipalGroup
+	PrincipalID   string
+	Level         string
+}
@@ -0,0 +1,53 @@
+package access
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// KnowledgeResource returns the resource of the knowledge with the access
+// the actor was granted on it and on its space
+func KnowledgeResource(accessRepository repository.AccessRepository, knowledge *model.Knowledge, actor authz.Subject) (authz.Resource, error) {
+	resourceIDs := []string{knowledge.ID}
+	if knowledge.SpaceID != nil {
+		resourceIDs = append(resourceIDs, *knowledge.SpaceID)
+	}
+	entries, err := accessRepository.FindByUser(actor.UserID, knowledge.TenantID, resourceIDs)
+	if err != nil {
+		return authz.Resource{}, err
+	}
+	return authz.KnowledgeResource(knowledge).WithAccess(actor.UserID, entries), nil
+}
+
+// SpaceResource returns the resource of the space with the access the actor was granted on it
+func SpaceResource(accessRepository repository.AccessRepository, space *model.Space, actor authz.Subject) (authz.Resource, error) {
+	entries, err := accessRepository.FindByUser(actor.UserID, space.TenantID, []string{space.ID})
+	if err != nil {
+		return authz.Resource{}, err
+	}
+	return authz.SpaceResource(space).WithAccess(actor.UserID, entries), nil
+}
+
+// List returns the access control of the space or knowledge. Knowledge
+// inherits the entries of its space.
+func List(accessRepository repository.AccessRepository, resourceType string, resourceID string, tenantID string, private bool, spaceID *string) (*model.AccessList, error) {
+	entries, err := accessRepository.FindByResource(resourceType, resourceID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	list := &model.AccessList{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Private:      private,
+		Entries:      entries,
+		Inherited:    []*model.AccessEntry{},
+	}
+	if spaceID != nil {
+		list.Inherited, err = accessRepository.FindByResource(model.AccessResourceSpace, *spaceID, tenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,202 @@
+package access
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type updateAccessUseCase struct {
+	accessRepository    repository.AccessRepository
+	knowledgeRepository repository.KnowledgeRepository
+	spaceRepository     repository.SpaceRepository
+	userRepository      repository.UserRepository
+	groupRepository     repository.GroupRepository
+	tenantRepository    repository.TenantRepository
+}
+
+// NewUpdateAccessUseCase creates a new instance of UpdateAccessUseCase
+func NewUpdateAccessUseCase(
+	accessRepository repository.AccessRepository,
+	knowledgeRepository repository.KnowledgeRepository,
+	spaceRepository repository.SpaceRepository,
+	userRepository repository.UserRepository,
+	groupRepository repository.GroupRepository,
+	tenantRepository repository.TenantRepository,
+) UpdateAccessUseCase {
+	return &updateAccessUseCase{
+		accessRepository:    accessRepository,
+		knowledgeRepository: knowledgeRepository,
+		spaceRepository:     spaceRepository,
+		userRepository:      userRepository,
+		groupRepository:     groupRepository,
+		tenantRepository:    tenantRepository,
+	}
+}
+
+// Execute changes the private flag and the access entries of a space or knowledge
+func (uc *updateAccessUseCase) Execute(input UpdateAccessInput) (*model.AccessList, error) {
+	// Validate input
+	if input.ResourceID == "" {
+		return nil, errors.New("resource ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Build entries
+	var entries []*model.AccessEntry
+	if input.Entries != nil {
+		entries, err = uc.buildEntries(input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch input.ResourceType {
+	case model.AccessResourceKnowledge:
+		return uc.updateKnowledge(input, entries)
+	case model.AccessResourceSpace:
+		return uc.updateSpace(input, entries)
+	}
+	return nil, ErrInvalidResourceType
+}
+
+// updateKnowledge changes the access control of a knowledge
+func (uc *updateAccessUseCase) updateKnowledge(input UpdateAccessInput, entries []*model.AccessEntry) (*model.AccessList, error) {
+	// Find knowledge
+	knowledge, err := uc.knowledgeRepository.FindByID(input.ResourceID, input.TenantID, authz.Viewer(input.Actor))
+	if err != nil || knowledge == nil {
+		return nil, ErrResourceNotFound
+	}
+
+	// Check permission
+	resource, err := KnowledgeResource(uc.accessRepository, knowledge, input.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := authz.Can(input.Actor, authz.ActionShare, resource); err != nil {
+		return nil, err
+	}
+
+	// Save access entries
+	if input.Entries != nil {
+		if err := uc.accessRepository.Replace(model.AccessResourceKnowledge, knowledge.ID, input.TenantID, entries); err != nil {
+			return nil, err
+		}
+	}
+
+	// Save private flag
+	if input.Private != nil && *input.Private != knowledge.Private {
+		knowledge.Private = *input.Private
+		knowledge.UpdatedAt = time.Now()
+		if err := uc.knowledgeRepository.Update(knowledge); err != nil {
+			return nil, err
+		}
+	}
+
+	return List(uc.accessRepository, model.AccessResourceKnowledge, knowledge.ID, input.TenantID, knowledge.Private, knowledge.SpaceID)
+}
+
+// updateSpace changes the access control of a space
+func (uc *updateAccessUseCase) updateSpace(input UpdateAccessInput, entries []*model.AccessEntry) (*model.AccessList, error) {
+	// Find space
+	space, err := uc.spaceRepository.FindByID(input.ResourceID, input.TenantID, authz.ViewerID(input.Actor))
+	if err != nil || space == nil {
+		return nil, ErrResourceNotFound
+	}
+
+	// Check permission
+	resource, err := SpaceResource(uc.accessRepository, space, input.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := authz.Can(input.Actor, authz.ActionShare, resource); err != nil {
+		return nil, err
+	}
+
+	// Save access entries
+	if input.Entries != nil {
+		if err := uc.accessRepository.Replace(model.AccessResourceSpace, space.ID, input.TenantID, entries); err != nil {
+			return nil, err
+		}
+	}
+
+	// Save private flag
+	if input.Private != nil && *input.Private != space.Private {
+		space.Private = *input.Private
+		space.UpdatedAt = time.Now()
+		if err := uc.spaceRepository.Update(space); err != nil {
+			return nil, err
+		}
+	}
+
+	return List(uc.accessRepository, model.AccessResourceSpace, space.ID, input.TenantID, space.Private, nil)
+}
+
+// buildEntries validates the requested entries. A principal listed more than
+// once gets the last level listed.
+func (uc *updateAccessUseCase) buildEntries(input UpdateAccessInput) ([]*model.AccessEntry, error) {
+	now := time.Now()
+	entries := []*model.AccessEntry{}
+	index := make(map[string]int, len(input.Entries))
+	for _, requested := range input.Entries {
+		if !model.IsValidAccessLevel(requested.Level) {
+			return nil, ErrInvalidAccessLevel
+		}
+		if err := uc.verifyPrincipal(requested.PrincipalType, requested.PrincipalID, input.TenantID); err != nil {
+			return nil, err
+		}
+
+		key := requested.PrincipalType + ":" + requested.PrincipalID
+		if i, ok := index[key]; ok {
+			entries[i].Level = requested.Level
+			continue
+		}
+		index[key] = len(entries)
+		entries = append(entries, &model.AccessEntry{
+			ID:            uuid.New().String(),
+			TenantID:      input.TenantID,
+			ResourceType:  input.ResourceType,
+			ResourceID:    input.ResourceID,
+			PrincipalType: requested.PrincipalType,
+			PrincipalID:   requested.PrincipalID,
+			Level:         requested.Level,
+			CreatedAt:     now,
+		})
+	}
+	return entries, nil
+}
+
+// verifyPrincipal checks that the user or group belongs to the tenant
+func (uc *updateAccessUseCase) verifyPrincipal(principalType string, principalID string, tenantID string) error {
+	switch principalType {
+	case model.PrincipalUser:
+		user, err := uc.userRepository.FindByID(principalID, tenantID)
+		if err != nil || user == nil {
+			return ErrPrincipalNotFound
+		}
+		return nil
+	case model.PrincipalGroup:
+		group, err := uc.groupRepository.FindByID(principalID, tenantID)
+		if err != nil || group == nil {
+			return ErrPrincipalNotFound
+		}
+		return nil
+	}
+	return ErrPrincipalNotFound
+}
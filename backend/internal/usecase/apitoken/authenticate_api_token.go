@@ -0,0 +1,59 @@
+package apitoken
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+type authenticateAPITokenUseCase struct {
+	apiTokenRepository repository.APITokenRepository
+	userRepository     repository.UserRepository
+	signer             *security.TokenSigner
+}
+
+// NewAuthenticateAPITokenUseCase creates a new instance of AuthenticateAPITokenUseCase
+func NewAuthenticateAPITokenUseCase(
+	apiTokenRepository repository.APITokenRepository,
+	userRepository repository.UserRepository,
+	signer *security.TokenSigner,
+) AuthenticateAPITokenUseCase {
+	return &authenticateAPITokenUseCase{
+		apiTokenRepository: apiTokenRepository,
+		userRepository:     userRepository,
+		signer:             signer,
+	}
+}
+
+// Execute checks an API token and returns it with the user it acts as
+func (uc *authenticateAPITokenUseCase) Execute(token string) (*AuthenticatedAPIToken, error) {
+	// Reject forged tokens before looking them up
+	signed, ok := stripTokenPrefix(token)
+	if !ok || uc.signer.Verify(signed) != nil {
+		return nil, ErrInvalidAPIToken
+	}
+
+	// Get API token
+	apiToken, err := uc.apiTokenRepository.FindByTokenHash(security.HashToken(token))
+	if err != nil || apiToken == nil || !apiToken.IsActive(time.Now()) {
+		return nil, ErrInvalidAPIToken
+	}
+	if !strings.HasPrefix(token, tokenPrefix(apiToken.Kind)) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	// Tokens stop working when their user is deactivated
+	user, err := uc.userRepository.FindByID(apiToken.UserID, apiToken.TenantID)
+	if err != nil || user == nil || !user.IsActive() {
+		return nil, ErrInvalidAPIToken
+	}
+
+	// Return user without password
+	user.Password = ""
+	return &AuthenticatedAPIToken{
+		APIToken: apiToken,
+		User:     user,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package apitoken
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+type createAPITokenUseCase struct {
+	apiTokenRepository repository.APITokenRepository
+	userRepository     repository.UserRepository
+	tenantRepository   repository.TenantRepository
+	signer             *security.TokenSigner
+}
+
+// NewCreateAPITokenUseCase creates a new instance of CreateAPITokenUseCase
+func NewCreateAPITokenUseCase(
+	apiTokenRepository repository.APITokenRepository,
+	userRepository repository.UserRepository,
+	tenantRepository repository.TenantRepository,
+	signer *security.TokenSigner,
+) CreateAPITokenUseCase {
+	return &createAPITokenUseCase{
+		apiTokenRepository: apiTokenRepository,
+		userRepository:     userRepository,
+		tenantRepository:   tenantRepository,
+		signer:             signer,
+	}
+}
+
+// Execute creates an API token. Only its hash is stored, so the returned
+// token value can't be retrieved again.
+func (uc *createAPITokenUseCase) Execute(input CreateAPITokenInput) (*CreatedAPIToken, error) {
+	// Validate input
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.UserID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.Name == "" {
+		return nil, errors.New("token name is required")
+	}
+	switch input.Kind {
+	case model.APITokenKindPersonal, model.APITokenKindService:
+	case model.APITokenKindSCIM:
+		// SCIM tokens can only be used for provisioning
+		input.Scopes = []string{model.ScopeSCIM}
+	default:
+		return nil, errors.New("invalid token kind")
+	}
+	if len(input.Scopes) == 0 {
+		return nil, ErrInvalidScope
+	}
+	for _, scope := range input.Scopes {
+		if input.Kind != model.APITokenKindSCIM && !model.IsValidScope(scope) {
+			return nil, ErrInvalidScope
+		}
+	}
+	now := time.Now()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return nil, ErrInvalidExpiry
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Verify user exists
+	user, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	// Generate token
+	signed, err := uc.signer.Generate()
+	if err != nil {
+		return nil, err
+	}
+	token := tokenPrefix(input.Kind) + signed
+
+	// Create API token
+	apiToken := &model.APIToken{
+		ID:        uuid.New().String(),
+		TenantID:  input.TenantID,
+		UserID:    input.UserID,
+		Kind:      input.Kind,
+		Name:      input.Name,
+		Prefix:    token[:displayPrefixLength],
+		TokenHash: security.HashToken(token),
+		Scopes:    input.Scopes,
+		ExpiresAt: input.ExpiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Save API token
+	err = uc.apiTokenRepository.Create(apiToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreatedAPIToken{
+		APIToken: apiToken,
+		Token:    token,
+	}, nil
+}
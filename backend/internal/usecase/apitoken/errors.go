@@ -0,0 +1,17 @@
+package apitoken
+
+import "errors"
+
+var (
+	// ErrInvalidAPIToken is returned when an API token is unknown, revoked or expired
+	ErrInvalidAPIToken = errors.New("invalid or expired API token")
+
+	// ErrInvalidScope is returned when a token is created without scopes or with an unknown scope
+	ErrInvalidScope = errors.New("invalid API token scope")
+
+	// ErrInvalidExpiry is returned when a token is created with an expiry in the past
+	ErrInvalidExpiry = errors.New("API token expiry must be in the future")
+
+	// ErrAPITokenNotFound is returned when revoking a token that does not exist or belongs to someone else
+	ErrAPITokenNotFound = errors.New("API token not found")
+)
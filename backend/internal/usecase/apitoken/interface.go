@@ -0,0 +1,53 @@
+package apitoken
+
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// CreatedAPIToken is a newly created token together with its secret value,
+// which is only shown once
+type CreatedAPIToken struct {
+	*model.APIToken
+	Token string `json:"token"`
+}
+
+// CreateAPITokenUseCase defines the interface for creating an API token
+type CreateAPITokenUseCase interface {
+	Execute(input CreateAPITokenInput) (*CreatedAPIToken, error)
+}
+
+// CreateAPITokenInput contains the data needed to create an API token
+type CreateAPITokenInput struct {
+	TenantID  string
+	UserID    string
+	Kind      string
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time // Optional, never expires when nil
+}
+
+// RevokeAPITokenUseCase defines the interface for revoking an API token
+type RevokeAPITokenUseCase interface {
+	Execute(input RevokeAPITokenInput) error
+}
+
+// RevokeAPITokenInput contains the data needed to revoke an API token
+type RevokeAPITokenInput struct {
+	ID       string
+	TenantID string
+	Kind     string
+	UserID   string // Optional, restricts revocation to the user's own tokens
+}
+
+// AuthenticateAPITokenUseCase defines the interface for authenticating a request made with an API token
+type AuthenticateAPITokenUseCase interface {
+	Execute(token string) (*AuthenticatedAPIToken, error)
+}
+
+// AuthenticatedAPIToken contains a valid token and the user it acts as
+type AuthenticatedAPIToken struct {
+	APIToken *model.APIToken
+	User     *model.User
+}
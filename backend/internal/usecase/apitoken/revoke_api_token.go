@@ -0,0 +1,50 @@
+package apitoken
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type revokeAPITokenUseCase struct {
+	apiTokenRepository repository.APITokenRepository
+}
+
+// NewRevokeAPITokenUseCase creates a new instance of RevokeAPITokenUseCase
+func NewRevokeAPITokenUseCase(apiTokenRepository repository.APITokenRepository) RevokeAPITokenUseCase {
+	return &revokeAPITokenUseCase{
+		apiTokenRepository: apiTokenRepository,
+	}
+}
+
+// Execute revokes an API token so that it is no longer accepted
+func (uc *revokeAPITokenUseCase) Execute(input RevokeAPITokenInput) error {
+	// Validate input
+	if input.ID == "" {
+		return errors.New("token ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Find API token
+	apiToken, err := uc.apiTokenRepository.FindByID(input.ID, input.TenantID)
+	if err != nil || apiToken == nil {
+		return ErrAPITokenNotFound
+	}
+	if apiToken.Kind != input.Kind || (input.UserID != "" && apiToken.UserID != input.UserID) {
+		return ErrAPITokenNotFound
+	}
+	if apiToken.RevokedAt != nil {
+		return nil
+	}
+
+	// Revoke API token
+	now := time.Now()
+	apiToken.RevokedAt = &now
+	apiToken.UpdatedAt = now
+
+	// Save API token
+	return uc.apiTokenRepository.Update(apiToken)
+}
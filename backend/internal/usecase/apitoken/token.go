@@ -0,0 +1,52 @@
+package apitoken
+
+import (
+	"strings"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// TokenPurpose identifies API tokens when they are signed
+const TokenPurpose = "api_token"
+
+// Token prefixes make API tokens recognisable, e.g. by secret scanners
+const (
+	PersonalTokenPrefix = "khp_"
+	ServiceTokenPrefix  = "khs_"
+	SCIMTokenPrefix     = "khscim_"
+)
+
+// displayPrefixLength is how much of a token is kept to recognise it in lists
+const displayPrefixLength = 12
+
+// IsAPIToken reports whether a bearer token is an API token rather than a JWT
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, PersonalTokenPrefix) || strings.HasPrefix(token, ServiceTokenPrefix)
+}
+
+// IsSCIMToken reports whether a bearer token is a SCIM provisioning token
+func IsSCIMToken(token string) bool {
+	return strings.HasPrefix(token, SCIMTokenPrefix)
+}
+
+// tokenPrefix returns the prefix of tokens of the kind
+func tokenPrefix(kind string) string {
+	switch kind {
+	case model.APITokenKindService:
+		return ServiceTokenPrefix
+	case model.APITokenKindSCIM:
+		return SCIMTokenPrefix
+	default:
+		return PersonalTokenPrefix
+	}
+}
+
+// stripTokenPrefix returns the signed part of a token
+func stripTokenPrefix(token string) (string, bool) {
+	for _, prefix := range []string{SCIMTokenPrefix, PersonalTokenPrefix, ServiceTokenPrefix} {
+		if signed, ok := strings.CutPrefix(token, prefix); ok {
+			return signed, true
+		}
+	}
+	return "", false
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/anchor"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
@@ -57,13 +59,18 @@ func (uc *createCommentUseCase) Execute(input CreateCommentInput) (*model.Commen
 		return nil, errors.New("tenant not found")
 	}
 
-	// Verify knowledge exists
-	knowledge, err := uc.knowledgeRepository.FindByID(input.KnowledgeID, input.TenantID)
-	if err != nil {
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionCreate, authz.NewResource(authz.ResourceComment, input.TenantID)); err != nil {
 		return nil, err
 	}
-	if knowledge == nil {
-		return nil, errors.New("knowledge not found")
+
+	// Verify knowledge exists
+	knowledge, err := uc.knowledgeRepository.FindByID(input.KnowledgeID, input.TenantID, authz.Viewer(input.Actor))
+	if err != nil || knowledge == nil {
+		return nil, ErrKnowledgeNotFound
+	}
+	if knowledge.DiscussionLocked {
+		return nil, ErrDiscussionLocked
 	}
 
 	// Verify author exists
@@ -87,6 +94,21 @@ func (uc *createCommentUseCase) Execute(input CreateCommentInput) (*model.Commen
 		UpdatedAt:   now,
 	}
 
+	// Anchor inline comments to the knowledge content
+	if input.AnchorBlock != "" {
+		if input.AnchorStart == nil || input.AnchorEnd == nil {
+			return nil, anchor.ErrInvalidAnchor
+		}
+		a, err := anchor.New(knowledge.Content, input.AnchorBlock, *input.AnchorStart, *input.AnchorEnd)
+		if err != nil {
+			return nil, err
+		}
+		comment.AnchorBlock = a.BlockID
+		comment.AnchorStart = &a.Start
+		comment.AnchorEnd = &a.End
+		comment.AnchorQuote = a.Quote
+	}
+
 	// Save comment
 	err = uc.commentRepository.Create(comment)
 	if err != nil {
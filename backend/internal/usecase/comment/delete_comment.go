@@ -3,6 +3,7 @@ package comment
 import (
 	"errors"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
 
@@ -50,6 +51,11 @@ func (uc *deleteCommentUseCase) Execute(input DeleteCommentInput) error {
 		return errors.New("comment not found")
 	}
 
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionDelete, authz.CommentResource(comment, "")); err != nil {
+		return err
+	}
+
 	// Delete comment
 	return uc.commentRepository.Delete(input.ID, input.TenantID)
 }
\ No newline at end of file
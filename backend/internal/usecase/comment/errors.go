@@ -0,0 +1,14 @@
+package comment
+
+import "errors"
+
+var (
+	// ErrKnowledgeNotFound is returned when the knowledge of a comment does not exist or isn't visible to the user
+	ErrKnowledgeNotFound = errors.New("knowledge not found")
+
+	// ErrDiscussionLocked is returned when commenting on knowledge whose discussion is locked
+	ErrDiscussionLocked = errors.New("discussion is locked")
+
+	// ErrCommentHidden is returned when editing a comment a moderator has hidden
+	ErrCommentHidden = errors.New("comment is hidden")
+)
@@ -1,6 +1,9 @@
 package comment
 
-import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
 
 // CreateCommentUseCase defines the interface for creating a comment
 type CreateCommentUseCase interface {
@@ -13,6 +16,10 @@ type CreateCommentInput struct {
 	AuthorID    string
 	KnowledgeID string
 	TenantID    string
+	AnchorBlock string // Optional block ID for inline comments
+	AnchorStart *int
+	AnchorEnd   *int
+	Actor       authz.Subject
 }
 
 // UpdateCommentUseCase defines the interface for updating a comment
@@ -24,7 +31,9 @@ type UpdateCommentUseCase interface {
 type UpdateCommentInput struct {
 	ID       string
 	Content  string
+	EditorID string
 	TenantID string
+	Actor    authz.Subject
 }
 
 // DeleteCommentUseCase defines the interface for deleting a comment
@@ -36,4 +45,19 @@ type DeleteCommentUseCase interface {
 type DeleteCommentInput struct {
 	ID       string
 	TenantID string
-}
\ No newline at end of file
+	Actor    authz.Subject
+}
+
+// ResolveCommentUseCase defines the interface for resolving or reopening a comment
+type ResolveCommentUseCase interface {
+	Execute(input ResolveCommentInput) (*model.Comment, error)
+}
+
+// ResolveCommentInput contains the data needed to resolve or reopen a comment
+type ResolveCommentInput struct {
+	ID         string
+	Resolved   bool
+	ResolverID string
+	TenantID   string
+	Actor      authz.Subject
+}
@@ -0,0 +1,93 @@
+package comment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type resolveCommentUseCase struct {
+	commentRepository   repository.CommentRepository
+	knowledgeRepository repository.KnowledgeRepository
+	tenantRepository    repository.TenantRepository
+}
+
+// NewResolveCommentUseCase creates a new instance of ResolveCommentUseCase
+func NewResolveCommentUseCase(
+	commentRepository repository.CommentRepository,
+	knowledgeRepository repository.KnowledgeRepository,
+	tenantRepository repository.TenantRepository,
+) ResolveCommentUseCase {
+	return &resolveCommentUseCase{
+		commentRepository:   commentRepository,
+		knowledgeRepository: knowledgeRepository,
+		tenantRepository:    tenantRepository,
+	}
+}
+
+// Execute marks a comment as resolved or reopens it
+func (uc *resolveCommentUseCase) Execute(input ResolveCommentInput) (*model.Comment, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("comment ID is required")
+	}
+	if input.Resolved && input.ResolverID == "" {
+		return nil, errors.New("resolver ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find comment
+	comment, err := uc.commentRepository.FindByID(input.ID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, errors.New("comment not found")
+	}
+
+	// Find the knowledge, whose author may resolve comments on it
+	knowledge, err := uc.knowledgeRepository.FindByID(comment.KnowledgeID, input.TenantID, authz.Viewer(input.Actor))
+	if err != nil || knowledge == nil {
+		return nil, ErrKnowledgeNotFound
+	}
+
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionResolve, authz.CommentResource(comment, knowledge.AuthorID)); err != nil {
+		return nil, err
+	}
+
+	// Update resolved state
+	now := time.Now()
+	if input.Resolved {
+		comment.Resolved = true
+		comment.ResolvedBy = &input.ResolverID
+		comment.ResolvedAt = &now
+	} else {
+		comment.Resolved = false
+		comment.ResolvedBy = nil
+		comment.ResolvedAt = nil
+	}
+	comment.UpdatedAt = now
+
+	// Save comment
+	err = uc.commentRepository.Update(comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
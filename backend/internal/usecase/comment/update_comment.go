@@ -4,23 +4,29 @@ import (
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
 
 type updateCommentUseCase struct {
-	commentRepository repository.CommentRepository
-	tenantRepository  repository.TenantRepository
+	commentRepository   repository.CommentRepository
+	knowledgeRepository repository.KnowledgeRepository
+	tenantRepository    repository.TenantRepository
 }
 
 // NewUpdateCommentUseCase creates a new instance of UpdateCommentUseCase
 func NewUpdateCommentUseCase(
 	commentRepository repository.CommentRepository,
+	knowledgeRepository repository.KnowledgeRepository,
 	tenantRepository repository.TenantRepository,
 ) UpdateCommentUseCase {
 	return &updateCommentUseCase{
-		commentRepository: commentRepository,
-		tenantRepository:  tenantRepository,
+		commentRepository:   commentRepository,
+		knowledgeRepository: knowledgeRepository,
+		tenantRepository:    tenantRepository,
 	}
 }
 
@@ -33,6 +39,9 @@ func (uc *updateCommentUseCase) Execute(input UpdateCommentInput) (*model.Commen
 	if input.Content == "" {
 		return nil, errors.New("comment content is required")
 	}
+	if input.EditorID == "" {
+		return nil, errors.New("editor ID is required")
+	}
 	if input.TenantID == "" {
 		return nil, errors.New("tenant ID is required")
 	}
@@ -55,6 +64,40 @@ func (uc *updateCommentUseCase) Execute(input UpdateCommentInput) (*model.Commen
 		return nil, errors.New("comment not found")
 	}
 
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionUpdate, authz.CommentResource(comment, "")); err != nil {
+		return nil, err
+	}
+
+	// Only moderators may edit hidden comments or comments of locked discussions
+	if !input.Actor.HasPermission(model.PermissionCommentsModerate) {
+		if comment.Hidden {
+			return nil, ErrCommentHidden
+		}
+		knowledge, err := uc.knowledgeRepository.FindByID(comment.KnowledgeID, input.TenantID, authz.Viewer(input.Actor))
+		if err != nil || knowledge == nil {
+			return nil, ErrKnowledgeNotFound
+		}
+		if knowledge.DiscussionLocked {
+			return nil, ErrDiscussionLocked
+		}
+	}
+
+	// Keep the previous content as edit history
+	if comment.Content != input.Content {
+		revision := &model.CommentRevision{
+			ID:        uuid.New().String(),
+			CommentID: comment.ID,
+			TenantID:  comment.TenantID,
+			Content:   comment.Content,
+			EditorID:  input.EditorID,
+			CreatedAt: time.Now(),
+		}
+		if err := uc.commentRepository.CreateRevision(revision); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update comment
 	comment.Content = input.Content
 	comment.UpdatedAt = time.Now()
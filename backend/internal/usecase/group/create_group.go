@@ -0,0 +1,111 @@
+package group
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type createGroupUseCase struct {
+	groupRepository  repository.GroupRepository
+	userRepository   repository.UserRepository
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewCreateGroupUseCase creates a new instance of CreateGroupUseCase
+func NewCreateGroupUseCase(
+	groupRepository repository.GroupRepository,
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+) CreateGroupUseCase {
+	return &createGroupUseCase{
+		groupRepository:  groupRepository,
+		userRepository:   userRepository,
+		roleRepository:   roleRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute creates a group with its initial members
+func (uc *createGroupUseCase) Execute(input CreateGroupInput) (*model.Group, error) {
+	// Validate input
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.Name == "" {
+		return nil, errors.New("group name is required")
+	}
+	if input.Handle != "" && !model.IsValidHandle(input.Handle) {
+		return nil, ErrInvalidHandle
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Group names are unique in a tenant
+	if nameTaken(uc.groupRepository, input.Name, input.TenantID, "") {
+		return nil, ErrGroupNameTaken
+	}
+
+	// Use the given handle or derive one from the name
+	handle := input.Handle
+	if handle == "" {
+		handle, err = deriveHandle(uc.groupRepository, input.Name, input.TenantID)
+		if err != nil {
+			return nil, err
+		}
+	} else if handleTaken(uc.groupRepository, handle, input.TenantID, "") {
+		return nil, ErrHandleTaken
+	}
+
+	// Verify role exists and may be granted
+	if input.Role != "" {
+		if err := verifyRole(uc.roleRepository, input.Role, input.TenantID, input.Actor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Verify members exist
+	if err := verifyMembers(uc.userRepository, input.TenantID, input.MemberIDs); err != nil {
+		return nil, err
+	}
+
+	// Create group
+	now := time.Now()
+	group := &model.Group{
+		ID:         uuid.New().String(),
+		TenantID:   input.TenantID,
+		Name:       input.Name,
+		Handle:     handle,
+		Role:       input.Role,
+		ExternalID: input.ExternalID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	// Save group
+	err = uc.groupRepository.Create(group)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add members
+	err = uc.groupRepository.AddMembers(group.ID, group.TenantID, input.MemberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
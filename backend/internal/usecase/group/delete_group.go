@@ -0,0 +1,52 @@
+package group
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type deleteGroupUseCase struct {
+	groupRepository  repository.GroupRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewDeleteGroupUseCase creates a new instance of DeleteGroupUseCase
+func NewDeleteGroupUseCase(
+	groupRepository repository.GroupRepository,
+	tenantRepository repository.TenantRepository,
+) DeleteGroupUseCase {
+	return &deleteGroupUseCase{
+		groupRepository:  groupRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute deletes a group and its memberships. The members themselves are kept.
+func (uc *deleteGroupUseCase) Execute(input DeleteGroupInput) error {
+	// Validate input
+	if input.ID == "" {
+		return errors.New("group ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Find group
+	group, err := uc.groupRepository.FindByID(input.ID, input.TenantID)
+	if err != nil || group == nil {
+		return ErrGroupNotFound
+	}
+
+	// Delete group
+	return uc.groupRepository.Delete(group.ID, group.TenantID)
+}
@@ -0,0 +1,23 @@
+package group
+
+import "errors"
+
+var (
+	// ErrGroupNotFound is returned when the group does not exist in the tenant
+	ErrGroupNotFound = errors.New("group not found")
+
+	// ErrGroupNameTaken is returned when another group of the tenant already has the name
+	ErrGroupNameTaken = errors.New("a group with this name already exists")
+
+	// ErrMemberNotFound is returned when a member to add is not a user of the tenant
+	ErrMemberNotFound = errors.New("member not found")
+
+	// ErrInvalidHandle is returned when a handle isn't lowercase words separated by hyphens
+	ErrInvalidHandle = errors.New("invalid handle")
+
+	// ErrHandleTaken is returned when another group of the tenant already has the handle
+	ErrHandleTaken = errors.New("a group with this handle already exists")
+
+	// ErrInvalidRole is returned when the group's role does not exist in the tenant
+	ErrInvalidRole = errors.New("invalid role")
+)
@@ -0,0 +1,52 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// maxHandleAttempts is how many numbered variants of a derived handle are tried
+const maxHandleAttempts = 100
+
+// handleTaken reports whether a group other than the one with the ID has the handle
+func handleTaken(groupRepository repository.GroupRepository, handle string, tenantID string, id string) bool {
+	existing, err := groupRepository.FindByHandle(handle, tenantID)
+	return err == nil && existing != nil && existing.ID != id
+}
+
+// deriveHandle derives a free handle from the name, numbering it when the
+// plain handle is taken
+func deriveHandle(groupRepository repository.GroupRepository, name string, tenantID string) (string, error) {
+	base := model.GroupHandle(name)
+	handle := base
+	for i := 2; handleTaken(groupRepository, handle, tenantID, ""); i++ {
+		if i > maxHandleAttempts {
+			return "", ErrHandleTaken
+		}
+		suffix := fmt.Sprintf("-%d", i)
+		if len(base)+len(suffix) > model.MaxSlugLength {
+			base = base[:model.MaxSlugLength-len(suffix)]
+		}
+		handle = base + suffix
+	}
+	return handle, nil
+}
+
+// verifyRole checks that the role exists in the tenant and that the actor
+// may grant its permissions to the members
+func verifyRole(roleRepository repository.RoleRepository, name string, tenantID string, actor authz.Subject) error {
+	role, err := roleRepository.FindByName(name, tenantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRole
+		}
+		return err
+	}
+	return authz.CanGrant(actor, role)
+}
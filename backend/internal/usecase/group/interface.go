@@ -0,0 +1,66 @@
+package group
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// CreateGroupUseCase defines the interface for creating a group
+type CreateGroupUseCase interface {
+	Execute(input CreateGroupInput) (*model.Group, error)
+}
+
+// CreateGroupInput contains the data needed to create a group
+type CreateGroupInput struct {
+	TenantID   string
+	Name       string
+	Handle     string        // Optional, derived from the name if empty
+	Role       string        // Optional, role whose permissions the members get
+	ExternalID string        // Optional, set when the group is provisioned through SCIM
+	MemberIDs  []string      // Optional, initial members
+	Actor      authz.Subject // Needed to give the group a role
+}
+
+// UpdateGroupUseCase defines the interface for updating a group
+type UpdateGroupUseCase interface {
+	Execute(input UpdateGroupInput) (*model.Group, error)
+}
+
+// UpdateGroupInput contains the data needed to update a group. Fields left
+// nil are unchanged.
+type UpdateGroupInput struct {
+	ID         string
+	TenantID   string
+	Name       *string
+	Handle     *string
+	Role       *string // Empty removes the group's role
+	ExternalID *string
+	Actor      authz.Subject // Needed to give the group a role
+}
+
+// DeleteGroupUseCase defines the interface for deleting a group
+type DeleteGroupUseCase interface {
+	Execute(input DeleteGroupInput) error
+}
+
+// DeleteGroupInput contains the data needed to delete a group
+type DeleteGroupInput struct {
+	ID       string
+	TenantID string
+}
+
+// UpdateGroupMembersUseCase defines the interface for changing the members of a group
+type UpdateGroupMembersUseCase interface {
+	Execute(input UpdateGroupMembersInput) error
+}
+
+// UpdateGroupMembersInput contains the data needed to change the members of a group
+type UpdateGroupMembersInput struct {
+	GroupID  string
+	TenantID string
+	Add      []string
+	Remove   []string
+	// Replace removes all current members before adding Add
+	Replace bool
+	Actor   authz.Subject // Needed to add members to a group with a role
+}
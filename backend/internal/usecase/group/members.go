@@ -0,0 +1,20 @@
+package group
+
+import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+
+// verifyMembers checks that every user ID belongs to a user of the tenant
+func verifyMembers(userRepository repository.UserRepository, tenantID string, userIDs []string) error {
+	for _, id := range userIDs {
+		user, err := userRepository.FindByID(id, tenantID)
+		if err != nil || user == nil {
+			return ErrMemberNotFound
+		}
+	}
+	return nil
+}
+
+// nameTaken reports whether a group other than the one with the ID has the name
+func nameTaken(groupRepository repository.GroupRepository, name string, tenantID string, id string) bool {
+	existing, err := groupRepository.FindByName(name, tenantID)
+	return err == nil && existing != nil && existing.ID != id
+}
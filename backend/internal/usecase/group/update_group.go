@@ -0,0 +1,101 @@
+package group
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type updateGroupUseCase struct {
+	groupRepository  repository.GroupRepository
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewUpdateGroupUseCase creates a new instance of UpdateGroupUseCase
+func NewUpdateGroupUseCase(
+	groupRepository repository.GroupRepository,
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+) UpdateGroupUseCase {
+	return &updateGroupUseCase{
+		groupRepository:  groupRepository,
+		roleRepository:   roleRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute updates a group
+func (uc *updateGroupUseCase) Execute(input UpdateGroupInput) (*model.Group, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("group ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.Name != nil && *input.Name == "" {
+		return nil, errors.New("group name is required")
+	}
+	if input.Handle != nil && !model.IsValidHandle(*input.Handle) {
+		return nil, ErrInvalidHandle
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find group
+	group, err := uc.groupRepository.FindByID(input.ID, input.TenantID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	// Update fields
+	if input.Name != nil && *input.Name != group.Name {
+		if nameTaken(uc.groupRepository, *input.Name, input.TenantID, group.ID) {
+			return nil, ErrGroupNameTaken
+		}
+		group.Name = *input.Name
+	}
+	if input.Handle != nil && *input.Handle != group.Handle {
+		if handleTaken(uc.groupRepository, *input.Handle, input.TenantID, group.ID) {
+			return nil, ErrHandleTaken
+		}
+		group.Handle = *input.Handle
+	}
+	if input.Role != nil && *input.Role != group.Role {
+		// Changing the role of a group grants or revokes its permissions
+		// for every member, so the actor needs the permissions of both
+		if *input.Role != "" {
+			if err := verifyRole(uc.roleRepository, *input.Role, input.TenantID, input.Actor); err != nil {
+				return nil, err
+			}
+		}
+		if group.Role != "" {
+			if err := verifyRole(uc.roleRepository, group.Role, input.TenantID, input.Actor); err != nil && !errors.Is(err, ErrInvalidRole) {
+				return nil, err
+			}
+		}
+		group.Role = *input.Role
+	}
+	if input.ExternalID != nil {
+		group.ExternalID = *input.ExternalID
+	}
+	group.UpdatedAt = time.Now()
+
+	// Save group
+	err = uc.groupRepository.Update(group)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
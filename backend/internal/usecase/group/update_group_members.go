@@ -0,0 +1,86 @@
+package group
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type updateGroupMembersUseCase struct {
+	groupRepository  repository.GroupRepository
+	userRepository   repository.UserRepository
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewUpdateGroupMembersUseCase creates a new instance of UpdateGroupMembersUseCase
+func NewUpdateGroupMembersUseCase(
+	groupRepository repository.GroupRepository,
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+) UpdateGroupMembersUseCase {
+	return &updateGroupMembersUseCase{
+		groupRepository:  groupRepository,
+		userRepository:   userRepository,
+		roleRepository:   roleRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute adds and removes members of a group
+func (uc *updateGroupMembersUseCase) Execute(input UpdateGroupMembersInput) error {
+	// Validate input
+	if input.GroupID == "" {
+		return errors.New("group ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Find group
+	group, err := uc.groupRepository.FindByID(input.GroupID, input.TenantID)
+	if err != nil || group == nil {
+		return ErrGroupNotFound
+	}
+
+	// Verify members exist
+	if err := verifyMembers(uc.userRepository, input.TenantID, input.Add); err != nil {
+		return err
+	}
+
+	// New members get the permissions of the group's role
+	if group.Role != "" && len(input.Add) > 0 {
+		if err := verifyRole(uc.roleRepository, group.Role, input.TenantID, input.Actor); err != nil {
+			return err
+		}
+	}
+
+	// Update members
+	if input.Replace {
+		err = uc.groupRepository.ReplaceMembers(group.ID, group.TenantID, input.Add)
+	} else {
+		err = uc.groupRepository.AddMembers(group.ID, group.TenantID, input.Add)
+	}
+	if err != nil {
+		return err
+	}
+	err = uc.groupRepository.RemoveMembers(group.ID, group.TenantID, input.Remove)
+	if err != nil {
+		return err
+	}
+
+	// Record the change on the group
+	group.UpdatedAt = time.Now()
+	return uc.groupRepository.Update(group)
+}
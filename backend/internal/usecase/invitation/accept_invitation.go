@@ -0,0 +1,75 @@
+package invitation
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/user"
+)
+
+type acceptInvitationUseCase struct {
+	invitationRepository repository.InvitationRepository
+	registerUserUseCase  user.RegisterUserUseCase
+	signer               *security.TokenSigner
+}
+
+// NewAcceptInvitationUseCase creates a new instance of AcceptInvitationUseCase
+func NewAcceptInvitationUseCase(
+	invitationRepository repository.InvitationRepository,
+	registerUserUseCase user.RegisterUserUseCase,
+	signer *security.TokenSigner,
+) AcceptInvitationUseCase {
+	return &acceptInvitationUseCase{
+		invitationRepository: invitationRepository,
+		registerUserUseCase:  registerUserUseCase,
+		signer:               signer,
+	}
+}
+
+// Execute creates the invited user and consumes the invitation
+func (uc *acceptInvitationUseCase) Execute(input AcceptInvitationInput) (*model.User, error) {
+	// Validate input
+	if input.Token == "" {
+		return nil, errors.New("token is required")
+	}
+
+	// Reject forged tokens before looking them up
+	if err := uc.signer.Verify(input.Token); err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	// Get invitation
+	invitation, err := uc.invitationRepository.FindByTokenHash(security.HashToken(input.Token))
+	if err != nil || invitation == nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	// Check invitation can still be used
+	if invitation.Status != model.InvitationStatusPending {
+		return nil, ErrInvitationNotPending
+	}
+	if invitation.IsExpired() {
+		return nil, ErrInvitationExpired
+	}
+
+	// Register user with the invited role, consuming the invitation in the
+	// same transaction so that it can't be used twice
+	newUser, err := uc.registerUserUseCase.Execute(user.RegisterUserInput{
+		Name:       input.Name,
+		Email:      invitation.Email,
+		Password:   input.Password,
+		TenantID:   invitation.TenantID,
+		Role:       invitation.Role,
+		Invitation: invitation,
+	})
+	if errors.Is(err, user.ErrInvitationUsed) {
+		return nil, ErrInvitationNotPending
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newUser, nil
+}
@@ -0,0 +1,154 @@
+package invitation
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+// TokenPurpose identifies invitation tokens when they are signed
+const TokenPurpose = "invitation"
+
+// invitationTTL is how long an invitation can be accepted
+const invitationTTL = 7 * 24 * time.Hour
+
+type createInvitationUseCase struct {
+	invitationRepository repository.InvitationRepository
+	userRepository       repository.UserRepository
+	roleRepository       repository.RoleRepository
+	tenantRepository     repository.TenantRepository
+	mailer               mail.Mailer
+	signer               *security.TokenSigner
+	acceptURL            string
+}
+
+// NewCreateInvitationUseCase creates a new instance of CreateInvitationUseCase.
+// The token is appended to acceptURL in the invitation email.
+func NewCreateInvitationUseCase(
+	invitationRepository repository.InvitationRepository,
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+	mailer mail.Mailer,
+	signer *security.TokenSigner,
+	acceptURL string,
+) CreateInvitationUseCase {
+	return &createInvitationUseCase{
+		invitationRepository: invitationRepository,
+		userRepository:       userRepository,
+		roleRepository:       roleRepository,
+		tenantRepository:     tenantRepository,
+		mailer:               mailer,
+		signer:               signer,
+		acceptURL:            acceptURL,
+	}
+}
+
+// Execute creates an invitation and emails its token to the invitee
+func (uc *createInvitationUseCase) Execute(input CreateInvitationInput) (*model.Invitation, error) {
+	// Validate input
+	if input.Email == "" {
+		return nil, errors.New("email is required")
+	}
+	if input.InvitedBy == "" {
+		return nil, errors.New("inviter ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Validate role
+	role, err := uc.roleRepository.FindByName(input.Role, input.TenantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRole
+		}
+		return nil, err
+	}
+
+	// Check permission
+	if err := authz.CanGrant(input.Actor, role); err != nil {
+		return nil, err
+	}
+
+	// Check if email already belongs to a user
+	existingUser, err := uc.userRepository.FindByEmail(input.Email, input.TenantID)
+	if err == nil && existingUser != nil {
+		return nil, ErrAlreadyMember
+	}
+
+	// Only the latest invitation for an email stays valid
+	if err := uc.invitationRepository.RevokePending(input.Email, input.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Generate token
+	token, err := uc.signer.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create invitation
+	now := time.Now()
+	invitation := &model.Invitation{
+		ID:        uuid.New().String(),
+		TenantID:  input.TenantID,
+		Email:     input.Email,
+		Role:      role.Name,
+		TokenHash: security.HashToken(token),
+		InvitedBy: input.InvitedBy,
+		Status:    model.InvitationStatusPending,
+		ExpiresAt: now.Add(invitationTTL),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Save invitation
+	err = uc.invitationRepository.Create(invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send invitation email, revoking the invitation if it cannot be delivered
+	err = uc.mailer.Send(mail.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to %s", tenant.Name),
+		Body: fmt.Sprintf(
+			"You have been invited to join %s on Knowledge Hub as %s.\n\nAccept the invitation here:\n%s?token=%s\n\nThis invitation expires on %s.\n",
+			tenant.Name,
+			invitation.Role,
+			uc.acceptURL,
+			url.QueryEscape(token),
+			invitation.ExpiresAt.Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		invitation.Status = model.InvitationStatusRevoked
+		invitation.UpdatedAt = time.Now()
+		if updateErr := uc.invitationRepository.Update(invitation); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,16 @@
+package invitation
+
+import "errors"
+
+var (
+	// ErrInvalidInvitation is returned when an invitation token is unknown or has been tampered with
+	ErrInvalidInvitation = errors.New("invalid invitation")
+	// ErrInvitationExpired is returned when an invitation is accepted after its expiry
+	ErrInvitationExpired = errors.New("invitation has expired")
+	// ErrInvitationNotPending is returned when an invitation was already accepted or revoked
+	ErrInvitationNotPending = errors.New("invitation is no longer pending")
+	// ErrAlreadyMember is returned when the invited email already belongs to a user of the tenant
+	ErrAlreadyMember = errors.New("email already registered for this tenant")
+	// ErrInvalidRole is returned when the role is not one of the tenant's roles
+	ErrInvalidRole = errors.New("invalid role")
+)
@@ -0,0 +1,43 @@
+package invitation
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// CreateInvitationUseCase defines the interface for inviting someone to a tenant
+type CreateInvitationUseCase interface {
+	Execute(input CreateInvitationInput) (*model.Invitation, error)
+}
+
+// CreateInvitationInput contains the data needed to create an invitation
+type CreateInvitationInput struct {
+	Email     string
+	Role      string
+	InvitedBy string
+	TenantID  string
+	Actor     authz.Subject // Inviter, who can't grant permissions they don't have
+}
+
+// RevokeInvitationUseCase defines the interface for revoking an invitation
+type RevokeInvitationUseCase interface {
+	Execute(input RevokeInvitationInput) (*model.Invitation, error)
+}
+
+// RevokeInvitationInput contains the data needed to revoke an invitation
+type RevokeInvitationInput struct {
+	ID       string
+	TenantID string
+}
+
+// AcceptInvitationUseCase defines the interface for accepting an invitation
+type AcceptInvitationUseCase interface {
+	Execute(input AcceptInvitationInput) (*model.User, error)
+}
+
+// AcceptInvitationInput contains the data needed to accept an invitation
+type AcceptInvitationInput struct {
+	Token    string
+	Name     string
+	Password string
+}
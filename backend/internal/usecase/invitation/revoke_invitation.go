@@ -0,0 +1,71 @@
+package invitation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type revokeInvitationUseCase struct {
+	invitationRepository repository.InvitationRepository
+	tenantRepository     repository.TenantRepository
+}
+
+// NewRevokeInvitationUseCase creates a new instance of RevokeInvitationUseCase
+func NewRevokeInvitationUseCase(
+	invitationRepository repository.InvitationRepository,
+	tenantRepository repository.TenantRepository,
+) RevokeInvitationUseCase {
+	return &revokeInvitationUseCase{
+		invitationRepository: invitationRepository,
+		tenantRepository:     tenantRepository,
+	}
+}
+
+// Execute revokes a pending invitation
+func (uc *revokeInvitationUseCase) Execute(input RevokeInvitationInput) (*model.Invitation, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("invitation ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Get invitation
+	invitation, err := uc.invitationRepository.FindByID(input.ID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, errors.New("invitation not found")
+	}
+
+	// Only pending invitations can be revoked
+	if invitation.Status != model.InvitationStatusPending {
+		return nil, ErrInvitationNotPending
+	}
+
+	// Revoke invitation
+	invitation.Status = model.InvitationStatusRevoked
+	invitation.UpdatedAt = time.Now()
+
+	// Save invitation
+	err = uc.invitationRepository.Update(invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
@@ -15,6 +16,8 @@ type createKnowledgeUseCase struct {
 	knowledgeRepository repository.KnowledgeRepository
 	userRepository      repository.UserRepository
 	tagRepository       repository.TagRepository
+	spaceRepository     repository.SpaceRepository
+	accessRepository    repository.AccessRepository
 	tenantRepository    repository.TenantRepository
 }
 
@@ -23,12 +26,16 @@ func NewCreateKnowledgeUseCase(
 	knowledgeRepository repository.KnowledgeRepository,
 	userRepository repository.UserRepository,
 	tagRepository repository.TagRepository,
+	spaceRepository repository.SpaceRepository,
+	accessRepository repository.AccessRepository,
 	tenantRepository repository.TenantRepository,
 ) CreateKnowledgeUseCase {
 	return &createKnowledgeUseCase{
 		knowledgeRepository: knowledgeRepository,
 		userRepository:      userRepository,
 		tagRepository:       tagRepository,
+		spaceRepository:     spaceRepository,
+		accessRepository:    accessRepository,
 		tenantRepository:    tenantRepository,
 	}
 }
@@ -58,6 +65,11 @@ func (uc *createKnowledgeUseCase) Execute(input CreateKnowledgeInput) (*model.Kn
 		return nil, errors.New("tenant not found")
 	}
 
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionCreate, authz.NewResource(authz.ResourceKnowledge, input.TenantID)); err != nil {
+		return nil, err
+	}
+
 	// Verify author exists
 	author, err := uc.userRepository.FindByID(input.AuthorID, input.TenantID)
 	if err != nil {
@@ -67,15 +79,36 @@ func (uc *createKnowledgeUseCase) Execute(input CreateKnowledgeInput) (*model.Kn
 		return nil, errors.New("author not found")
 	}
 
+	// Verify space exists and the author may add knowledge to it
+	var space *model.Space
+	if input.SpaceID != "" {
+		space, err = findSpace(uc.spaceRepository, input.SpaceID, input.TenantID, authz.ViewerID(input.Actor))
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyContribution(uc.accessRepository, space, input.Actor); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create knowledge
 	now := time.Now()
 	
-	// Use provided status or default to published
-	status := model.KnowledgeStatusPublished
-	if input.Status != "" {
-		status = input.Status
+	// Use provided status or default to published, or to draft for users
+	// who can't publish
+	status := input.Status
+	if status == "" {
+		status = model.KnowledgeStatusPublished
+		if !input.Actor.HasPermission(model.PermissionKnowledgePublish) {
+			status = model.KnowledgeStatusDraft
+		}
 	}
-	
+	if status == model.KnowledgeStatusPublished {
+		if err := authz.Can(input.Actor, authz.ActionPublish, authz.NewResource(authz.ResourceKnowledge, input.TenantID)); err != nil {
+			return nil, err
+		}
+	}
+
 	knowledge := &model.Knowledge{
 		ID:        uuid.New().String(),
 		Title:     input.Title,
@@ -83,6 +116,7 @@ func (uc *createKnowledgeUseCase) Execute(input CreateKnowledgeInput) (*model.Kn
 		AuthorID:  input.AuthorID,
 		TenantID:  input.TenantID,
 		Status:    status,
+		Private:   input.Private,
 		Tags:      []model.Tag{},
 		Comments:  []model.Comment{},
 		CreatedAt: now,
@@ -105,6 +139,12 @@ func (uc *createKnowledgeUseCase) Execute(input CreateKnowledgeInput) (*model.Kn
 		}
 	}
 
+	// Add the default tags of the space
+	if space != nil {
+		knowledge.SpaceID = &space.ID
+		addDefaultTags(knowledge, space)
+	}
+
 	// Save knowledge
 	err = uc.knowledgeRepository.Create(knowledge)
 	if err != nil {
@@ -3,21 +3,26 @@ package knowledge
 import (
 	"errors"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/access"
 )
 
 type deleteKnowledgeUseCase struct {
 	knowledgeRepository repository.KnowledgeRepository
+	accessRepository    repository.AccessRepository
 	tenantRepository    repository.TenantRepository
 }
 
 // NewDeleteKnowledgeUseCase creates a new instance of DeleteKnowledgeUseCase
 func NewDeleteKnowledgeUseCase(
 	knowledgeRepository repository.KnowledgeRepository,
+	accessRepository repository.AccessRepository,
 	tenantRepository repository.TenantRepository,
 ) DeleteKnowledgeUseCase {
 	return &deleteKnowledgeUseCase{
 		knowledgeRepository: knowledgeRepository,
+		accessRepository:    accessRepository,
 		tenantRepository:    tenantRepository,
 	}
 }
@@ -42,12 +47,18 @@ func (uc *deleteKnowledgeUseCase) Execute(input DeleteKnowledgeInput) error {
 	}
 
 	// Find knowledge
-	knowledge, err := uc.knowledgeRepository.FindByID(input.ID, input.TenantID)
+	knowledge, err := uc.knowledgeRepository.FindByID(input.ID, input.TenantID, authz.Viewer(input.Actor))
+	if err != nil || knowledge == nil {
+		return ErrKnowledgeNotFound
+	}
+
+	// Check permission
+	resource, err := access.KnowledgeResource(uc.accessRepository, knowledge, input.Actor)
 	if err != nil {
 		return err
 	}
-	if knowledge == nil {
-		return errors.New("knowledge not found")
+	if err := authz.Can(input.Actor, authz.ActionDelete, resource); err != nil {
+		return err
 	}
 
 	// Delete knowledge
@@ -0,0 +1,11 @@
+package knowledge
+
+import "errors"
+
+var (
+	// ErrKnowledgeNotFound is returned when the knowledge does not exist in the tenant
+	ErrKnowledgeNotFound = errors.New("knowledge not found")
+
+	// ErrSpaceNotFound is returned when the space does not exist in the tenant
+	ErrSpaceNotFound = errors.New("space not found")
+)
@@ -1,6 +1,10 @@
 package knowledge
 
-import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
 
 // CreateKnowledgeUseCase defines the interface for creating knowledge
 type CreateKnowledgeUseCase interface {
@@ -13,8 +17,11 @@ type CreateKnowledgeInput struct {
 	Content  string
 	AuthorID string
 	TenantID string
-	Status   string   // Optional status, defaults to KnowledgeStatusPublished if empty
+	Status   string // Optional status, defaults to KnowledgeStatusPublished if empty, or to KnowledgeStatusDraft without the publish permission
 	TagIDs   []string
+	SpaceID  string // Optional, the space's default tags are added
+	Private  bool
+	Actor    authz.Subject
 }
 
 // UpdateKnowledgeUseCase defines the interface for updating knowledge
@@ -29,6 +36,7 @@ type UpdateKnowledgeInput struct {
 	Content  string
 	TenantID string
 	TagIDs   []string
+	Actor    authz.Subject
 }
 
 // DeleteKnowledgeUseCase defines the interface for deleting knowledge
@@ -40,6 +48,7 @@ type DeleteKnowledgeUseCase interface {
 type DeleteKnowledgeInput struct {
 	ID       string
 	TenantID string
+	Actor    authz.Subject
 }
 
 // SearchKnowledgeUseCase defines the interface for searching knowledge
@@ -53,4 +62,19 @@ type SearchKnowledgeInput struct {
 	TenantID string
 	TagIDs   []string
 	AuthorID string
+	SpaceID  string
+	Viewer   repository.Viewer // Who the results must be visible to
+}
+
+// MoveKnowledgeUseCase defines the interface for moving knowledge to another space
+type MoveKnowledgeUseCase interface {
+	Execute(input MoveKnowledgeInput) (*model.Knowledge, error)
+}
+
+// MoveKnowledgeInput contains the data needed to move knowledge to another space
+type MoveKnowledgeInput struct {
+	ID       string
+	TenantID string
+	SpaceID  string // Empty moves the knowledge out of its space
+	Actor    authz.Subject
 }
\ No newline at end of file
@@ -0,0 +1,92 @@
+package knowledge
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/access"
+)
+
+type moveKnowledgeUseCase struct {
+	knowledgeRepository repository.KnowledgeRepository
+	spaceRepository     repository.SpaceRepository
+	accessRepository    repository.AccessRepository
+	tenantRepository    repository.TenantRepository
+}
+
+// NewMoveKnowledgeUseCase creates a new instance of MoveKnowledgeUseCase
+func NewMoveKnowledgeUseCase(
+	knowledgeRepository repository.KnowledgeRepository,
+	spaceRepository repository.SpaceRepository,
+	accessRepository repository.AccessRepository,
+	tenantRepository repository.TenantRepository,
+) MoveKnowledgeUseCase {
+	return &moveKnowledgeUseCase{
+		knowledgeRepository: knowledgeRepository,
+		spaceRepository:     spaceRepository,
+		accessRepository:    accessRepository,
+		tenantRepository:    tenantRepository,
+	}
+}
+
+// Execute moves knowledge to another space and adds the space's default tags
+func (uc *moveKnowledgeUseCase) Execute(input MoveKnowledgeInput) (*model.Knowledge, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("knowledge ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find knowledge
+	knowledge, err := uc.knowledgeRepository.FindByID(input.ID, input.TenantID, authz.Viewer(input.Actor))
+	if err != nil || knowledge == nil {
+		return nil, ErrKnowledgeNotFound
+	}
+
+	// Check permission
+	resource, err := access.KnowledgeResource(uc.accessRepository, knowledge, input.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := authz.Can(input.Actor, authz.ActionUpdate, resource); err != nil {
+		return nil, err
+	}
+
+	// Move knowledge
+	if input.SpaceID == "" {
+		knowledge.SpaceID = nil
+	} else {
+		space, err := findSpace(uc.spaceRepository, input.SpaceID, input.TenantID, authz.ViewerID(input.Actor))
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyContribution(uc.accessRepository, space, input.Actor); err != nil {
+			return nil, err
+		}
+		knowledge.SpaceID = &space.ID
+		addDefaultTags(knowledge, space)
+	}
+	knowledge.UpdatedAt = time.Now()
+
+	// Save knowledge
+	err = uc.knowledgeRepository.Update(knowledge)
+	if err != nil {
+		return nil, err
+	}
+
+	return knowledge, nil
+}
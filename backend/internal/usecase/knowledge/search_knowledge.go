@@ -43,7 +43,7 @@ func (uc *searchKnowledgeUseCase) Execute(input SearchKnowledgeInput) ([]*model.
 	}
 
 	// Use the repository's search method
-	results, err := uc.knowledgeRepository.Search(input.Query, input.TenantID, input.TagIDs, input.AuthorID)
+	results, err := uc.knowledgeRepository.Search(input.Query, input.TenantID, input.TagIDs, input.AuthorID, input.SpaceID, input.Viewer)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,41 @@
+package knowledge
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/access"
+)
+
+// findSpace returns the space, or ErrSpaceNotFound when it doesn't exist in
+// the tenant or isn't visible to the viewer
+func findSpace(spaceRepository repository.SpaceRepository, id string, tenantID string, viewerID string) (*model.Space, error) {
+	space, err := spaceRepository.FindByID(id, tenantID, viewerID)
+	if err != nil || space == nil {
+		return nil, ErrSpaceNotFound
+	}
+	return space, nil
+}
+
+// verifyContribution checks that the actor may add knowledge to the space
+func verifyContribution(accessRepository repository.AccessRepository, space *model.Space, actor authz.Subject) error {
+	resource, err := access.SpaceResource(accessRepository, space, actor)
+	if err != nil {
+		return err
+	}
+	return authz.Can(actor, authz.ActionContribute, resource)
+}
+
+// addDefaultTags adds the default tags of the space that the knowledge doesn't have yet
+func addDefaultTags(knowledge *model.Knowledge, space *model.Space) {
+	has := make(map[string]bool, len(knowledge.Tags))
+	for _, tag := range knowledge.Tags {
+		has[tag.ID] = true
+	}
+	for _, tag := range space.DefaultTags {
+		if !has[tag.ID] {
+			knowledge.Tags = append(knowledge.Tags, tag)
+			has[tag.ID] = true
+		}
+	}
+}
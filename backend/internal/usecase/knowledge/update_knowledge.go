@@ -6,13 +6,18 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/anchor"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/access"
 )
 
 type updateKnowledgeUseCase struct {
 	knowledgeRepository repository.KnowledgeRepository
 	tagRepository       repository.TagRepository
+	commentRepository   repository.CommentRepository
+	accessRepository    repository.AccessRepository
 	tenantRepository    repository.TenantRepository
 }
 
@@ -20,11 +25,15 @@ type updateKnowledgeUseCase struct {
 func NewUpdateKnowledgeUseCase(
 	knowledgeRepository repository.KnowledgeRepository,
 	tagRepository repository.TagRepository,
+	commentRepository repository.CommentRepository,
+	accessRepository repository.AccessRepository,
 	tenantRepository repository.TenantRepository,
 ) UpdateKnowledgeUseCase {
 	return &updateKnowledgeUseCase{
 		knowledgeRepository: knowledgeRepository,
 		tagRepository:       tagRepository,
+		commentRepository:   commentRepository,
+		accessRepository:    accessRepository,
 		tenantRepository:    tenantRepository,
 	}
 }
@@ -49,15 +58,22 @@ func (uc *updateKnowledgeUseCase) Execute(input UpdateKnowledgeInput) (*model.Kn
 	}
 
 	// Find knowledge
-	knowledge, err := uc.knowledgeRepository.FindByID(input.ID, input.TenantID)
+	knowledge, err := uc.knowledgeRepository.FindByID(input.ID, input.TenantID, authz.Viewer(input.Actor))
+	if err != nil || knowledge == nil {
+		return nil, ErrKnowledgeNotFound
+	}
+
+	// Check permission
+	resource, err := access.KnowledgeResource(uc.accessRepository, knowledge, input.Actor)
 	if err != nil {
 		return nil, err
 	}
-	if knowledge == nil {
-		return nil, errors.New("knowledge not found")
+	if err := authz.Can(input.Actor, authz.ActionUpdate, resource); err != nil {
+		return nil, err
 	}
 
 	// Update knowledge fields if provided
+	contentChanged := input.Content != "" && input.Content != knowledge.Content
 	if input.Title != "" {
 		knowledge.Title = input.Title
 	}
@@ -90,5 +106,51 @@ func (uc *updateKnowledgeUseCase) Execute(input UpdateKnowledgeInput) (*model.Kn
 		return nil, err
 	}
 
+	// Move inline comments along with the edited content
+	if contentChanged {
+		if err := uc.reanchorComments(knowledge); err != nil {
+			return nil, err
+		}
+	}
+
 	return knowledge, nil
+}
+
+// reanchorComments relocates inline comments in the updated content and
+// marks them as outdated when their anchored text was removed
+func (uc *updateKnowledgeUseCase) reanchorComments(knowledge *model.Knowledge) error {
+	comments, err := uc.commentRepository.FindByKnowledgeID(knowledge.ID, knowledge.TenantID, nil, repository.Viewer{HiddenComments: true})
+	if err != nil {
+		return err
+	}
+
+	for _, comment := range comments {
+		if !comment.IsInline() || comment.Outdated || comment.AnchorStart == nil || comment.AnchorEnd == nil {
+			continue
+		}
+
+		current := anchor.Anchor{
+			BlockID: comment.AnchorBlock,
+			Start:   *comment.AnchorStart,
+			End:     *comment.AnchorEnd,
+			Quote:   comment.AnchorQuote,
+		}
+		relocated, ok := anchor.Relocate(current, knowledge.Content)
+		if ok && relocated == current {
+			continue
+		}
+
+		if ok {
+			comment.AnchorBlock = relocated.BlockID
+			comment.AnchorStart = &relocated.Start
+			comment.AnchorEnd = &relocated.End
+		} else {
+			comment.Outdated = true
+		}
+		if err := uc.commentRepository.Update(comment); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
\ No newline at end of file
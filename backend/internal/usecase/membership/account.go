@@ -0,0 +1,43 @@
+package membership
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// findMemberships returns the memberships of the user the account belongs
+// to. An account that never joined another tenant has a single implicit
+// membership, without an ID, in its own tenant.
+func findMemberships(membershipRepository repository.MembershipRepository, userID string, tenantID string) ([]*model.Membership, error) {
+	accountID := userID
+	membership, err := membershipRepository.FindByUserID(userID, tenantID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if membership != nil {
+		accountID = membership.AccountID
+	}
+
+	memberships, err := membershipRepository.FindByAccountID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		memberships = []*model.Membership{{AccountID: accountID, TenantID: tenantID, UserID: userID}}
+	}
+	return memberships, nil
+}
+
+// membershipOf returns the membership in the tenant, or nil
+func membershipOf(memberships []*model.Membership, tenantID string) *model.Membership {
+	for _, membership := range memberships {
+		if membership.TenantID == tenantID {
+			return membership
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package membership
+
+import "errors"
+
+var (
+	// ErrNotMember is returned when the user has no account in the tenant
+	ErrNotMember = errors.New("not a member of the tenant")
+
+	// ErrAlreadyMember is returned when the user already has an account in the tenant
+	ErrAlreadyMember = errors.New("already a member of the tenant")
+
+	// ErrAccountDeactivated is returned when the user's account in the tenant is deactivated
+	ErrAccountDeactivated = errors.New("account in the tenant is deactivated")
+
+	// ErrSignInRequired is returned when the tenant requires SSO or a second
+	// factor that switching from another tenant can't satisfy
+	ErrSignInRequired = errors.New("the tenant requires signing in to it directly")
+
+	// ErrEmailMismatch is returned when the invitation was sent to another email than the user's
+	ErrEmailMismatch = errors.New("invitation was sent to another email")
+)
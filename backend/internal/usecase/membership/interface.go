@@ -0,0 +1,44 @@
+package membership
+
+import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+
+// TenantMembership is a tenant the user belongs to and their account in it
+type TenantMembership struct {
+	Tenant *model.Tenant
+	User   *model.User // Account of the user in the tenant, whose role applies there
+}
+
+// ListMembershipsUseCase defines the interface for listing the tenants a user belongs to
+type ListMembershipsUseCase interface {
+	Execute(input ListMembershipsInput) ([]*TenantMembership, error)
+}
+
+// ListMembershipsInput contains the data needed to list the tenants of a user
+type ListMembershipsInput struct {
+	UserID   string
+	TenantID string
+}
+
+// SwitchTenantUseCase defines the interface for switching to the user's account in another tenant
+type SwitchTenantUseCase interface {
+	Execute(input SwitchTenantInput) (*model.User, error)
+}
+
+// SwitchTenantInput contains the data needed to switch tenants
+type SwitchTenantInput struct {
+	UserID         string // Current account of the user
+	TenantID       string
+	TargetTenantID string
+}
+
+// JoinTenantUseCase defines the interface for joining another tenant with an invitation
+type JoinTenantUseCase interface {
+	Execute(input JoinTenantInput) (*TenantMembership, error)
+}
+
+// JoinTenantInput contains the data needed to join a tenant
+type JoinTenantInput struct {
+	UserID   string // Current account of the user
+	TenantID string
+	Token    string // Invitation to the other tenant, sent to the user's email
+}
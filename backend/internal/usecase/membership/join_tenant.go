@@ -0,0 +1,144 @@
+package membership
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/invitation"
+)
+
+type joinTenantUseCase struct {
+	membershipRepository repository.MembershipRepository
+	invitationRepository repository.InvitationRepository
+	userRepository       repository.UserRepository
+	tenantRepository     repository.TenantRepository
+	signer               *security.TokenSigner
+}
+
+// NewJoinTenantUseCase creates a new instance of JoinTenantUseCase. The
+// signer must be the one invitation tokens are signed with.
+func NewJoinTenantUseCase(
+	membershipRepository repository.MembershipRepository,
+	invitationRepository repository.InvitationRepository,
+	userRepository repository.UserRepository,
+	tenantRepository repository.TenantRepository,
+	signer *security.TokenSigner,
+) JoinTenantUseCase {
+	return &joinTenantUseCase{
+		membershipRepository: membershipRepository,
+		invitationRepository: invitationRepository,
+		userRepository:       userRepository,
+		tenantRepository:     tenantRepository,
+		signer:               signer,
+	}
+}
+
+// Execute accepts an invitation for a signed-in user of another tenant. The
+// user gets an account in the invitation's tenant with the invited role,
+// which they sign in to by switching tenants rather than with a password.
+func (uc *joinTenantUseCase) Execute(input JoinTenantInput) (*TenantMembership, error) {
+	// Validate input
+	if input.Token == "" {
+		return nil, errors.New("token is required")
+	}
+
+	// Reject forged tokens before looking them up
+	if err := uc.signer.Verify(input.Token); err != nil {
+		return nil, invitation.ErrInvalidInvitation
+	}
+
+	// Get invitation
+	invited, err := uc.invitationRepository.FindByTokenHash(security.HashToken(input.Token))
+	if err != nil || invited == nil {
+		return nil, invitation.ErrInvalidInvitation
+	}
+
+	// Check invitation can still be used
+	if invited.Status != model.InvitationStatusPending {
+		return nil, invitation.ErrInvitationNotPending
+	}
+	if invited.IsExpired() {
+		return nil, invitation.ErrInvitationExpired
+	}
+
+	// Find user, who must own the invited email
+	current, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(current.Email, invited.Email) {
+		return nil, ErrEmailMismatch
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(invited.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Check the user doesn't belong to the tenant yet
+	memberships, err := findMemberships(uc.membershipRepository, input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if membershipOf(memberships, invited.TenantID) != nil {
+		return nil, ErrAlreadyMember
+	}
+	if existing, err := uc.userRepository.FindByEmail(invited.Email, invited.TenantID); err == nil && existing != nil {
+		return nil, ErrAlreadyMember
+	}
+
+	// Build account in the tenant
+	now := time.Now()
+	account := &model.User{
+		ID:        uuid.New().String(),
+		Name:      current.Name,
+		Email:     invited.Email,
+		TenantID:  invited.TenantID,
+		Role:      invited.Role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Build memberships, including the current account's on its first join
+	var created []*model.Membership
+	accountID := memberships[0].AccountID
+	if memberships[0].ID == "" {
+		memberships[0].ID = uuid.New().String()
+		memberships[0].CreatedAt = now
+		created = append(created, memberships[0])
+	}
+	created = append(created, &model.Membership{
+		ID:        uuid.New().String(),
+		AccountID: accountID,
+		TenantID:  invited.TenantID,
+		UserID:    account.ID,
+		CreatedAt: now,
+	})
+
+	// Consume invitation
+	invited.Status = model.InvitationStatusAccepted
+	invited.AcceptedAt = &now
+	invited.UpdatedAt = now
+
+	// Save account, memberships and invitation together, so that a failure
+	// leaves neither an account without membership nor a reusable invitation
+	joined, err := uc.membershipRepository.Join(account, created, invited)
+	if err != nil {
+		return nil, err
+	}
+	if !joined {
+		return nil, invitation.ErrInvitationNotPending
+	}
+
+	return &TenantMembership{Tenant: tenant, User: account}, nil
+}
@@ -0,0 +1,64 @@
+package membership
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type listMembershipsUseCase struct {
+	membershipRepository repository.MembershipRepository
+	userRepository       repository.UserRepository
+	tenantRepository     repository.TenantRepository
+}
+
+// NewListMembershipsUseCase creates a new instance of ListMembershipsUseCase
+func NewListMembershipsUseCase(
+	membershipRepository repository.MembershipRepository,
+	userRepository repository.UserRepository,
+	tenantRepository repository.TenantRepository,
+) ListMembershipsUseCase {
+	return &listMembershipsUseCase{
+		membershipRepository: membershipRepository,
+		userRepository:       userRepository,
+		tenantRepository:     tenantRepository,
+	}
+}
+
+// Execute lists the tenants the user belongs to with their active accounts
+// in them, including the current one
+func (uc *listMembershipsUseCase) Execute(input ListMembershipsInput) ([]*TenantMembership, error) {
+	// Validate input
+	if input.UserID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Find memberships
+	memberships, err := findMemberships(uc.membershipRepository, input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the tenants and accounts, skipping deactivated ones and other
+	// tenants that are being deleted
+	result := make([]*TenantMembership, 0, len(memberships))
+	for _, membership := range memberships {
+		tenant, err := uc.tenantRepository.FindByID(membership.TenantID)
+		if err != nil || tenant == nil {
+			continue
+		}
+		if tenant.IsDisabled() && tenant.ID != input.TenantID {
+			continue
+		}
+		account, err := uc.userRepository.FindByID(membership.UserID, membership.TenantID)
+		if err != nil || account == nil || !account.IsActive() {
+			continue
+		}
+		account.Password = ""
+		result = append(result, &TenantMembership{Tenant: tenant, User: account})
+	}
+	return result, nil
+}
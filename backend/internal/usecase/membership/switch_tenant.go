@@ -0,0 +1,83 @@
+package membership
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type switchTenantUseCase struct {
+	membershipRepository repository.MembershipRepository
+	userRepository       repository.UserRepository
+	tenantRepository     repository.TenantRepository
+}
+
+// NewSwitchTenantUseCase creates a new instance of SwitchTenantUseCase
+func NewSwitchTenantUseCase(
+	membershipRepository repository.MembershipRepository,
+	userRepository repository.UserRepository,
+	tenantRepository repository.TenantRepository,
+) SwitchTenantUseCase {
+	return &switchTenantUseCase{
+		membershipRepository: membershipRepository,
+		userRepository:       userRepository,
+		tenantRepository:     tenantRepository,
+	}
+}
+
+// Execute returns the user's account in the target tenant to start a session
+// for. Tenants that require SSO can't be switched to, and tenants that
+// require a second factor only from an account that has one.
+func (uc *switchTenantUseCase) Execute(input SwitchTenantInput) (*model.User, error) {
+	// Validate input
+	if input.UserID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" || input.TargetTenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Find membership
+	memberships, err := findMemberships(uc.membershipRepository, input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	membership := membershipOf(memberships, input.TargetTenantID)
+	if membership == nil {
+		return nil, ErrNotMember
+	}
+
+	// Find account in the tenant
+	account, err := uc.userRepository.FindByID(membership.UserID, membership.TenantID)
+	if err != nil || account == nil {
+		return nil, ErrNotMember
+	}
+	if !account.IsActive() {
+		return nil, ErrAccountDeactivated
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TargetTenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Check the tenant's sign-in requirements
+	if tenant.Settings.PasswordLoginDisabled() {
+		return nil, ErrSignInRequired
+	}
+	if tenant.Settings.Security.RequireTwoFactor && !account.TwoFactorEnabled {
+		current, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+		if err != nil || current == nil || !current.TwoFactorEnabled {
+			return nil, ErrSignInRequired
+		}
+	}
+
+	// Return user without password
+	account.Password = ""
+	return account, nil
+}
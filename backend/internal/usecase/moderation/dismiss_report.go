@@ -0,0 +1,87 @@
+package moderation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type dismissReportUseCase struct {
+	moderationRepository repository.ModerationRepository
+	tenantRepository     repository.TenantRepository
+}
+
+// NewDismissReportUseCase creates a new instance of DismissReportUseCase
+func NewDismissReportUseCase(
+	moderationRepository repository.ModerationRepository,
+	tenantRepository repository.TenantRepository,
+) DismissReportUseCase {
+	return &dismissReportUseCase{
+		moderationRepository: moderationRepository,
+		tenantRepository:     tenantRepository,
+	}
+}
+
+// Execute dismisses a comment report and records the moderation action
+func (uc *dismissReportUseCase) Execute(input DismissReportInput) (*model.CommentReport, error) {
+	// Validate input
+	if input.ReportID == "" {
+		return nil, errors.New("report ID is required")
+	}
+	if input.ActorID == "" {
+		return nil, errors.New("actor ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find report
+	report, err := uc.moderationRepository.FindReportByID(input.ReportID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, errors.New("report not found")
+	}
+	if report.Status != model.ReportStatusOpen {
+		return nil, errors.New("report is already closed")
+	}
+
+	// Update report
+	report.Status = model.ReportStatusDismissed
+	report.UpdatedAt = time.Now()
+	err = uc.moderationRepository.UpdateReport(report)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record moderation action
+	err = uc.moderationRepository.CreateAction(&model.ModerationAction{
+		ID:         uuid.New().String(),
+		TenantID:   input.TenantID,
+		ActorID:    input.ActorID,
+		Action:     model.ModerationActionDismissReport,
+		TargetType: model.ModerationTargetReport,
+		TargetID:   report.ID,
+		Reason:     input.Reason,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,100 @@
+package moderation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type hideCommentUseCase struct {
+	moderationRepository repository.ModerationRepository
+	commentRepository    repository.CommentRepository
+	tenantRepository     repository.TenantRepository
+}
+
+// NewHideCommentUseCase creates a new instance of HideCommentUseCase
+func NewHideCommentUseCase(
+	moderationRepository repository.ModerationRepository,
+	commentRepository repository.CommentRepository,
+	tenantRepository repository.TenantRepository,
+) HideCommentUseCase {
+	return &hideCommentUseCase{
+		moderationRepository: moderationRepository,
+		commentRepository:    commentRepository,
+		tenantRepository:     tenantRepository,
+	}
+}
+
+// Execute hides or unhides a comment and records the moderation action
+func (uc *hideCommentUseCase) Execute(input HideCommentInput) (*model.Comment, error) {
+	// Validate input
+	if input.CommentID == "" {
+		return nil, errors.New("comment ID is required")
+	}
+	if input.ActorID == "" {
+		return nil, errors.New("actor ID is required")
+	}
+	if input.Hidden && input.Reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find comment
+	comment, err := uc.commentRepository.FindByID(input.CommentID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, errors.New("comment not found")
+	}
+
+	// Update comment visibility
+	comment.Hidden = input.Hidden
+	comment.UpdatedAt = time.Now()
+	err = uc.commentRepository.Update(comment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hiding a comment addresses every open report on it
+	action := model.ModerationActionUnhideComment
+	if input.Hidden {
+		action = model.ModerationActionHideComment
+		err = uc.moderationRepository.ResolveReportsForComment(comment.ID, input.TenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Record moderation action
+	err = uc.moderationRepository.CreateAction(&model.ModerationAction{
+		ID:         uuid.New().String(),
+		TenantID:   input.TenantID,
+		ActorID:    input.ActorID,
+		Action:     action,
+		TargetType: model.ModerationTargetComment,
+		TargetID:   comment.ID,
+		Reason:     input.Reason,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
@@ -0,0 +1,57 @@
+package moderation
+
+import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+
+// ReportCommentUseCase defines the interface for reporting a comment
+type ReportCommentUseCase interface {
+	Execute(input ReportCommentInput) (*model.CommentReport, error)
+}
+
+// ReportCommentInput contains the data needed to report a comment
+type ReportCommentInput struct {
+	CommentID  string
+	ReporterID string
+	Reason     string
+	TenantID   string
+}
+
+// HideCommentUseCase defines the interface for hiding or unhiding a comment
+type HideCommentUseCase interface {
+	Execute(input HideCommentInput) (*model.Comment, error)
+}
+
+// HideCommentInput contains the data needed to hide or unhide a comment
+type HideCommentInput struct {
+	CommentID string
+	Hidden    bool
+	ActorID   string
+	Reason    string
+	TenantID  string
+}
+
+// LockDiscussionUseCase defines the interface for locking or unlocking a knowledge's discussion
+type LockDiscussionUseCase interface {
+	Execute(input LockDiscussionInput) (*model.Knowledge, error)
+}
+
+// LockDiscussionInput contains the data needed to lock or unlock a knowledge's discussion
+type LockDiscussionInput struct {
+	KnowledgeID string
+	Locked      bool
+	ActorID     string
+	Reason      string
+	TenantID    string
+}
+
+// DismissReportUseCase defines the interface for dismissing a comment report
+type DismissReportUseCase interface {
+	Execute(input DismissReportInput) (*model.CommentReport, error)
+}
+
+// DismissReportInput contains the data needed to dismiss a comment report
+type DismissReportInput struct {
+	ReportID string
+	ActorID  string
+	Reason   string
+	TenantID string
+}
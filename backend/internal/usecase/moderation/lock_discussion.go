@@ -0,0 +1,94 @@
+package moderation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type lockDiscussionUseCase struct {
+	moderationRepository repository.ModerationRepository
+	knowledgeRepository  repository.KnowledgeRepository
+	tenantRepository     repository.TenantRepository
+}
+
+// NewLockDiscussionUseCase creates a new instance of LockDiscussionUseCase
+func NewLockDiscussionUseCase(
+	moderationRepository repository.ModerationRepository,
+	knowledgeRepository repository.KnowledgeRepository,
+	tenantRepository repository.TenantRepository,
+) LockDiscussionUseCase {
+	return &lockDiscussionUseCase{
+		moderationRepository: moderationRepository,
+		knowledgeRepository:  knowledgeRepository,
+		tenantRepository:     tenantRepository,
+	}
+}
+
+// Execute locks or unlocks the discussion of a knowledge and records the moderation action
+func (uc *lockDiscussionUseCase) Execute(input LockDiscussionInput) (*model.Knowledge, error) {
+	// Validate input
+	if input.KnowledgeID == "" {
+		return nil, errors.New("knowledge ID is required")
+	}
+	if input.ActorID == "" {
+		return nil, errors.New("actor ID is required")
+	}
+	if input.Locked && input.Reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find knowledge
+	knowledge, err := uc.knowledgeRepository.FindByID(input.KnowledgeID, input.TenantID, repository.Viewer{UserID: input.ActorID, HiddenComments: true})
+	if err != nil {
+		return nil, err
+	}
+	if knowledge == nil {
+		return nil, errors.New("knowledge not found")
+	}
+
+	// Update discussion state
+	knowledge.DiscussionLocked = input.Locked
+	knowledge.UpdatedAt = time.Now()
+	err = uc.knowledgeRepository.Update(knowledge)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record moderation action
+	action := model.ModerationActionUnlockDiscussion
+	if input.Locked {
+		action = model.ModerationActionLockDiscussion
+	}
+	err = uc.moderationRepository.CreateAction(&model.ModerationAction{
+		ID:         uuid.New().String(),
+		TenantID:   input.TenantID,
+		ActorID:    input.ActorID,
+		Action:     action,
+		TargetType: model.ModerationTargetKnowledge,
+		TargetID:   knowledge.ID,
+		Reason:     input.Reason,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return knowledge, nil
+}
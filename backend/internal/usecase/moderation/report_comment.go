@@ -0,0 +1,86 @@
+package moderation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type reportCommentUseCase struct {
+	moderationRepository repository.ModerationRepository
+	commentRepository    repository.CommentRepository
+	tenantRepository     repository.TenantRepository
+}
+
+// NewReportCommentUseCase creates a new instance of ReportCommentUseCase
+func NewReportCommentUseCase(
+	moderationRepository repository.ModerationRepository,
+	commentRepository repository.CommentRepository,
+	tenantRepository repository.TenantRepository,
+) ReportCommentUseCase {
+	return &reportCommentUseCase{
+		moderationRepository: moderationRepository,
+		commentRepository:    commentRepository,
+		tenantRepository:     tenantRepository,
+	}
+}
+
+// Execute reports a comment to the moderators
+func (uc *reportCommentUseCase) Execute(input ReportCommentInput) (*model.CommentReport, error) {
+	// Validate input
+	if input.CommentID == "" {
+		return nil, errors.New("comment ID is required")
+	}
+	if input.ReporterID == "" {
+		return nil, errors.New("reporter ID is required")
+	}
+	if input.Reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Verify comment exists
+	comment, err := uc.commentRepository.FindByID(input.CommentID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, errors.New("comment not found")
+	}
+
+	// Create report
+	now := time.Now()
+	report := &model.CommentReport{
+		ID:         uuid.New().String(),
+		CommentID:  comment.ID,
+		TenantID:   input.TenantID,
+		ReporterID: input.ReporterID,
+		Reason:     input.Reason,
+		Status:     model.ReportStatusOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	// Save report
+	err = uc.moderationRepository.CreateReport(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,16 @@
+package notification
+
+// NotifyMentionsUseCase defines the interface for notifying the members of mentioned groups
+type NotifyMentionsUseCase interface {
+	Execute(input NotifyMentionsInput) error
+}
+
+// NotifyMentionsInput contains the data needed to notify the members of the
+// groups mentioned in knowledge content or a comment
+type NotifyMentionsInput struct {
+	KnowledgeID  string
+	TenantID     string
+	AuthorID     string // User who wrote the text, who isn't notified
+	Text         string
+	PreviousText string // Optional, groups already mentioned in it aren't notified again
+}
@@ -0,0 +1,128 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/mention"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
+)
+
+type notifyMentionsUseCase struct {
+	knowledgeRepository repository.KnowledgeRepository
+	groupRepository     repository.GroupRepository
+	userRepository      repository.UserRepository
+	tenantRepository    repository.TenantRepository
+	mailer              mail.Mailer
+	knowledgeURL        string
+}
+
+// NewNotifyMentionsUseCase creates a new instance of NotifyMentionsUseCase.
+// The knowledge ID is appended to knowledgeURL in the notification email.
+func NewNotifyMentionsUseCase(
+	knowledgeRepository repository.KnowledgeRepository,
+	groupRepository repository.GroupRepository,
+	userRepository repository.UserRepository,
+	tenantRepository repository.TenantRepository,
+	mailer mail.Mailer,
+	knowledgeURL string,
+) NotifyMentionsUseCase {
+	return &notifyMentionsUseCase{
+		knowledgeRepository: knowledgeRepository,
+		groupRepository:     groupRepository,
+		userRepository:      userRepository,
+		tenantRepository:    tenantRepository,
+		mailer:              mailer,
+		knowledgeURL:        knowledgeURL,
+	}
+}
+
+// Execute emails the members of the newly mentioned groups. Members are
+// notified once even if several of their groups are mentioned, and only if
+// they can see the knowledge.
+func (uc *notifyMentionsUseCase) Execute(input NotifyMentionsInput) error {
+	// Validate input
+	if input.KnowledgeID == "" {
+		return errors.New("knowledge ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Find mentions
+	handles := mention.Added(input.PreviousText, input.Text)
+	if len(handles) == 0 {
+		return nil
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Find knowledge
+	knowledge, err := uc.knowledgeRepository.FindByID(input.KnowledgeID, input.TenantID, repository.Viewer{})
+	if err != nil {
+		return err
+	}
+	authorName := "Someone"
+	if author, err := uc.userRepository.FindByID(input.AuthorID, input.TenantID); err == nil && author != nil {
+		authorName = author.Name
+	}
+
+	// Notify members of the mentioned groups
+	notified := map[string]bool{input.AuthorID: true}
+	var failed error
+	for _, handle := range handles {
+		group, err := uc.groupRepository.FindByHandle(handle, input.TenantID)
+		if err != nil || group == nil {
+			continue
+		}
+		members, err := uc.groupRepository.FindMembers(group.ID, input.TenantID)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if notified[member.ID] || !member.IsActive() || !uc.canSee(knowledge, member) {
+				continue
+			}
+			notified[member.ID] = true
+			if err := uc.send(tenant, knowledge, group, member, authorName); err != nil {
+				failed = err
+			}
+		}
+	}
+	if failed != nil {
+		return fmt.Errorf("failed to send mention notification: %w", failed)
+	}
+	return nil
+}
+
+// canSee reports whether the knowledge is visible to the user
+func (uc *notifyMentionsUseCase) canSee(knowledge *model.Knowledge, user *model.User) bool {
+	found, err := uc.knowledgeRepository.FindByID(knowledge.ID, knowledge.TenantID, repository.Viewer{UserID: user.ID})
+	return err == nil && found != nil
+}
+
+// send emails the notification to a member of the mentioned group
+func (uc *notifyMentionsUseCase) send(tenant *model.Tenant, knowledge *model.Knowledge, group *model.Group, member *model.User, authorName string) error {
+	return uc.mailer.Send(mail.Message{
+		To:      member.Email,
+		Subject: fmt.Sprintf("@%s was mentioned in %s", group.Handle, knowledge.Title),
+		Body: fmt.Sprintf(
+			"%s mentioned @%s, a group you are a member of, in %q on %s.\n\nRead it here:\n%s/%s\n",
+			authorName,
+			group.Handle,
+			knowledge.Title,
+			tenant.Name,
+			uc.knowledgeURL,
+			knowledge.ID,
+		),
+	})
+}
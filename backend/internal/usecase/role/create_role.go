@@ -0,0 +1,82 @@
+package role
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type createRoleUseCase struct {
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewCreateRoleUseCase creates a new instance of CreateRoleUseCase
+func NewCreateRoleUseCase(
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+) CreateRoleUseCase {
+	return &createRoleUseCase{
+		roleRepository:   roleRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute creates a custom role
+func (uc *createRoleUseCase) Execute(input CreateRoleInput) (*model.TenantRole, error) {
+	// Validate input
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.Name == "" {
+		return nil, errors.New("role name is required")
+	}
+	permissions, err := normalizePermissions(input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Check permission
+	if err := canGrantPermissions(input.Actor, input.TenantID, permissions); err != nil {
+		return nil, err
+	}
+
+	// Check if name is taken
+	existingRole, err := uc.roleRepository.FindByName(input.Name, input.TenantID)
+	if err == nil && existingRole != nil {
+		return nil, ErrRoleNameTaken
+	}
+
+	// Create role
+	now := time.Now()
+	role := &model.TenantRole{
+		ID:          uuid.New().String(),
+		TenantID:    input.TenantID,
+		Name:        input.Name,
+		Description: input.Description,
+		Permissions: permissions,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// Save role
+	err = uc.roleRepository.Create(role)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
@@ -0,0 +1,72 @@
+package role
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type deleteRoleUseCase struct {
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewDeleteRoleUseCase creates a new instance of DeleteRoleUseCase
+func NewDeleteRoleUseCase(
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+) DeleteRoleUseCase {
+	return &deleteRoleUseCase{
+		roleRepository:   roleRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute deletes a custom role. Roles still assigned to users or groups, and
+// the default roles, can't be deleted.
+func (uc *deleteRoleUseCase) Execute(input DeleteRoleInput) error {
+	// Validate input
+	if input.ID == "" {
+		return errors.New("role ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Find role
+	role, err := uc.roleRepository.FindByID(input.ID, input.TenantID)
+	if err != nil || role == nil {
+		return ErrRoleNotFound
+	}
+	if role.System {
+		return ErrSystemRole
+	}
+
+	// Check if role is in use
+	count, err := uc.roleRepository.CountUsers(role.Name, input.TenantID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRoleInUse
+	}
+	count, err = uc.roleRepository.CountGroups(role.Name, input.TenantID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRoleInUse
+	}
+
+	// Delete role
+	return uc.roleRepository.Delete(role.ID, input.TenantID)
+}
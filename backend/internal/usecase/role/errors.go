@@ -0,0 +1,20 @@
+package role
+
+import "errors"
+
+var (
+	// ErrRoleNotFound is returned when the role does not exist in the tenant
+	ErrRoleNotFound = errors.New("role not found")
+
+	// ErrRoleNameTaken is returned when the tenant already has a role with the name
+	ErrRoleNameTaken = errors.New("a role with this name already exists")
+
+	// ErrInvalidPermission is returned when a permission is not one a role can grant
+	ErrInvalidPermission = errors.New("invalid permission")
+
+	// ErrSystemRole is returned when changing the admin role or deleting a default role
+	ErrSystemRole = errors.New("default roles can't be changed this way")
+
+	// ErrRoleInUse is returned when deleting a role that users or groups still have
+	ErrRoleInUse = errors.New("role is assigned to users or groups")
+)
@@ -0,0 +1,47 @@
+package role
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// CreateRoleUseCase defines the interface for creating a tenant role
+type CreateRoleUseCase interface {
+	Execute(input CreateRoleInput) (*model.TenantRole, error)
+}
+
+// CreateRoleInput contains the data needed to create a tenant role
+type CreateRoleInput struct {
+	TenantID    string
+	Name        string
+	Description string
+	Permissions []string
+	Actor       authz.Subject // Can't grant permissions they don't have
+}
+
+// UpdateRoleUseCase defines the interface for updating a tenant role
+type UpdateRoleUseCase interface {
+	Execute(input UpdateRoleInput) (*model.TenantRole, error)
+}
+
+// UpdateRoleInput contains the data needed to update a tenant role. Fields
+// left nil are unchanged. The name can't be changed, as users reference
+// their role by name.
+type UpdateRoleInput struct {
+	ID          string
+	TenantID    string
+	Description *string
+	Permissions []string // Replaces the permissions when not nil
+	Actor       authz.Subject
+}
+
+// DeleteRoleUseCase defines the interface for deleting a tenant role
+type DeleteRoleUseCase interface {
+	Execute(input DeleteRoleInput) error
+}
+
+// DeleteRoleInput contains the data needed to delete a tenant role
+type DeleteRoleInput struct {
+	ID       string
+	TenantID string
+}
@@ -0,0 +1,32 @@
+package role
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// normalizePermissions validates the permissions and removes duplicates,
+// returning them in the order of model.Permissions
+func normalizePermissions(permissions []string) ([]string, error) {
+	requested := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		if !model.IsValidPermission(permission) {
+			return nil, ErrInvalidPermission
+		}
+		requested[permission] = true
+	}
+
+	normalized := make([]string, 0, len(requested))
+	for _, permission := range model.Permissions {
+		if requested[permission] {
+			normalized = append(normalized, permission)
+		}
+	}
+	return normalized, nil
+}
+
+// canGrantPermissions returns authz.ErrForbidden unless the actor has every
+// permission, so that roles can't be used to escalate privileges
+func canGrantPermissions(actor authz.Subject, tenantID string, permissions []string) error {
+	return authz.CanGrant(actor, &model.TenantRole{TenantID: tenantID, Permissions: permissions})
+}
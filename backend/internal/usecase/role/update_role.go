@@ -0,0 +1,84 @@
+package role
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type updateRoleUseCase struct {
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewUpdateRoleUseCase creates a new instance of UpdateRoleUseCase
+func NewUpdateRoleUseCase(
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+) UpdateRoleUseCase {
+	return &updateRoleUseCase{
+		roleRepository:   roleRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute updates the description and permissions of a role. The permissions
+// of the admin role can't be changed.
+func (uc *updateRoleUseCase) Execute(input UpdateRoleInput) (*model.TenantRole, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("role ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find role
+	role, err := uc.roleRepository.FindByID(input.ID, input.TenantID)
+	if err != nil || role == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	// Update fields
+	if input.Description != nil {
+		role.Description = *input.Description
+	}
+	if input.Permissions != nil {
+		if !role.IsEditable() {
+			return nil, ErrSystemRole
+		}
+		permissions, err := normalizePermissions(input.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		// Both the current and the new permissions must be the actor's own,
+		// so that they can neither grant nor take away more than they have
+		if err := canGrantPermissions(input.Actor, input.TenantID, permissions); err != nil {
+			return nil, err
+		}
+		if err := canGrantPermissions(input.Actor, input.TenantID, role.Permissions); err != nil {
+			return nil, err
+		}
+		role.Permissions = permissions
+	}
+	role.UpdatedAt = time.Now()
+
+	// Save role
+	err = uc.roleRepository.Update(role)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
@@ -0,0 +1,14 @@
+package scim
+
+import "errors"
+
+var (
+	// ErrUserNotFound is returned when the user does not exist in the tenant
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrUserExists is returned when another user of the tenant already has the email
+	ErrUserExists = errors.New("a user with this email already exists")
+
+	// ErrInvalidRole is returned when the identity provider sends an unknown role
+	ErrInvalidRole = errors.New("invalid role")
+)
@@ -0,0 +1,35 @@
+package scim
+
+import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+
+// ProvisionUserUseCase defines the interface for creating a user on behalf of the identity provider
+type ProvisionUserUseCase interface {
+	Execute(input ProvisionUserInput) (*model.User, error)
+}
+
+// ProvisionUserInput contains the data needed to provision a user
+type ProvisionUserInput struct {
+	TenantID   string
+	Email      string
+	Name       string
+	ExternalID string
+	Role       string // Optional, viewer when empty
+	Active     bool
+}
+
+// UpdateProvisionedUserUseCase defines the interface for updating a user on behalf of the identity provider
+type UpdateProvisionedUserUseCase interface {
+	Execute(input UpdateProvisionedUserInput) (*model.User, error)
+}
+
+// UpdateProvisionedUserInput contains the data needed to update a provisioned
+// user. Fields left nil are unchanged.
+type UpdateProvisionedUserInput struct {
+	ID         string
+	TenantID   string
+	Email      *string
+	Name       *string
+	ExternalID *string
+	Role       *string
+	Active     *bool
+}
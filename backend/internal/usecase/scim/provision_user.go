@@ -0,0 +1,93 @@
+package scim
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type provisionUserUseCase struct {
+	userRepository   repository.UserRepository
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewProvisionUserUseCase creates a new instance of ProvisionUserUseCase
+func NewProvisionUserUseCase(
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+) ProvisionUserUseCase {
+	return &provisionUserUseCase{
+		userRepository:   userRepository,
+		roleRepository:   roleRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute creates a user without a password. Provisioned users sign in
+// through SSO or set a password with a password reset.
+func (uc *provisionUserUseCase) Execute(input ProvisionUserInput) (*model.User, error) {
+	// Validate input
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.Email == "" {
+		return nil, errors.New("email is required")
+	}
+	if input.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	role := model.RoleViewer
+	if input.Role != "" {
+		role = model.Role(input.Role)
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Validate role
+	if err := validateRole(uc.roleRepository, role.String(), input.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Check if user already exists
+	existingUser, err := uc.userRepository.FindByEmail(input.Email, input.TenantID)
+	if err == nil && existingUser != nil {
+		return nil, ErrUserExists
+	}
+
+	// Create user
+	now := time.Now()
+	user := &model.User{
+		ID:         uuid.New().String(),
+		Name:       input.Name,
+		Email:      input.Email,
+		TenantID:   input.TenantID,
+		Role:       role.String(),
+		ExternalID: input.ExternalID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if !input.Active {
+		user.DeactivatedAt = &now
+	}
+
+	// Save user
+	err = uc.userRepository.Create(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,20 @@
+package scim
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// validateRole returns ErrInvalidRole when the tenant has no role with the name
+func validateRole(roleRepository repository.RoleRepository, name string, tenantID string) error {
+	if _, err := roleRepository.FindByName(name, tenantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRole
+		}
+		return err
+	}
+	return nil
+}
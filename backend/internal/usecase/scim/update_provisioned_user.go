@@ -0,0 +1,119 @@
+package scim
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type updateProvisionedUserUseCase struct {
+	userRepository    repository.UserRepository
+	roleRepository    repository.RoleRepository
+	sessionRepository repository.SessionRepository
+	tenantRepository  repository.TenantRepository
+}
+
+// NewUpdateProvisionedUserUseCase creates a new instance of UpdateProvisionedUserUseCase
+func NewUpdateProvisionedUserUseCase(
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	sessionRepository repository.SessionRepository,
+	tenantRepository repository.TenantRepository,
+) UpdateProvisionedUserUseCase {
+	return &updateProvisionedUserUseCase{
+		userRepository:    userRepository,
+		roleRepository:    roleRepository,
+		sessionRepository: sessionRepository,
+		tenantRepository:  tenantRepository,
+	}
+}
+
+// Execute updates a user. Deactivated users are signed out and keep their content.
+func (uc *updateProvisionedUserUseCase) Execute(input UpdateProvisionedUserInput) (*model.User, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.Email != nil && *input.Email == "" {
+		return nil, errors.New("email is required")
+	}
+	if input.Name != nil && *input.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Validate role
+	if input.Role != nil {
+		if err := validateRole(uc.roleRepository, *input.Role, input.TenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.ID, input.TenantID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	// Check if email is being changed and if it's already in use
+	if input.Email != nil && *input.Email != user.Email {
+		existingUser, err := uc.userRepository.FindByEmail(*input.Email, input.TenantID)
+		if err == nil && existingUser != nil {
+			return nil, ErrUserExists
+		}
+		user.Email = *input.Email
+	}
+
+	// Update fields
+	now := time.Now()
+	if input.Name != nil {
+		user.Name = *input.Name
+	}
+	if input.ExternalID != nil {
+		user.ExternalID = *input.ExternalID
+	}
+	if input.Role != nil {
+		user.Role = *input.Role
+	}
+	deactivated := false
+	if input.Active != nil {
+		if *input.Active {
+			user.DeactivatedAt = nil
+		} else if user.IsActive() {
+			user.DeactivatedAt = &now
+			deactivated = true
+		}
+	}
+	user.UpdatedAt = now
+
+	// Save user
+	err = uc.userRepository.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deactivated users are signed out immediately
+	if deactivated {
+		err = uc.sessionRepository.RevokeAllForUser(user.ID, user.TenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return user without password
+	user.Password = ""
+	return user, nil
+}
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+type createSessionUseCase struct {
+	sessionRepository repository.SessionRepository
+	tenantRepository  repository.TenantRepository
+	signer            *security.TokenSigner
+}
+
+// NewCreateSessionUseCase creates a new instance of CreateSessionUseCase
+func NewCreateSessionUseCase(
+	sessionRepository repository.SessionRepository,
+	tenantRepository repository.TenantRepository,
+	signer *security.TokenSigner,
+) CreateSessionUseCase {
+	return &createSessionUseCase{
+		sessionRepository: sessionRepository,
+		tenantRepository:  tenantRepository,
+		signer:            signer,
+	}
+}
+
+// Execute starts a session for an authenticated user
+func (uc *createSessionUseCase) Execute(input CreateSessionInput) (*SessionTokens, error) {
+	// Validate input
+	if input.UserID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+	if tenant.IsDisabled() {
+		return nil, ErrTenantDisabled
+	}
+
+	// Create session
+	now := time.Now()
+	session := &model.Session{
+		ID:         uuid.New().String(),
+		UserID:     input.UserID,
+		TenantID:   input.TenantID,
+		UserAgent:  input.UserAgent,
+		IPAddress:  input.IPAddress,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(sessionTTL),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	// Save session
+	err = uc.sessionRepository.Create(session)
+	if err != nil {
+		return nil, err
+	}
+
+	// Issue refresh token
+	refreshToken, err := issueRefreshToken(uc.sessionRepository, uc.signer, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		Session:      session,
+		RefreshToken: refreshToken,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package session
+
+import "errors"
+
+var (
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or belongs to an ended session
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+	// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+
+	// ErrTenantDisabled is returned when starting a session in a tenant that is being deleted
+	ErrTenantDisabled = errors.New("tenant is disabled")
+
+	// ErrSessionNotFound is returned when a session does not exist or belongs to another user
+	ErrSessionNotFound = errors.New("session not found")
+)
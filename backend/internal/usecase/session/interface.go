@@ -0,0 +1,63 @@
+package session
+
+import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+
+// SessionTokens contains a session and the refresh token issued for it
+type SessionTokens struct {
+	Session      *model.Session
+	RefreshToken string
+}
+
+// CreateSessionUseCase defines the interface for starting a session after a successful login
+type CreateSessionUseCase interface {
+	Execute(input CreateSessionInput) (*SessionTokens, error)
+}
+
+// CreateSessionInput contains the data needed to create a session
+type CreateSessionInput struct {
+	UserID    string
+	TenantID  string
+	UserAgent string
+	IPAddress string
+}
+
+// RefreshSessionUseCase defines the interface for rotating a refresh token
+type RefreshSessionUseCase interface {
+	Execute(input RefreshSessionInput) (*RefreshSessionOutput, error)
+}
+
+// RefreshSessionInput contains the data needed to refresh a session
+type RefreshSessionInput struct {
+	RefreshToken string
+	IPAddress    string
+	TenantID     string // Optional, refresh tokens of other tenants are rejected when set
+}
+
+// RefreshSessionOutput contains the refreshed session and its current user
+type RefreshSessionOutput struct {
+	SessionTokens
+	User *model.User
+}
+
+// RevokeSessionUseCase defines the interface for revoking a session
+type RevokeSessionUseCase interface {
+	Execute(input RevokeSessionInput) error
+}
+
+// RevokeSessionInput contains the data needed to revoke a session
+type RevokeSessionInput struct {
+	ID       string
+	UserID   string
+	TenantID string
+}
+
+// RevokeUserSessionsUseCase defines the interface for signing a user out of all devices
+type RevokeUserSessionsUseCase interface {
+	Execute(input RevokeUserSessionsInput) error
+}
+
+// RevokeUserSessionsInput contains the data needed to revoke all sessions of a user
+type RevokeUserSessionsInput struct {
+	UserID   string
+	TenantID string
+}
@@ -0,0 +1,108 @@
+package session
+
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+type refreshSessionUseCase struct {
+	sessionRepository repository.SessionRepository
+	userRepository    repository.UserRepository
+	signer            *security.TokenSigner
+}
+
+// NewRefreshSessionUseCase creates a new instance of RefreshSessionUseCase
+func NewRefreshSessionUseCase(
+	sessionRepository repository.SessionRepository,
+	userRepository repository.UserRepository,
+	signer *security.TokenSigner,
+) RefreshSessionUseCase {
+	return &refreshSessionUseCase{
+		sessionRepository: sessionRepository,
+		userRepository:    userRepository,
+		signer:            signer,
+	}
+}
+
+// Execute exchanges a refresh token for a new one and extends the session.
+// Presenting a rotated token again means it was stolen, so the whole session is revoked.
+func (uc *refreshSessionUseCase) Execute(input RefreshSessionInput) (*RefreshSessionOutput, error) {
+	// Reject forged tokens before looking them up
+	if err := uc.signer.Verify(input.RefreshToken); err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Get refresh token
+	refreshToken, err := uc.sessionRepository.FindRefreshTokenByHash(security.HashToken(input.RefreshToken))
+	if err != nil || refreshToken == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if input.TenantID != "" && refreshToken.TenantID != input.TenantID {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Get session
+	session, err := uc.sessionRepository.FindByID(refreshToken.SessionID, refreshToken.TenantID)
+	if err != nil || session == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Detect reuse of a rotated token
+	if refreshToken.UsedAt != nil {
+		return nil, uc.revoke(session, ErrRefreshTokenReused)
+	}
+	if !session.IsActive() || time.Now().After(refreshToken.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Consume refresh token, losing a concurrent refresh counts as reuse
+	ok, err := uc.sessionRepository.MarkRefreshTokenUsed(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, uc.revoke(session, ErrRefreshTokenReused)
+	}
+
+	// Get user, whose role may have changed since the last refresh
+	user, err := uc.userRepository.FindByID(session.UserID, session.TenantID)
+	if err != nil || user == nil || !user.IsActive() {
+		return nil, uc.revoke(session, ErrInvalidRefreshToken)
+	}
+
+	// Extend session, unless it was revoked since it was read
+	extended, err := uc.sessionRepository.Extend(session, time.Now().Add(sessionTTL), input.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !extended {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Issue new refresh token
+	newRefreshToken, err := issueRefreshToken(uc.sessionRepository, uc.signer, session)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return user without password
+	user.Password = ""
+	return &RefreshSessionOutput{
+		SessionTokens: SessionTokens{
+			Session:      session,
+			RefreshToken: newRefreshToken,
+		},
+		User: user,
+	}, nil
+}
+
+// revoke ends the session and returns the given error
+func (uc *refreshSessionUseCase) revoke(session *model.Session, reason error) error {
+	if err := uc.sessionRepository.Revoke(session); err != nil {
+		return err
+	}
+	return reason
+}
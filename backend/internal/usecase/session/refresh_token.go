@@ -0,0 +1,46 @@
+package session
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+// RefreshTokenPurpose identifies refresh tokens when they are signed
+const RefreshTokenPurpose = "refresh_token"
+
+// AccessTokenTTL is how long an access token is valid
+const AccessTokenTTL = 15 * time.Minute
+
+// sessionTTL is how long a session stays valid without being refreshed
+const sessionTTL = 30 * 24 * time.Hour
+
+// issueRefreshToken creates a new refresh token for the session
+func issueRefreshToken(
+	sessionRepository repository.SessionRepository,
+	signer *security.TokenSigner,
+	session *model.Session,
+) (string, error) {
+	token, err := signer.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	refreshToken := &model.RefreshToken{
+		ID:        uuid.New().String(),
+		SessionID: session.ID,
+		TenantID:  session.TenantID,
+		TokenHash: security.HashToken(token),
+		ExpiresAt: session.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+	if err := sessionRepository.CreateRefreshToken(refreshToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
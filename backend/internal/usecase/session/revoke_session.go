@@ -0,0 +1,45 @@
+package session
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type revokeSessionUseCase struct {
+	sessionRepository repository.SessionRepository
+}
+
+// NewRevokeSessionUseCase creates a new instance of RevokeSessionUseCase
+func NewRevokeSessionUseCase(
+	sessionRepository repository.SessionRepository,
+) RevokeSessionUseCase {
+	return &revokeSessionUseCase{
+		sessionRepository: sessionRepository,
+	}
+}
+
+// Execute revokes one of the user's sessions, invalidating its access and refresh tokens
+func (uc *revokeSessionUseCase) Execute(input RevokeSessionInput) error {
+	// Validate input
+	if input.ID == "" {
+		return errors.New("session ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Get session
+	session, err := uc.sessionRepository.FindByID(input.ID, input.TenantID)
+	if err != nil || session == nil || session.UserID != input.UserID {
+		return ErrSessionNotFound
+	}
+
+	// Already revoked sessions stay as they are
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	// Revoke session
+	return uc.sessionRepository.Revoke(session)
+}
@@ -0,0 +1,58 @@
+package session
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type revokeUserSessionsUseCase struct {
+	sessionRepository repository.SessionRepository
+	userRepository    repository.UserRepository
+	tenantRepository  repository.TenantRepository
+}
+
+// NewRevokeUserSessionsUseCase creates a new instance of RevokeUserSessionsUseCase
+func NewRevokeUserSessionsUseCase(
+	sessionRepository repository.SessionRepository,
+	userRepository repository.UserRepository,
+	tenantRepository repository.TenantRepository,
+) RevokeUserSessionsUseCase {
+	return &revokeUserSessionsUseCase{
+		sessionRepository: sessionRepository,
+		userRepository:    userRepository,
+		tenantRepository:  tenantRepository,
+	}
+}
+
+// Execute revokes all sessions of a user, signing them out of every device
+func (uc *revokeUserSessionsUseCase) Execute(input RevokeUserSessionsInput) error {
+	// Validate input
+	if input.UserID == "" {
+		return errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Verify user exists
+	user, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	// Revoke sessions
+	return uc.sessionRepository.RevokeAllForUser(user.ID, user.TenantID)
+}
@@ -0,0 +1,120 @@
+package space
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type createSpaceUseCase struct {
+	spaceRepository  repository.SpaceRepository
+	tagRepository    repository.TagRepository
+	accessRepository repository.AccessRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewCreateSpaceUseCase creates a new instance of CreateSpaceUseCase
+func NewCreateSpaceUseCase(
+	spaceRepository repository.SpaceRepository,
+	tagRepository repository.TagRepository,
+	accessRepository repository.AccessRepository,
+	tenantRepository repository.TenantRepository,
+) CreateSpaceUseCase {
+	return &createSpaceUseCase{
+		spaceRepository:  spaceRepository,
+		tagRepository:    tagRepository,
+		accessRepository: accessRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute creates a space
+func (uc *createSpaceUseCase) Execute(input CreateSpaceInput) (*model.Space, error) {
+	// Validate input
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.Name == "" {
+		return nil, errors.New("space name is required")
+	}
+	if input.Slug != "" && !model.IsValidSlug(input.Slug) {
+		return nil, ErrInvalidSlug
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionCreate, authz.NewResource(authz.ResourceSpace, input.TenantID)); err != nil {
+		return nil, err
+	}
+
+	// Use the given slug or derive one from the name
+	slug := input.Slug
+	if slug == "" {
+		slug, err = deriveSlug(uc.spaceRepository, input.Name, input.TenantID)
+		if err != nil {
+			return nil, err
+		}
+	} else if slugTaken(uc.spaceRepository, slug, input.TenantID, "") {
+		return nil, ErrSlugTaken
+	}
+
+	// Verify default tags exist
+	tags, err := findTags(uc.tagRepository, input.DefaultTagIDs, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create space
+	now := time.Now()
+	space := &model.Space{
+		ID:          uuid.New().String(),
+		TenantID:    input.TenantID,
+		Name:        input.Name,
+		Slug:        slug,
+		Description: input.Description,
+		Icon:        input.Icon,
+		DefaultTags: tags,
+		Private:     input.Private,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// Save space
+	err = uc.spaceRepository.Create(space)
+	if err != nil {
+		return nil, err
+	}
+
+	// Let the creator manage a private space, which would otherwise be
+	// visible to nobody
+	if space.Private {
+		err = uc.accessRepository.Replace(model.AccessResourceSpace, space.ID, input.TenantID, []*model.AccessEntry{{
+			ID:            uuid.New().String(),
+			TenantID:      input.TenantID,
+			ResourceType:  model.AccessResourceSpace,
+			ResourceID:    space.ID,
+			PrincipalType: model.PrincipalUser,
+			PrincipalID:   input.Actor.UserID,
+			Level:         model.AccessLevelAdmin,
+			CreatedAt:     now,
+		}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return space, nil
+}
@@ -0,0 +1,79 @@
+package space
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/access"
+)
+
+type deleteSpaceUseCase struct {
+	spaceRepository     repository.SpaceRepository
+	knowledgeRepository repository.KnowledgeRepository
+	accessRepository    repository.AccessRepository
+	tenantRepository    repository.TenantRepository
+}
+
+// NewDeleteSpaceUseCase creates a new instance of DeleteSpaceUseCase
+func NewDeleteSpaceUseCase(
+	spaceRepository repository.SpaceRepository,
+	knowledgeRepository repository.KnowledgeRepository,
+	accessRepository repository.AccessRepository,
+	tenantRepository repository.TenantRepository,
+) DeleteSpaceUseCase {
+	return &deleteSpaceUseCase{
+		spaceRepository:     spaceRepository,
+		knowledgeRepository: knowledgeRepository,
+		accessRepository:    accessRepository,
+		tenantRepository:    tenantRepository,
+	}
+}
+
+// Execute deletes an empty space. Knowledge has to be moved out of the space
+// first, so that deleting a space never changes where knowledge is listed.
+func (uc *deleteSpaceUseCase) Execute(input DeleteSpaceInput) error {
+	// Validate input
+	if input.ID == "" {
+		return errors.New("space ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Find space
+	space, err := uc.spaceRepository.FindByID(input.ID, input.TenantID, authz.ViewerID(input.Actor))
+	if err != nil || space == nil {
+		return ErrSpaceNotFound
+	}
+
+	// Check permission
+	resource, err := access.SpaceResource(uc.accessRepository, space, input.Actor)
+	if err != nil {
+		return err
+	}
+	if err := authz.Can(input.Actor, authz.ActionDelete, resource); err != nil {
+		return err
+	}
+
+	// Check if space is empty
+	count, err := uc.knowledgeRepository.CountBySpace(space.ID, input.TenantID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrSpaceNotEmpty
+	}
+
+	// Delete space
+	return uc.spaceRepository.Delete(space.ID, input.TenantID)
+}
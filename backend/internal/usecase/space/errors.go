@@ -0,0 +1,20 @@
+package space
+
+import "errors"
+
+var (
+	// ErrSpaceNotFound is returned when the space does not exist in the tenant
+	ErrSpaceNotFound = errors.New("space not found")
+
+	// ErrInvalidSlug is returned when a slug is not lowercase words separated by hyphens
+	ErrInvalidSlug = errors.New("invalid slug")
+
+	// ErrSlugTaken is returned when another space of the tenant already has the slug
+	ErrSlugTaken = errors.New("a space with this slug already exists")
+
+	// ErrTagNotFound is returned when a default tag is not a tag of the tenant
+	ErrTagNotFound = errors.New("tag not found")
+
+	// ErrSpaceNotEmpty is returned when deleting a space that still contains knowledge
+	ErrSpaceNotEmpty = errors.New("space contains knowledge")
+)
@@ -0,0 +1,53 @@
+package space
+
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
+
+// CreateSpaceUseCase defines the interface for creating a space
+type CreateSpaceUseCase interface {
+	Execute(input CreateSpaceInput) (*model.Space, error)
+}
+
+// CreateSpaceInput contains the data needed to create a space
+type CreateSpaceInput struct {
+	TenantID      string
+	Name          string
+	Slug          string // Optional, derived from the name if empty
+	Description   string
+	Icon          string
+	DefaultTagIDs []string
+	Private       bool // Only the creator is granted access to a private space
+	Actor         authz.Subject
+}
+
+// UpdateSpaceUseCase defines the interface for updating a space
+type UpdateSpaceUseCase interface {
+	Execute(input UpdateSpaceInput) (*model.Space, error)
+}
+
+// UpdateSpaceInput contains the data needed to update a space. Fields left
+// nil are unchanged.
+type UpdateSpaceInput struct {
+	ID            string
+	TenantID      string
+	Name          *string
+	Slug          *string
+	Description   *string
+	Icon          *string
+	DefaultTagIDs []string // Replaces the default tags when not nil
+	Actor         authz.Subject
+}
+
+// DeleteSpaceUseCase defines the interface for deleting a space
+type DeleteSpaceUseCase interface {
+	Execute(input DeleteSpaceInput) error
+}
+
+// DeleteSpaceInput contains the data needed to delete a space
+type DeleteSpaceInput struct {
+	ID       string
+	TenantID string
+	Actor    authz.Subject
+}
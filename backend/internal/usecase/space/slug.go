@@ -0,0 +1,54 @@
+package space
+
+import (
+	"fmt"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// maxSlugAttempts is how many numbered variants of a derived slug are tried
+const maxSlugAttempts = 100
+
+// slugTaken reports whether another space of the tenant has the slug,
+// including private spaces the actor can't see
+func slugTaken(spaceRepository repository.SpaceRepository, slug string, tenantID string, exceptID string) bool {
+	existing, err := spaceRepository.FindBySlug(slug, tenantID, "")
+	return err == nil && existing != nil && existing.ID != exceptID
+}
+
+// deriveSlug derives a free slug from the name, numbering it when the plain
+// slug is taken
+func deriveSlug(spaceRepository repository.SpaceRepository, name string, tenantID string) (string, error) {
+	base := model.Slugify(name)
+	slug := base
+	for i := 2; slugTaken(spaceRepository, slug, tenantID, ""); i++ {
+		if i > maxSlugAttempts {
+			return "", ErrSlugTaken
+		}
+		suffix := fmt.Sprintf("-%d", i)
+		if len(base)+len(suffix) > model.MaxSlugLength {
+			base = base[:model.MaxSlugLength-len(suffix)]
+		}
+		slug = base + suffix
+	}
+	return slug, nil
+}
+
+// findTags returns the tags of the tenant with the IDs
+func findTags(tagRepository repository.TagRepository, ids []string, tenantID string) ([]model.Tag, error) {
+	tags := make([]model.Tag, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		tag, err := tagRepository.FindByID(id, tenantID)
+		if err != nil || tag == nil {
+			return nil, ErrTagNotFound
+		}
+		tags = append(tags, *tag)
+	}
+	return tags, nil
+}
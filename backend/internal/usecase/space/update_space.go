@@ -0,0 +1,108 @@
+package space
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/access"
+)
+
+type updateSpaceUseCase struct {
+	spaceRepository  repository.SpaceRepository
+	tagRepository    repository.TagRepository
+	accessRepository repository.AccessRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewUpdateSpaceUseCase creates a new instance of UpdateSpaceUseCase
+func NewUpdateSpaceUseCase(
+	spaceRepository repository.SpaceRepository,
+	tagRepository repository.TagRepository,
+	accessRepository repository.AccessRepository,
+	tenantRepository repository.TenantRepository,
+) UpdateSpaceUseCase {
+	return &updateSpaceUseCase{
+		spaceRepository:  spaceRepository,
+		tagRepository:    tagRepository,
+		accessRepository: accessRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute updates a space. Changing the default tags doesn't change the tags
+// of knowledge already in the space.
+func (uc *updateSpaceUseCase) Execute(input UpdateSpaceInput) (*model.Space, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("space ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.Name != nil && *input.Name == "" {
+		return nil, errors.New("space name is required")
+	}
+	if input.Slug != nil && !model.IsValidSlug(*input.Slug) {
+		return nil, ErrInvalidSlug
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find space
+	space, err := uc.spaceRepository.FindByID(input.ID, input.TenantID, authz.ViewerID(input.Actor))
+	if err != nil || space == nil {
+		return nil, ErrSpaceNotFound
+	}
+
+	// Check permission
+	resource, err := access.SpaceResource(uc.accessRepository, space, input.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := authz.Can(input.Actor, authz.ActionUpdate, resource); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if input.Name != nil {
+		space.Name = *input.Name
+	}
+	if input.Slug != nil && *input.Slug != space.Slug {
+		if slugTaken(uc.spaceRepository, *input.Slug, input.TenantID, space.ID) {
+			return nil, ErrSlugTaken
+		}
+		space.Slug = *input.Slug
+	}
+	if input.Description != nil {
+		space.Description = *input.Description
+	}
+	if input.Icon != nil {
+		space.Icon = *input.Icon
+	}
+	if input.DefaultTagIDs != nil {
+		tags, err := findTags(uc.tagRepository, input.DefaultTagIDs, input.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		space.DefaultTags = tags
+	}
+	space.UpdatedAt = time.Now()
+
+	// Save space
+	err = uc.spaceRepository.Update(space)
+	if err != nil {
+		return nil, err
+	}
+
+	return space, nil
+}
@@ -0,0 +1,142 @@
+package sso
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/oidc"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+type completeLoginUseCase struct {
+	userRepository     repository.UserRepository
+	roleRepository     repository.RoleRepository
+	tenantRepository   repository.TenantRepository
+	ssoStateRepository repository.SSOStateRepository
+	oidcClient         *oidc.Client
+	stateSigner        *security.TokenSigner
+}
+
+// NewCompleteLoginUseCase creates a new instance of CompleteLoginUseCase
+func NewCompleteLoginUseCase(
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+	ssoStateRepository repository.SSOStateRepository,
+	oidcClient *oidc.Client,
+	stateSigner *security.TokenSigner,
+) CompleteLoginUseCase {
+	return &completeLoginUseCase{
+		userRepository:     userRepository,
+		roleRepository:     roleRepository,
+		tenantRepository:   tenantRepository,
+		ssoStateRepository: ssoStateRepository,
+		oidcClient:         oidcClient,
+		stateSigner:        stateSigner,
+	}
+}
+
+// Execute exchanges the authorization code, verifies the ID token and returns
+// the signed-in user. Users who don't exist yet are provisioned with the role
+// mapped from their claims; existing users have their role kept in sync.
+func (uc *completeLoginUseCase) Execute(input CompleteLoginInput) (*model.User, error) {
+	// Validate input
+	if input.State == "" {
+		return nil, ErrInvalidState
+	}
+	if input.Code == "" {
+		return nil, errors.New("authorization code is required")
+	}
+	if input.RedirectURI == "" {
+		return nil, errors.New("redirect URI is required")
+	}
+
+	// Consume the state, so a callback can't be replayed
+	if err := uc.stateSigner.Verify(input.State); err != nil {
+		return nil, ErrInvalidState
+	}
+	state, err := uc.ssoStateRepository.Consume(security.HashToken(input.State))
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || time.Now().After(state.ExpiresAt) {
+		return nil, ErrInvalidState
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(state.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+	if tenant.IsDisabled() {
+		return nil, ErrTenantDisabled
+	}
+	config := tenant.Settings.SSO
+	if !config.Enabled {
+		return nil, ErrSSONotEnabled
+	}
+
+	// Exchange the code and verify the ID token
+	provider, err := uc.oidcClient.Discover(config.Issuer)
+	if err != nil {
+		return nil, errors.Join(ErrProviderFailed, err)
+	}
+	tokens, err := provider.Exchange(config.ClientID, config.ClientSecret, input.Code, input.RedirectURI, state.CodeVerifier)
+	if err != nil {
+		return nil, errors.Join(ErrProviderFailed, err)
+	}
+	claims, err := provider.VerifyIDToken(tokens.IDToken, config.ClientID, state.Nonce)
+	if err != nil {
+		return nil, errors.Join(ErrProviderFailed, err)
+	}
+
+	// Only trust emails the identity provider has verified
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+	if !config.AllowsEmail(claims.Email) {
+		return nil, ErrEmailDomainNotAllowed
+	}
+
+	// Map the provider's values to a role of the tenant
+	roles, err := uc.roleRepository.FindAll(tenant.ID)
+	if err != nil {
+		return nil, err
+	}
+	role, mapped := mapRole(roles, claimValues(claims.Raw, config.RoleClaim), config.RoleMapping, config.DefaultRole)
+
+	name := claims.Name
+	if name == "" {
+		name = strings.Split(claims.Email, "@")[0]
+	}
+
+	return signIn(uc.userRepository, tenant, claims.Email, name, role, mapped)
+}
+
+// claimValues returns the string values of a claim holding a string or a list of strings
+func claimValues(claims map[string]interface{}, claim string) []string {
+	if claim == "" {
+		return nil
+	}
+
+	switch value := claims[claim].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var values []string
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,380 @@
+package sso
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/oidc"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/oidc/oidctest"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+const (
+	testTenantID    = "tenant-1"
+	testRedirectURI = "https://hub.example.com/api/auth/sso/callback"
+)
+
+// The fakes keep what the SSO flow needs in memory. Methods it doesn't use
+// panic through the embedded nil interface.
+
+type fakeTenantRepository struct {
+	repository.TenantRepository
+	tenant *model.Tenant
+}
+
+func (r *fakeTenantRepository) FindByID(id string) (*model.Tenant, error) {
+	if r.tenant.ID != id {
+		return nil, nil
+	}
+	return r.tenant, nil
+}
+
+type fakeRoleRepository struct {
+	repository.RoleRepository
+}
+
+func (r *fakeRoleRepository) FindAll(tenantID string) ([]*model.TenantRole, error) {
+	return model.DefaultRoles(tenantID), nil
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[string]*model.User // By email
+}
+
+func (r *fakeUserRepository) FindByEmail(email string, tenantID string) (*model.User, error) {
+	if user, ok := r.users[email]; ok && user.TenantID == tenantID {
+		copied := *user
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepository) Create(user *model.User) error {
+	r.users[user.Email] = user
+	return nil
+}
+
+func (r *fakeUserRepository) Update(user *model.User) error {
+	r.users[user.Email] = user
+	return nil
+}
+
+type fakeSSOStateRepository struct {
+	mu     sync.Mutex
+	states map[string]*model.SSOState // By state hash
+}
+
+func (r *fakeSSOStateRepository) Create(state *model.SSOState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[state.StateHash] = state
+	return nil
+}
+
+func (r *fakeSSOStateRepository) Consume(stateHash string) (*model.SSOState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state := r.states[stateHash]
+	delete(r.states, stateHash)
+	return state, nil
+}
+
+// ssoTest signs users in to a tenant configured with the mock provider
+type ssoTest struct {
+	server   *oidctest.Server
+	tenant   *model.Tenant
+	users    *fakeUserRepository
+	states   *fakeSSOStateRepository
+	signer   *security.TokenSigner
+	start    StartLoginUseCase
+	complete CompleteLoginUseCase
+}
+
+func newSSOTest(t *testing.T) *ssoTest {
+	t.Helper()
+	server, err := oidctest.NewServer("knowledge-hub", "client-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+
+	tenant := &model.Tenant{ID: testTenantID, Name: "Acme"}
+	tenant.Settings.SSO = model.SSO{
+		Enabled:      true,
+		Issuer:       server.Issuer(),
+		ClientID:     server.ClientID,
+		ClientSecret: server.ClientSecret,
+		RoleClaim:    "groups",
+		RoleMapping:  map[string]string{"kh-editors": model.RoleEditor.String()},
+	}
+
+	st := &ssoTest{
+		server: server,
+		tenant: tenant,
+		users:  &fakeUserRepository{users: make(map[string]*model.User)},
+		states: &fakeSSOStateRepository{states: make(map[string]*model.SSOState)},
+		signer: security.NewTokenSigner([]byte("test-secret"), StatePurpose),
+	}
+	tenants := &fakeTenantRepository{tenant: tenant}
+	client := oidc.NewClient(server.Client())
+	st.start = NewStartLoginUseCase(tenants, st.states, client, st.signer)
+	st.complete = NewCompleteLoginUseCase(st.users, &fakeRoleRepository{}, tenants, st.states, client, st.signer)
+
+	server.SetUser(map[string]interface{}{
+		"sub":            "alice",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"name":           "Alice Example",
+		"groups":         []string{"kh-editors"},
+	})
+	return st
+}
+
+// authorize starts a login and follows it through the provider's
+// authorization endpoint, returning the parameters of the callback
+func (st *ssoTest) authorize(t *testing.T) (state string, code string) {
+	t.Helper()
+	authURL, err := st.start.Execute(StartLoginInput{TenantID: testTenantID, RedirectURI: testRedirectURI})
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorization endpoint returned %d", resp.StatusCode)
+	}
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return callback.Query().Get("state"), callback.Query().Get("code")
+}
+
+func (st *ssoTest) completeLogin(state string, code string) (*model.User, error) {
+	return st.complete.Execute(CompleteLoginInput{State: state, Code: code, RedirectURI: testRedirectURI})
+}
+
+func TestCompleteLogin(t *testing.T) {
+	st := newSSOTest(t)
+	state, code := st.authorize(t)
+
+	user, err := st.completeLogin(state, code)
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if user.Email != "alice@example.com" || user.Name != "Alice Example" || user.TenantID != testTenantID {
+		t.Errorf("user = %+v", user)
+	}
+	if user.Role != model.RoleEditor.String() {
+		t.Errorf("role = %q, want the mapped editor role", user.Role)
+	}
+	if _, ok := st.users.users["alice@example.com"]; !ok {
+		t.Error("user was not provisioned")
+	}
+}
+
+func TestCompleteLoginState(t *testing.T) {
+	t.Run("replayed", func(t *testing.T) {
+		st := newSSOTest(t)
+		state, code := st.authorize(t)
+		if _, err := st.completeLogin(state, code); err != nil {
+			t.Fatalf("CompleteLogin: %v", err)
+		}
+
+		if _, err := st.completeLogin(state, code); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("err = %v, want ErrInvalidState", err)
+		}
+	})
+
+	t.Run("of another login", func(t *testing.T) {
+		st := newSSOTest(t)
+		_, code := st.authorize(t)
+
+		// Correctly signed, but never stored for a login
+		other, err := st.signer.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := st.completeLogin(other, code); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("err = %v, want ErrInvalidState", err)
+		}
+	})
+
+	t.Run("forged", func(t *testing.T) {
+		st := newSSOTest(t)
+		state, code := st.authorize(t)
+
+		forged, err := security.NewTokenSigner([]byte("other-secret"), StatePurpose).Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range []string{forged, state + "x", ""} {
+			if _, err := st.completeLogin(s, code); !errors.Is(err, ErrInvalidState) {
+				t.Errorf("state %q: err = %v, want ErrInvalidState", s, err)
+			}
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		st := newSSOTest(t)
+		state, code := st.authorize(t)
+		for _, stored := range st.states.states {
+			stored.ExpiresAt = time.Now().Add(-time.Second)
+		}
+
+		if _, err := st.completeLogin(state, code); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("err = %v, want ErrInvalidState", err)
+		}
+	})
+}
+
+func TestCompleteLoginPKCE(t *testing.T) {
+	st := newSSOTest(t)
+	state, code := st.authorize(t)
+
+	// The provider only releases tokens for the verifier of the challenge it was sent
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stored := range st.states.states {
+		stored.CodeVerifier = verifier
+	}
+
+	if _, err := st.completeLogin(state, code); !errors.Is(err, ErrProviderFailed) {
+		t.Errorf("err = %v, want ErrProviderFailed", err)
+	}
+}
+
+func TestCompleteLoginRedirectURI(t *testing.T) {
+	st := newSSOTest(t)
+	state, code := st.authorize(t)
+
+	_, err := st.complete.Execute(CompleteLoginInput{State: state, Code: code, RedirectURI: "https://evil.example.com/callback"})
+	if !errors.Is(err, ErrProviderFailed) {
+		t.Errorf("err = %v, want ErrProviderFailed", err)
+	}
+}
+
+func TestCompleteLoginIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+	}{
+		{"nonce mismatch", map[string]interface{}{"nonce": "nonce-of-another-login"}},
+		{"missing nonce", map[string]interface{}{"nonce": nil}},
+		{"expired", map[string]interface{}{
+			"iat": time.Now().Add(-time.Hour).Unix(),
+			"exp": time.Now().Add(-10 * time.Minute).Unix(),
+		}},
+		{"issued in the future", map[string]interface{}{"iat": time.Now().Add(time.Hour).Unix()}},
+		{"other audience", map[string]interface{}{"aud": "other-client"}},
+		{"other issuer", map[string]interface{}{"iss": "https://idp.evil.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSSOTest(t)
+			claims := map[string]interface{}{
+				"sub":            "alice",
+				"email":          "alice@example.com",
+				"email_verified": true,
+			}
+			for k, v := range tt.claims {
+				claims[k] = v
+			}
+			st.server.SetUser(claims)
+			state, code := st.authorize(t)
+
+			_, err := st.completeLogin(state, code)
+			if !errors.Is(err, ErrProviderFailed) || !errors.Is(err, oidc.ErrInvalidIDToken) {
+				t.Errorf("err = %v, want ErrProviderFailed and ErrInvalidIDToken", err)
+			}
+			if len(st.users.users) != 0 {
+				t.Error("user was provisioned")
+			}
+		})
+	}
+}
+
+func TestCompleteLoginEmail(t *testing.T) {
+	tests := []struct {
+		name           string
+		email          string
+		verified       bool
+		allowedDomains []string
+		want           error
+	}{
+		{"allowed domain", "alice@example.com", true, []string{"example.com"}, nil},
+		{"allowed domain in other case", "alice@EXAMPLE.com", true, []string{"example.com"}, nil},
+		{"other domain", "mallory@evil.example", true, []string{"example.com"}, ErrEmailDomainNotAllowed},
+		{"subdomain", "mallory@evil.example.com", true, []string{"example.com"}, ErrEmailDomainNotAllowed},
+		{"domain as suffix of the local part", "example.com@evil.example", true, []string{"example.com"}, ErrEmailDomainNotAllowed},
+		{"no restriction", "bob@anywhere.example", true, nil, nil},
+		{"not verified", "alice@example.com", false, nil, ErrEmailNotVerified},
+		{"missing", "", true, nil, ErrEmailNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSSOTest(t)
+			st.tenant.Settings.SSO.AllowedDomains = tt.allowedDomains
+			st.server.SetUser(map[string]interface{}{
+				"sub":            "user",
+				"email":          tt.email,
+				"email_verified": tt.verified,
+			})
+			state, code := st.authorize(t)
+
+			_, err := st.completeLogin(state, code)
+			if tt.want == nil && err != nil {
+				t.Errorf("CompleteLogin: %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteLoginDisabledTenant(t *testing.T) {
+	t.Run("disabled during the login", func(t *testing.T) {
+		st := newSSOTest(t)
+		state, code := st.authorize(t)
+		disabledAt := time.Now()
+		st.tenant.DisabledAt = &disabledAt
+
+		if _, err := st.completeLogin(state, code); !errors.Is(err, ErrTenantDisabled) {
+			t.Errorf("err = %v, want ErrTenantDisabled", err)
+		}
+		if _, ok := st.users.users["alice@example.com"]; ok {
+			t.Error("user was provisioned in a disabled tenant")
+		}
+	})
+
+	t.Run("disabled before the login", func(t *testing.T) {
+		st := newSSOTest(t)
+		disabledAt := time.Now()
+		st.tenant.DisabledAt = &disabledAt
+
+		_, err := st.start.Execute(StartLoginInput{TenantID: testTenantID, RedirectURI: testRedirectURI})
+		if !errors.Is(err, ErrTenantDisabled) {
+			t.Errorf("err = %v, want ErrTenantDisabled", err)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package sso
+
+import "errors"
+
+var (
+	// ErrSSONotEnabled is returned when the tenant has not configured SSO
+	ErrSSONotEnabled = errors.New("SSO is not enabled for this tenant")
+
+	// ErrInvalidState is returned when the callback's state is unknown, used or expired
+	ErrInvalidState = errors.New("invalid or expired SSO state")
+
+	// ErrProviderFailed is returned when the identity provider can't be reached or rejects the login
+	ErrProviderFailed = errors.New("identity provider login failed")
+
+	// ErrEmailNotVerified is returned when the identity provider does not vouch for the user's email
+	ErrEmailNotVerified = errors.New("email is missing or not verified by the identity provider")
+
+	// ErrEmailDomainNotAllowed is returned when the email domain is not on the tenant's SSO allowlist
+	ErrEmailDomainNotAllowed = errors.New("email domain is not allowed")
+
+	// ErrTenantDisabled is returned when signing in to a tenant that is being deleted
+	ErrTenantDisabled = errors.New("tenant is disabled")
+
+	// ErrUserDeactivated is returned when a deactivated user signs in through SSO
+	ErrUserDeactivated = errors.New("user is deactivated")
+
+	// ErrInvalidAssertion is returned when a SAML response is malformed, unsigned or not meant for us
+	ErrInvalidAssertion = errors.New("invalid SAML assertion")
+)
@@ -0,0 +1,50 @@
+package sso
+
+import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+
+// StatePurpose is the purpose of the signed state sent to the identity provider
+const StatePurpose = "sso_state"
+
+// StartLoginUseCase defines the interface for sending a user to the tenant's identity provider
+type StartLoginUseCase interface {
+	Execute(input StartLoginInput) (string, error)
+}
+
+// StartLoginInput contains the data needed to start an SSO login
+type StartLoginInput struct {
+	TenantID    string
+	RedirectURI string // Callback the identity provider returns to
+}
+
+// CompleteLoginUseCase defines the interface for finishing an SSO login
+type CompleteLoginUseCase interface {
+	Execute(input CompleteLoginInput) (*model.User, error)
+}
+
+// CompleteLoginInput contains the parameters of the identity provider's callback
+type CompleteLoginInput struct {
+	State       string
+	Code        string
+	RedirectURI string // Must match the one the login was started with
+}
+
+// GetSAMLMetadataUseCase defines the interface for getting the service provider metadata of a tenant
+type GetSAMLMetadataUseCase interface {
+	Execute(tenantID string) ([]byte, error)
+}
+
+// StartSAMLLoginUseCase defines the interface for sending a user to the tenant's SAML identity provider
+type StartSAMLLoginUseCase interface {
+	Execute(tenantID string) (string, error)
+}
+
+// CompleteSAMLLoginUseCase defines the interface for finishing a SAML login
+type CompleteSAMLLoginUseCase interface {
+	Execute(input CompleteSAMLLoginInput) (*model.User, error)
+}
+
+// CompleteSAMLLoginInput contains the response posted to the assertion consumer service
+type CompleteSAMLLoginInput struct {
+	TenantID     string
+	SAMLResponse string
+}
@@ -0,0 +1,247 @@
+package sso
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/saml"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+// serviceProvider returns the SAML service provider of a tenant. Each tenant
+// has its own entity ID and assertion consumer service under baseURL.
+func serviceProvider(baseURL string, tenantID string) *saml.ServiceProvider {
+	return &saml.ServiceProvider{
+		EntityID: baseURL + "/" + tenantID + "/metadata",
+		ACSURL:   baseURL + "/" + tenantID + "/acs",
+	}
+}
+
+// identityProvider returns the configured SAML identity provider of a tenant
+func identityProvider(config model.SAML) (*saml.IdentityProvider, error) {
+	certificate, err := saml.ParseCertificate(config.IdPCertificate)
+	if err != nil {
+		return nil, err
+	}
+	return &saml.IdentityProvider{
+		EntityID:    config.IdPEntityID,
+		SSOURL:      config.IdPSSOURL,
+		Certificate: certificate,
+	}, nil
+}
+
+type getSAMLMetadataUseCase struct {
+	tenantRepository repository.TenantRepository
+	baseURL          string
+}
+
+// NewGetSAMLMetadataUseCase creates a new instance of GetSAMLMetadataUseCase
+func NewGetSAMLMetadataUseCase(tenantRepository repository.TenantRepository, baseURL string) GetSAMLMetadataUseCase {
+	return &getSAMLMetadataUseCase{
+		tenantRepository: tenantRepository,
+		baseURL:          baseURL,
+	}
+}
+
+// Execute returns the service provider metadata. It is available before SAML
+// is enabled, so that it can be registered with the identity provider first.
+func (uc *getSAMLMetadataUseCase) Execute(tenantID string) ([]byte, error) {
+	// Validate input
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	return serviceProvider(uc.baseURL, tenant.ID).Metadata()
+}
+
+type startSAMLLoginUseCase struct {
+	tenantRepository   repository.TenantRepository
+	ssoStateRepository repository.SSOStateRepository
+	baseURL            string
+}
+
+// NewStartSAMLLoginUseCase creates a new instance of StartSAMLLoginUseCase
+func NewStartSAMLLoginUseCase(
+	tenantRepository repository.TenantRepository,
+	ssoStateRepository repository.SSOStateRepository,
+	baseURL string,
+) StartSAMLLoginUseCase {
+	return &startSAMLLoginUseCase{
+		tenantRepository:   tenantRepository,
+		ssoStateRepository: ssoStateRepository,
+		baseURL:            baseURL,
+	}
+}
+
+// Execute remembers a new authentication request and returns the identity
+// provider URL carrying it
+func (uc *startSAMLLoginUseCase) Execute(tenantID string) (string, error) {
+	// Validate input
+	if tenantID == "" {
+		return "", errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(tenantID)
+	if err != nil {
+		return "", err
+	}
+	if tenant == nil {
+		return "", errors.New("tenant not found")
+	}
+	if tenant.IsDisabled() {
+		return "", ErrTenantDisabled
+	}
+	config := tenant.Settings.SAML
+	if !config.Enabled {
+		return "", ErrSSONotEnabled
+	}
+	idp, err := identityProvider(config)
+	if err != nil {
+		return "", err
+	}
+
+	// Save the request, so that only responses to it are accepted
+	requestID, err := saml.NewRequestID()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	err = uc.ssoStateRepository.Create(&model.SSOState{
+		ID:        uuid.New().String(),
+		TenantID:  tenant.ID,
+		StateHash: security.HashToken(requestID),
+		ExpiresAt: now.Add(stateTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return serviceProvider(uc.baseURL, tenant.ID).AuthnRequestURL(idp, requestID, "", now)
+}
+
+type completeSAMLLoginUseCase struct {
+	userRepository     repository.UserRepository
+	roleRepository     repository.RoleRepository
+	tenantRepository   repository.TenantRepository
+	ssoStateRepository repository.SSOStateRepository
+	baseURL            string
+}
+
+// NewCompleteSAMLLoginUseCase creates a new instance of CompleteSAMLLoginUseCase
+func NewCompleteSAMLLoginUseCase(
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+	ssoStateRepository repository.SSOStateRepository,
+	baseURL string,
+) CompleteSAMLLoginUseCase {
+	return &completeSAMLLoginUseCase{
+		userRepository:     userRepository,
+		roleRepository:     roleRepository,
+		tenantRepository:   tenantRepository,
+		ssoStateRepository: ssoStateRepository,
+		baseURL:            baseURL,
+	}
+}
+
+// Execute validates the signed response and returns the signed-in user,
+// provisioning them on their first login with the role mapped from their
+// attributes. Only responses to a request started by this application are
+// accepted, each at most once.
+func (uc *completeSAMLLoginUseCase) Execute(input CompleteSAMLLoginInput) (*model.User, error) {
+	// Validate input
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.SAMLResponse == "" {
+		return nil, ErrInvalidAssertion
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+	if tenant.IsDisabled() {
+		return nil, ErrTenantDisabled
+	}
+	config := tenant.Settings.SAML
+	if !config.Enabled {
+		return nil, ErrSSONotEnabled
+	}
+	idp, err := identityProvider(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the response
+	assertion, err := serviceProvider(uc.baseURL, tenant.ID).ParseResponse(idp, input.SAMLResponse, time.Now())
+	if err != nil {
+		return nil, errors.Join(ErrInvalidAssertion, err)
+	}
+
+	// Consume the request it answers, so a response can't be replayed
+	if assertion.InResponseTo == "" {
+		return nil, ErrInvalidState
+	}
+	state, err := uc.ssoStateRepository.Consume(security.HashToken(assertion.InResponseTo))
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || state.TenantID != tenant.ID || time.Now().After(state.ExpiresAt) {
+		return nil, ErrInvalidState
+	}
+
+	// Identify the user by email
+	email := assertion.NameID
+	if config.EmailAttribute != "" {
+		email = firstValue(assertion.Attributes[config.EmailAttribute])
+	}
+	if !strings.Contains(email, "@") {
+		return nil, ErrEmailNotVerified
+	}
+	if !config.AllowsEmail(email) {
+		return nil, ErrEmailDomainNotAllowed
+	}
+
+	name := firstValue(assertion.Attributes[config.NameAttribute])
+	if config.NameAttribute == "" || name == "" {
+		name = strings.Split(email, "@")[0]
+	}
+
+	// Map the provider's values to a role of the tenant
+	roles, err := uc.roleRepository.FindAll(tenant.ID)
+	if err != nil {
+		return nil, err
+	}
+	role, mapped := mapRole(roles, assertion.Attributes[config.RoleAttribute], config.RoleMapping, config.DefaultRole)
+
+	return signIn(uc.userRepository, tenant, email, name, role, mapped)
+}
+
+// firstValue returns the first value of an attribute, or an empty string
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
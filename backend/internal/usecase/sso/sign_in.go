@@ -0,0 +1,88 @@
+package sso
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// signIn returns the user with the email vouched for by the identity
+// provider. Users who don't exist yet are provisioned with the given role;
+// existing users have their role kept in sync when the provider mapped one.
+func signIn(userRepository repository.UserRepository, tenant *model.Tenant, email string, name string, role model.Role, mapped bool) (*model.User, error) {
+	// Find existing user
+	user, err := userRepository.FindByEmail(email, tenant.ID)
+	if err != nil || user == nil {
+		return provision(userRepository, tenant, email, name, role)
+	}
+
+	// Deactivated users keep their content but cannot log in
+	if !user.IsActive() {
+		return nil, ErrUserDeactivated
+	}
+
+	// Keep the role in sync with the identity provider when it has a say
+	if mapped && user.Role != role.String() {
+		user.Role = role.String()
+		user.UpdatedAt = time.Now()
+		if err := userRepository.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return user without password
+	user.Password = ""
+	return user, nil
+}
+
+// provision creates a user for a first SSO login. The user has no password,
+// so they can only sign in through SSO until they set one.
+func provision(userRepository repository.UserRepository, tenant *model.Tenant, email string, name string, role model.Role) (*model.User, error) {
+	now := time.Now()
+	user := &model.User{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Email:     email,
+		TenantID:  tenant.ID,
+		Role:      role.String(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Save user
+	err := userRepository.Create(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// mapRole returns the tenant role mapped from the identity provider's values
+// that grants the most permissions. It reports false and returns the default
+// role when no value is mapped to a role of the tenant.
+func mapRole(roles []*model.TenantRole, values []string, mapping map[string]string, defaultRole string) (model.Role, bool) {
+	byName := make(map[string]*model.TenantRole, len(roles))
+	for _, role := range roles {
+		byName[role.Name] = role
+	}
+
+	var best *model.TenantRole
+	for _, value := range values {
+		role, ok := byName[mapping[value]]
+		if ok && (best == nil || len(role.Permissions) > len(best.Permissions)) {
+			best = role
+		}
+	}
+	if best != nil {
+		return model.Role(best.Name), true
+	}
+
+	if _, ok := byName[defaultRole]; ok {
+		return model.Role(defaultRole), false
+	}
+	return model.RoleViewer, false
+}
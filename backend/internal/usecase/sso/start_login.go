@@ -0,0 +1,109 @@
+package sso
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/oidc"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+// stateTTL is how long a user has to sign in at the identity provider
+const stateTTL = 10 * time.Minute
+
+type startLoginUseCase struct {
+	tenantRepository   repository.TenantRepository
+	ssoStateRepository repository.SSOStateRepository
+	oidcClient         *oidc.Client
+	stateSigner        *security.TokenSigner
+}
+
+// NewStartLoginUseCase creates a new instance of StartLoginUseCase
+func NewStartLoginUseCase(
+	tenantRepository repository.TenantRepository,
+	ssoStateRepository repository.SSOStateRepository,
+	oidcClient *oidc.Client,
+	stateSigner *security.TokenSigner,
+) StartLoginUseCase {
+	return &startLoginUseCase{
+		tenantRepository:   tenantRepository,
+		ssoStateRepository: ssoStateRepository,
+		oidcClient:         oidcClient,
+		stateSigner:        stateSigner,
+	}
+}
+
+// Execute stores a single-use state with the nonce and PKCE verifier of the
+// login and returns the identity provider's authorization URL
+func (uc *startLoginUseCase) Execute(input StartLoginInput) (string, error) {
+	// Validate input
+	if input.TenantID == "" {
+		return "", errors.New("tenant ID is required")
+	}
+	if input.RedirectURI == "" {
+		return "", errors.New("redirect URI is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return "", err
+	}
+	if tenant == nil {
+		return "", errors.New("tenant not found")
+	}
+	if tenant.IsDisabled() {
+		return "", ErrTenantDisabled
+	}
+	config := tenant.Settings.SSO
+	if !config.Enabled {
+		return "", ErrSSONotEnabled
+	}
+
+	// Discover the identity provider
+	provider, err := uc.oidcClient.Discover(config.Issuer)
+	if err != nil {
+		return "", errors.Join(ErrProviderFailed, err)
+	}
+
+	// Generate state, nonce and PKCE verifier
+	state, err := uc.stateSigner.Generate()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return "", err
+	}
+	codeVerifier, err := oidc.RandomString()
+	if err != nil {
+		return "", err
+	}
+
+	// Save state
+	now := time.Now()
+	err = uc.ssoStateRepository.Create(&model.SSOState{
+		ID:           uuid.New().String(),
+		TenantID:     tenant.ID,
+		StateHash:    security.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(stateTTL),
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return provider.AuthCodeURL(oidc.AuthRequest{
+		ClientID:      config.ClientID,
+		RedirectURI:   input.RedirectURI,
+		State:         state,
+		Nonce:         nonce,
+		CodeChallenge: oidc.CodeChallenge(codeVerifier),
+	}), nil
+}
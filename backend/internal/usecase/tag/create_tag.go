@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
@@ -45,6 +46,11 @@ func (uc *createTagUseCase) Execute(input CreateTagInput) (*model.Tag, error) {
 		return nil, errors.New("tenant not found")
 	}
 
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionCreate, authz.NewResource(authz.ResourceTag, input.TenantID)); err != nil {
+		return nil, err
+	}
+
 	// Create tag
 	now := time.Now()
 	tag := &model.Tag{
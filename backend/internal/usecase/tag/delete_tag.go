@@ -3,6 +3,7 @@ package tag
 import (
 	"errors"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
 
@@ -50,6 +51,11 @@ func (uc *deleteTagUseCase) Execute(input DeleteTagInput) error {
 		return errors.New("tag not found")
 	}
 
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionDelete, authz.TagResource(tag)); err != nil {
+		return err
+	}
+
 	// Delete tag
 	return uc.tagRepository.Delete(input.ID, input.TenantID)
 }
\ No newline at end of file
@@ -1,6 +1,9 @@
 package tag
 
-import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
 
 // CreateTagUseCase defines the interface for creating a tag
 type CreateTagUseCase interface {
@@ -11,6 +14,7 @@ type CreateTagUseCase interface {
 type CreateTagInput struct {
 	Name     string
 	TenantID string
+	Actor    authz.Subject
 }
 
 // UpdateTagUseCase defines the interface for updating a tag
@@ -23,6 +27,7 @@ type UpdateTagInput struct {
 	ID       string
 	Name     string
 	TenantID string
+	Actor    authz.Subject
 }
 
 // DeleteTagUseCase defines the interface for deleting a tag
@@ -34,4 +39,5 @@ type DeleteTagUseCase interface {
 type DeleteTagInput struct {
 	ID       string
 	TenantID string
+	Actor    authz.Subject
 }
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
@@ -52,6 +53,11 @@ func (uc *updateTagUseCase) Execute(input UpdateTagInput) (*model.Tag, error) {
 		return nil, errors.New("tag not found")
 	}
 
+	// Check permission
+	if err := authz.Can(input.Actor, authz.ActionUpdate, authz.TagResource(tag)); err != nil {
+		return nil, err
+	}
+
 	// Update tag fields if provided
 	if input.Name != "" {
 		tag.Name = input.Name
@@ -0,0 +1,77 @@
+package tenant
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type cancelTenantDeletionUseCase struct {
+	tenantRepository         repository.TenantRepository
+	tenantDeletionRepository repository.TenantDeletionRepository
+}
+
+// NewCancelTenantDeletionUseCase creates a new instance of CancelTenantDeletionUseCase
+func NewCancelTenantDeletionUseCase(
+	tenantRepository repository.TenantRepository,
+	tenantDeletionRepository repository.TenantDeletionRepository,
+) CancelTenantDeletionUseCase {
+	return &cancelTenantDeletionUseCase{
+		tenantRepository:         tenantRepository,
+		tenantDeletionRepository: tenantDeletionRepository,
+	}
+}
+
+// Execute cancels the scheduled deletion of a tenant and enables it again
+func (uc *cancelTenantDeletionUseCase) Execute(tenantID string) (*model.TenantDeletion, error) {
+	// Validate input
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Find tenant
+	tenant, err := uc.tenantRepository.FindByID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find deletion
+	deletion, err := uc.tenantDeletionRepository.FindLatestByTenantID(tenant.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoDeletionScheduled
+		}
+		return nil, err
+	}
+	if !deletion.IsPending() {
+		return nil, ErrNoDeletionScheduled
+	}
+	if !deletion.CanCancel(time.Now()) {
+		return nil, ErrDeletionNotCancelable
+	}
+
+	// Cancel deletion, unless the purge has just started
+	canceled, err := uc.tenantDeletionRepository.Cancel(deletion)
+	if err != nil {
+		return nil, err
+	}
+	if !canceled {
+		return nil, ErrDeletionNotCancelable
+	}
+
+	// Enable tenant
+	tenant.DisabledAt = nil
+	tenant.UpdatedAt = time.Now()
+	if err := uc.tenantRepository.Update(tenant); err != nil {
+		return nil, err
+	}
+
+	return deletion, nil
+}
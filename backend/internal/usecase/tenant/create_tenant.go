@@ -2,26 +2,54 @@ package tenant
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
 )
 
+// adminInvitationTTL is how long the invitation of a new tenant's first admin
+// can be accepted
+const adminInvitationTTL = 7 * 24 * time.Hour
+
 type createTenantUseCase struct {
-	tenantRepository repository.TenantRepository
+	tenantRepository     repository.TenantRepository
+	roleRepository       repository.RoleRepository
+	invitationRepository repository.InvitationRepository
+	mailer               mail.Mailer
+	signer               *security.TokenSigner
+	acceptURL            string
 }
 
-// NewCreateTenantUseCase creates a new instance of CreateTenantUseCase
-func NewCreateTenantUseCase(tenantRepository repository.TenantRepository) CreateTenantUseCase {
+// NewCreateTenantUseCase creates a new instance of CreateTenantUseCase. The
+// signer must sign invitation tokens, which are appended to acceptURL in the
+// invitation of the tenant's first admin.
+func NewCreateTenantUseCase(
+	tenantRepository repository.TenantRepository,
+	roleRepository repository.RoleRepository,
+	invitationRepository repository.InvitationRepository,
+	mailer mail.Mailer,
+	signer *security.TokenSigner,
+	acceptURL string,
+) CreateTenantUseCase {
 	return &createTenantUseCase{
-		tenantRepository: tenantRepository,
+		tenantRepository:     tenantRepository,
+		roleRepository:       roleRepository,
+		invitationRepository: invitationRepository,
+		mailer:               mailer,
+		signer:               signer,
+		acceptURL:            acceptURL,
 	}
 }
 
-// Execute creates a new tenant
+// Execute creates a new tenant and invites its first admin. Tenants get
+// their admin only this way, never through self-registration.
 func (uc *createTenantUseCase) Execute(input CreateTenantInput) (*model.Tenant, error) {
 	// Validate input
 	if input.Name == "" {
@@ -30,6 +58,23 @@ func (uc *createTenantUseCase) Execute(input CreateTenantInput) (*model.Tenant,
 	if input.Domain == "" {
 		return nil, errors.New("tenant domain is required")
 	}
+	if input.AdminEmail == "" {
+		return nil, errors.New("admin email is required")
+	}
+	if input.CreatedBy == "" {
+		return nil, errors.New("creator ID is required")
+	}
+
+	// New tenants only accept invited users unless configured otherwise
+	if input.Registration.Mode == "" {
+		input.Registration.Mode = model.RegistrationModeInviteOnly
+	}
+
+	// New tenants reject weak passwords unless configured otherwise
+	passwordPolicy := model.DefaultPasswordPolicy()
+	if input.PasswordPolicy != nil {
+		passwordPolicy = *input.PasswordPolicy
+	}
 
 	// Check if domain already exists
 	existingTenant, err := uc.tenantRepository.FindByDomain(input.Domain)
@@ -50,6 +95,9 @@ func (uc *createTenantUseCase) Execute(input CreateTenantInput) (*model.Tenant,
 				Tags:     true,
 				Ratings:  true,
 			},
+			Registration:   input.Registration,
+			Security:       input.Security,
+			PasswordPolicy: passwordPolicy,
 		},
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -61,5 +109,63 @@ func (uc *createTenantUseCase) Execute(input CreateTenantInput) (*model.Tenant,
 		return nil, err
 	}
 
+	// Seed the default roles
+	for _, role := range model.DefaultRoles(tenant.ID) {
+		role.ID = uuid.New().String()
+		if err := uc.roleRepository.Create(role); err != nil {
+			return nil, err
+		}
+	}
+
+	// Invite the first admin
+	if err := uc.inviteAdmin(tenant, input.AdminEmail, input.CreatedBy); err != nil {
+		return nil, err
+	}
+
 	return tenant, nil
+}
+
+// inviteAdmin creates the invitation of the tenant's first admin and emails
+// its token
+func (uc *createTenantUseCase) inviteAdmin(tenant *model.Tenant, email string, invitedBy string) error {
+	// Generate token
+	token, err := uc.signer.Generate()
+	if err != nil {
+		return err
+	}
+
+	// Save invitation
+	now := time.Now()
+	invitation := &model.Invitation{
+		ID:        uuid.New().String(),
+		TenantID:  tenant.ID,
+		Email:     email,
+		Role:      model.RoleAdmin.String(),
+		TokenHash: security.HashToken(token),
+		InvitedBy: invitedBy,
+		Status:    model.InvitationStatusPending,
+		ExpiresAt: now.Add(adminInvitationTTL),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.invitationRepository.Create(invitation); err != nil {
+		return err
+	}
+
+	// Send invitation email
+	err = uc.mailer.Send(mail.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("%s has been created", tenant.Name),
+		Body: fmt.Sprintf(
+			"%s has been created on Knowledge Hub, and you have been invited to be its admin.\n\nAccept the invitation here:\n%s?token=%s\n\nThis invitation expires on %s.\n",
+			tenant.Name,
+			uc.acceptURL,
+			url.QueryEscape(token),
+			invitation.ExpiresAt.Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send admin invitation email: %w", err)
+	}
+	return nil
 }
\ No newline at end of file
@@ -2,37 +2,107 @@ package tenant
 
 import (
 	"errors"
+	"time"
 
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
 
+// deletionGracePeriod is how long the deletion of a tenant can be canceled
+// before its data is purged
+const deletionGracePeriod = 7 * 24 * time.Hour
+
 type deleteTenantUseCase struct {
-	tenantRepository repository.TenantRepository
+	tenantRepository         repository.TenantRepository
+	tenantDeletionRepository repository.TenantDeletionRepository
+	userRepository           repository.UserRepository
+	sessionRepository        repository.SessionRepository
 }
 
 // NewDeleteTenantUseCase creates a new instance of DeleteTenantUseCase
-func NewDeleteTenantUseCase(tenantRepository repository.TenantRepository) DeleteTenantUseCase {
+func NewDeleteTenantUseCase(
+	tenantRepository repository.TenantRepository,
+	tenantDeletionRepository repository.TenantDeletionRepository,
+	userRepository repository.UserRepository,
+	sessionRepository repository.SessionRepository,
+) DeleteTenantUseCase {
 	return &deleteTenantUseCase{
-		tenantRepository: tenantRepository,
+		tenantRepository:         tenantRepository,
+		tenantDeletionRepository: tenantDeletionRepository,
+		userRepository:           userRepository,
+		sessionRepository:        sessionRepository,
 	}
 }
 
-// Execute deletes a tenant
-func (uc *deleteTenantUseCase) Execute(id string) error {
+// Execute disables a tenant and schedules its data to be purged once the
+// grace period has passed
+func (uc *deleteTenantUseCase) Execute(input DeleteTenantInput) (*model.TenantDeletion, error) {
 	// Validate input
-	if id == "" {
-		return errors.New("tenant ID is required")
+	if input.ID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.RequestedBy == "" {
+		return nil, errors.New("requester ID is required")
 	}
 
 	// Find tenant
-	tenant, err := uc.tenantRepository.FindByID(id)
+	tenant, err := uc.tenantRepository.FindByID(input.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if tenant == nil {
-		return errors.New("tenant not found")
+		return nil, errors.New("tenant not found")
+	}
+
+	// Check for a deletion under way
+	latest, err := uc.tenantDeletionRepository.FindLatestByTenantID(tenant.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if latest != nil && latest.IsPending() {
+		return nil, ErrDeletionAlreadyScheduled
+	}
+
+	// Find requester, who receives the final report
+	requester, err := uc.userRepository.FindByID(input.RequestedBy, tenant.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Delete tenant
-	return uc.tenantRepository.Delete(id)
-}
\ No newline at end of file
+	// Save deletion
+	now := time.Now()
+	deletion := &model.TenantDeletion{
+		ID:          uuid.New().String(),
+		TenantID:    tenant.ID,
+		TenantName:  tenant.Name,
+		RequestedBy: requester.ID,
+		NotifyEmail: requester.Email,
+		Status:      model.TenantDeletionStatusScheduled,
+		PurgeAfter:  now.Add(deletionGracePeriod),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.tenantDeletionRepository.Create(deletion); err != nil {
+		return nil, err
+	}
+
+	// Disable tenant, dropping the deletion if the tenant stays enabled
+	tenant.DisabledAt = &now
+	tenant.UpdatedAt = now
+	if err := uc.tenantRepository.Update(tenant); err != nil {
+		if _, cancelErr := uc.tenantDeletionRepository.Cancel(deletion); cancelErr != nil {
+			return nil, cancelErr
+		}
+		return nil, err
+	}
+
+	// Sign out everyone else
+	if err := uc.sessionRepository.RevokeAllForTenant(tenant.ID, input.SessionID); err != nil {
+		return nil, err
+	}
+
+	return deletion, nil
+}
@@ -0,0 +1,17 @@
+package tenant
+
+import "errors"
+
+var (
+	// ErrInvalidSSOConfiguration is returned when enabling SSO without a usable identity provider configuration
+	ErrInvalidSSOConfiguration = errors.New("invalid SSO configuration")
+
+	// ErrDeletionAlreadyScheduled is returned when deleting a tenant whose deletion is already under way
+	ErrDeletionAlreadyScheduled = errors.New("tenant deletion is already scheduled")
+
+	// ErrNoDeletionScheduled is returned when canceling the deletion of a tenant that isn't being deleted
+	ErrNoDeletionScheduled = errors.New("no tenant deletion is scheduled")
+
+	// ErrDeletionNotCancelable is returned when canceling a deletion after its grace period has passed
+	ErrDeletionNotCancelable = errors.New("tenant deletion can no longer be canceled")
+)
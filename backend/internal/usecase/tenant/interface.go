@@ -1,6 +1,10 @@
 package tenant
 
-import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+import (
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
 
 // CreateTenantUseCase defines the interface for creating a tenant
 type CreateTenantUseCase interface {
@@ -9,9 +13,14 @@ type CreateTenantUseCase interface {
 
 // CreateTenantInput contains the data needed to create a tenant
 type CreateTenantInput struct {
-	Name   string
-	Domain string
-	Theme  model.Theme
+	Name           string
+	Domain         string
+	AdminEmail     string // Invited to be the tenant's first admin
+	CreatedBy      string // User creating the tenant
+	Theme          model.Theme
+	Registration   model.Registration // Optional, defaults to invite-only
+	Security       model.Security
+	PasswordPolicy *model.PasswordPolicy // Optional, defaults to model.DefaultPasswordPolicy
 }
 
 // UpdateTenantSettingsUseCase defines the interface for updating tenant settings
@@ -21,11 +30,34 @@ type UpdateTenantSettingsUseCase interface {
 
 // UpdateTenantSettingsInput contains the data needed to update tenant settings
 type UpdateTenantSettingsInput struct {
-	ID       string
-	Settings model.Settings
+	ID             string
+	Settings       model.Settings        // Registration is left unchanged when its mode is empty
+	Security       *model.Security       // Optional, left unchanged when nil
+	PasswordPolicy *model.PasswordPolicy // Optional, left unchanged when nil
+	SSO            *model.SSO            // Optional, left unchanged when nil. An empty client secret keeps the current one.
+	SAML           *model.SAML           // Optional, left unchanged when nil
 }
 
-// DeleteTenantUseCase defines the interface for deleting a tenant
+// DeleteTenantUseCase defines the interface for deleting a tenant. The tenant
+// is disabled right away and its data is purged after a grace period.
 type DeleteTenantUseCase interface {
-	Execute(id string) error
-}
\ No newline at end of file
+	Execute(input DeleteTenantInput) (*model.TenantDeletion, error)
+}
+
+// DeleteTenantInput contains the data needed to delete a tenant
+type DeleteTenantInput struct {
+	ID          string
+	RequestedBy string // User deleting the tenant, who receives the final report
+	SessionID   string // Session of the user, kept so that they can still cancel the deletion
+}
+
+// CancelTenantDeletionUseCase defines the interface for canceling the deletion of a tenant
+type CancelTenantDeletionUseCase interface {
+	Execute(tenantID string) (*model.TenantDeletion, error)
+}
+
+// PurgeTenantsUseCase defines the interface for purging the data of tenants
+// whose deletion grace period has passed
+type PurgeTenantsUseCase interface {
+	Execute(now time.Time) ([]*model.TenantDeletion, error)
+}
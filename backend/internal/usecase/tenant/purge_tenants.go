@@ -0,0 +1,163 @@
+package tenant
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
+)
+
+// purgeBatchSize is how many rows are deleted per statement, so that purging
+// a large tenant doesn't hold locks on a table for long
+const purgeBatchSize = 500
+
+// purgeLease is how long a worker may purge a tenant before another worker
+// can take over. It is extended after every batch.
+const purgeLease = 5 * time.Minute
+
+type purgeTenantsUseCase struct {
+	tenantRepository         repository.TenantRepository
+	tenantDeletionRepository repository.TenantDeletionRepository
+	mailer                   mail.Mailer
+}
+
+// NewPurgeTenantsUseCase creates a new instance of PurgeTenantsUseCase
+func NewPurgeTenantsUseCase(
+	tenantRepository repository.TenantRepository,
+	tenantDeletionRepository repository.TenantDeletionRepository,
+	mailer mail.Mailer,
+) PurgeTenantsUseCase {
+	return &purgeTenantsUseCase{
+		tenantRepository:         tenantRepository,
+		tenantDeletionRepository: tenantDeletionRepository,
+		mailer:                   mailer,
+	}
+}
+
+// Execute purges the data of the tenants whose grace period has passed and
+// returns the deletions it worked on. A deletion that fails is marked as
+// failed and retried on a later run, resuming where it stopped.
+func (uc *purgeTenantsUseCase) Execute(now time.Time) ([]*model.TenantDeletion, error) {
+	// Find deletions due
+	deletions, err := uc.tenantDeletionRepository.FindDue(now)
+	if err != nil {
+		return nil, err
+	}
+
+	var processed []*model.TenantDeletion
+	for _, deletion := range deletions {
+		// Claim deletion, skipping it if another worker got it first
+		claimed, err := uc.tenantDeletionRepository.Claim(deletion, time.Now().Add(purgeLease))
+		if err != nil {
+			return processed, err
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := uc.purge(deletion); err != nil {
+			deletion.Status = model.TenantDeletionStatusFailed
+			deletion.Error = err.Error()
+			deletion.LockedUntil = nil
+			deletion.UpdatedAt = time.Now()
+			if updateErr := uc.tenantDeletionRepository.Update(deletion); updateErr != nil {
+				return processed, updateErr
+			}
+		}
+		processed = append(processed, deletion)
+	}
+
+	return processed, nil
+}
+
+// purge deletes the tenant's data in batches, then the tenant, and sends the
+// final report
+func (uc *purgeTenantsUseCase) purge(deletion *model.TenantDeletion) error {
+	if deletion.StartedAt == nil {
+		startedAt := time.Now()
+		deletion.StartedAt = &startedAt
+	}
+	if deletion.Report == nil {
+		deletion.Report = map[string]int64{}
+	}
+	deletion.Error = ""
+
+	// Delete data in batches, recording progress after each one
+	for {
+		table, deleted, err := uc.tenantRepository.PurgeBatch(deletion.TenantID, purgeBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to purge %s: %w", table, err)
+		}
+		if table == "" {
+			break
+		}
+		deletion.Report[table] += deleted
+
+		lockedUntil := time.Now().Add(purgeLease)
+		deletion.LockedUntil = &lockedUntil
+		deletion.UpdatedAt = time.Now()
+		if err := uc.tenantDeletionRepository.Update(deletion); err != nil {
+			return err
+		}
+	}
+
+	// Delete tenant
+	if err := uc.tenantRepository.Delete(deletion.TenantID); err != nil {
+		return fmt.Errorf("failed to delete tenant: %w", err)
+	}
+	deletion.Report["tenants"] = 1
+
+	// Save deletion
+	completedAt := time.Now()
+	deletion.Status = model.TenantDeletionStatusCompleted
+	deletion.CompletedAt = &completedAt
+	deletion.LockedUntil = nil
+	deletion.UpdatedAt = completedAt
+	if err := uc.tenantDeletionRepository.Update(deletion); err != nil {
+		return err
+	}
+
+	// Send final report. The tenant is gone at this point, so a failure to
+	// send it is only recorded on the deletion.
+	if deletion.NotifyEmail != "" {
+		err := uc.mailer.Send(mail.Message{
+			To:      deletion.NotifyEmail,
+			Subject: fmt.Sprintf("%s has been deleted", deletion.TenantName),
+			Body:    deletionReport(deletion),
+		})
+		if err != nil {
+			deletion.Error = fmt.Sprintf("failed to send report: %v", err)
+			return uc.tenantDeletionRepository.Update(deletion)
+		}
+	}
+
+	return nil
+}
+
+// deletionReport returns the body of the final report email
+func deletionReport(deletion *model.TenantDeletion) string {
+	tables := make([]string, 0, len(deletion.Report))
+	for table := range deletion.Report {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s and all of its data have been deleted from Knowledge Hub.\n\n", deletion.TenantName)
+	fmt.Fprintf(&body, "Requested: %s\n", deletion.CreatedAt.Format(time.RFC1123))
+	if deletion.CompletedAt != nil {
+		fmt.Fprintf(&body, "Completed: %s\n", deletion.CompletedAt.Format(time.RFC1123))
+	}
+	body.WriteString("\nDeleted records:\n")
+	var total int64
+	for _, table := range tables {
+		fmt.Fprintf(&body, "  %s: %d\n", table, deletion.Report[table])
+		total += deletion.Report[table]
+	}
+	fmt.Fprintf(&body, "  total: %d\n", total)
+	return body.String()
+}
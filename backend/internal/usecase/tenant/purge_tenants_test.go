@@ -0,0 +1,416 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
+)
+
+const testTenantID = "tenant-1"
+
+// The fakes keep what deleting and purging tenants need in memory. Methods
+// they don't use panic through the embedded nil interface.
+
+type fakeTenantRepository struct {
+	repository.TenantRepository
+	tenant  *model.Tenant
+	deleted bool
+
+	tables  []string         // Tables in the order they are purged
+	rows    map[string]int64 // Rows left per table
+	failOn  string           // Table whose next batch fails
+	batches []int            // Limit of every batch
+}
+
+func (r *fakeTenantRepository) FindByID(id string) (*model.Tenant, error) {
+	if r.deleted || r.tenant.ID != id {
+		return nil, nil
+	}
+	copied := *r.tenant
+	return &copied, nil
+}
+
+func (r *fakeTenantRepository) Update(tenant *model.Tenant) error {
+	copied := *tenant
+	r.tenant = &copied
+	return nil
+}
+
+func (r *fakeTenantRepository) PurgeBatch(id string, limit int) (string, int64, error) {
+	r.batches = append(r.batches, limit)
+	for _, table := range r.tables {
+		if r.rows[table] == 0 {
+			continue
+		}
+		if table == r.failOn {
+			r.failOn = ""
+			return table, 0, errors.New("deadlock detected")
+		}
+		deleted := min(r.rows[table], int64(limit))
+		r.rows[table] -= deleted
+		return table, deleted, nil
+	}
+	return "", 0, nil
+}
+
+func (r *fakeTenantRepository) Delete(id string) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeTenantDeletionRepository struct {
+	repository.TenantDeletionRepository
+	deletions map[string]*model.TenantDeletion // By ID
+	saved     []model.TenantDeletion           // Every update, in order
+}
+
+func (r *fakeTenantDeletionRepository) Create(deletion *model.TenantDeletion) error {
+	copied := *deletion
+	r.deletions[deletion.ID] = &copied
+	return nil
+}
+
+func (r *fakeTenantDeletionRepository) FindLatestByTenantID(tenantID string) (*model.TenantDeletion, error) {
+	var latest *model.TenantDeletion
+	for _, deletion := range r.deletions {
+		if deletion.TenantID == tenantID && (latest == nil || deletion.CreatedAt.After(latest.CreatedAt)) {
+			latest = deletion
+		}
+	}
+	if latest == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *latest
+	return &copied, nil
+}
+
+// FindDue selects deletions like the query of the repository does
+func (r *fakeTenantDeletionRepository) FindDue(now time.Time) ([]*model.TenantDeletion, error) {
+	var due []*model.TenantDeletion
+	for _, deletion := range r.deletions {
+		if !deletion.IsPending() || deletion.PurgeAfter.After(now) {
+			continue
+		}
+		if deletion.LockedUntil != nil && deletion.LockedUntil.After(now) {
+			continue
+		}
+		copied := *deletion
+		due = append(due, &copied)
+	}
+	return due, nil
+}
+
+func (r *fakeTenantDeletionRepository) Claim(deletion *model.TenantDeletion, lockedUntil time.Time) (bool, error) {
+	stored := r.deletions[deletion.ID]
+	if stored.Status == model.TenantDeletionStatusCanceled || stored.Status == model.TenantDeletionStatusCompleted {
+		return false, nil
+	}
+	if stored.LockedUntil != nil && stored.LockedUntil.After(time.Now()) {
+		return false, nil
+	}
+	stored.Status = model.TenantDeletionStatusPurging
+	stored.LockedUntil = &lockedUntil
+	deletion.Status = model.TenantDeletionStatusPurging
+	deletion.LockedUntil = &lockedUntil
+	return true, nil
+}
+
+func (r *fakeTenantDeletionRepository) Cancel(deletion *model.TenantDeletion) (bool, error) {
+	stored := r.deletions[deletion.ID]
+	if stored.Status != model.TenantDeletionStatusScheduled {
+		return false, nil
+	}
+	now := time.Now()
+	stored.Status = model.TenantDeletionStatusCanceled
+	stored.CanceledAt = &now
+	deletion.Status = model.TenantDeletionStatusCanceled
+	deletion.CanceledAt = &now
+	return true, nil
+}
+
+func (r *fakeTenantDeletionRepository) Update(deletion *model.TenantDeletion) error {
+	copied := *deletion
+	copied.Report = make(map[string]int64, len(deletion.Report))
+	for table, deleted := range deletion.Report {
+		copied.Report[table] = deleted
+	}
+	r.deletions[deletion.ID] = &copied
+	r.saved = append(r.saved, copied)
+	return nil
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+}
+
+func (r *fakeUserRepository) FindByID(id string, tenantID string) (*model.User, error) {
+	return &model.User{ID: id, TenantID: tenantID, Email: "owner@example.com"}, nil
+}
+
+type fakeSessionRepository struct {
+	repository.SessionRepository
+	revokedExcept *string
+}
+
+func (r *fakeSessionRepository) RevokeAllForTenant(tenantID string, exceptSessionID string) error {
+	r.revokedExcept = &exceptSessionID
+	return nil
+}
+
+type fakeMailer struct {
+	messages []mail.Message
+}
+
+func (m *fakeMailer) Send(msg mail.Message) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// deletionTest wires the deletion use cases to the fakes for a tenant with
+// data in two tables
+type deletionTest struct {
+	tenants   *fakeTenantRepository
+	deletions *fakeTenantDeletionRepository
+	sessions  *fakeSessionRepository
+	mailer    *fakeMailer
+
+	delete DeleteTenantUseCase
+	cancel CancelTenantDeletionUseCase
+	purge  PurgeTenantsUseCase
+}
+
+func newDeletionTest() *deletionTest {
+	dt := &deletionTest{
+		tenants: &fakeTenantRepository{
+			tenant: &model.Tenant{ID: testTenantID, Name: "Acme"},
+			tables: []string{"comments", "knowledge"},
+			rows:   map[string]int64{"comments": 30, "knowledge": 2*purgeBatchSize + 200},
+		},
+		deletions: &fakeTenantDeletionRepository{deletions: map[string]*model.TenantDeletion{}},
+		sessions:  &fakeSessionRepository{},
+		mailer:    &fakeMailer{},
+	}
+	dt.delete = NewDeleteTenantUseCase(dt.tenants, dt.deletions, &fakeUserRepository{}, dt.sessions)
+	dt.cancel = NewCancelTenantDeletionUseCase(dt.tenants, dt.deletions)
+	dt.purge = NewPurgeTenantsUseCase(dt.tenants, dt.deletions, dt.mailer)
+	return dt
+}
+
+// scheduleDeletion deletes the tenant and returns the scheduled deletion
+func (dt *deletionTest) scheduleDeletion(t *testing.T) *model.TenantDeletion {
+	t.Helper()
+	deletion, err := dt.delete.Execute(DeleteTenantInput{ID: testTenantID, RequestedBy: "user-1", SessionID: "session-1"})
+	if err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	return deletion
+}
+
+func TestDeleteTenantSchedulesPurgeAfterGracePeriod(t *testing.T) {
+	dt := newDeletionTest()
+
+	before := time.Now()
+	deletion := dt.scheduleDeletion(t)
+	after := time.Now()
+
+	if deletion.Status != model.TenantDeletionStatusScheduled {
+		t.Errorf("status = %q, want %q", deletion.Status, model.TenantDeletionStatusScheduled)
+	}
+	if deletion.PurgeAfter.Before(before.Add(deletionGracePeriod)) || deletion.PurgeAfter.After(after.Add(deletionGracePeriod)) {
+		t.Errorf("purge after = %v, want %v after the request", deletion.PurgeAfter, deletionGracePeriod)
+	}
+	if deletion.NotifyEmail != "owner@example.com" {
+		t.Errorf("notify email = %q, want the requester's", deletion.NotifyEmail)
+	}
+	if !dt.tenants.tenant.IsDisabled() {
+		t.Error("tenant is still enabled")
+	}
+	if dt.sessions.revokedExcept == nil || *dt.sessions.revokedExcept != "session-1" {
+		t.Error("sessions weren't revoked except the requester's")
+	}
+
+	if _, err := dt.delete.Execute(DeleteTenantInput{ID: testTenantID, RequestedBy: "user-1"}); !errors.Is(err, ErrDeletionAlreadyScheduled) {
+		t.Errorf("deleting again: got error %v, want %v", err, ErrDeletionAlreadyScheduled)
+	}
+
+	// Nothing is purged during the grace period
+	processed, err := dt.purge.Execute(deletion.PurgeAfter.Add(-time.Second))
+	if err != nil {
+		t.Fatalf("PurgeTenants during the grace period: %v", err)
+	}
+	if len(processed) != 0 || len(dt.tenants.batches) != 0 {
+		t.Fatalf("purged %d deletions in %d batches during the grace period", len(processed), len(dt.tenants.batches))
+	}
+
+	// Everything is once it has passed
+	processed, err = dt.purge.Execute(deletion.PurgeAfter)
+	if err != nil {
+		t.Fatalf("PurgeTenants after the grace period: %v", err)
+	}
+	if len(processed) != 1 || processed[0].Status != model.TenantDeletionStatusCompleted {
+		t.Fatalf("processed = %+v, want the deletion completed", processed)
+	}
+	if !dt.tenants.deleted {
+		t.Error("tenant wasn't deleted")
+	}
+}
+
+func TestCancelTenantDeletion(t *testing.T) {
+	t.Run("during the grace period", func(t *testing.T) {
+		dt := newDeletionTest()
+		deletion := dt.scheduleDeletion(t)
+
+		canceled, err := dt.cancel.Execute(testTenantID)
+		if err != nil {
+			t.Fatalf("CancelTenantDeletion: %v", err)
+		}
+		if canceled.Status != model.TenantDeletionStatusCanceled || canceled.CanceledAt == nil {
+			t.Errorf("deletion = %+v, want it canceled", canceled)
+		}
+		if dt.tenants.tenant.IsDisabled() {
+			t.Error("tenant is still disabled")
+		}
+
+		// The canceled deletion is never purged
+		processed, err := dt.purge.Execute(deletion.PurgeAfter.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("PurgeTenants: %v", err)
+		}
+		if len(processed) != 0 || dt.tenants.deleted {
+			t.Errorf("purged a canceled deletion: %+v", processed)
+		}
+	})
+
+	t.Run("after the grace period", func(t *testing.T) {
+		dt := newDeletionTest()
+		deletion := dt.scheduleDeletion(t)
+		dt.deletions.deletions[deletion.ID].PurgeAfter = time.Now().Add(-time.Second)
+
+		if _, err := dt.cancel.Execute(testTenantID); !errors.Is(err, ErrDeletionNotCancelable) {
+			t.Errorf("got error %v, want %v", err, ErrDeletionNotCancelable)
+		}
+		if !dt.tenants.tenant.IsDisabled() {
+			t.Error("tenant was enabled again")
+		}
+	})
+
+	t.Run("once purging began", func(t *testing.T) {
+		dt := newDeletionTest()
+		deletion := dt.scheduleDeletion(t)
+		dt.deletions.deletions[deletion.ID].Status = model.TenantDeletionStatusPurging
+
+		if _, err := dt.cancel.Execute(testTenantID); !errors.Is(err, ErrDeletionNotCancelable) {
+			t.Errorf("got error %v, want %v", err, ErrDeletionNotCancelable)
+		}
+	})
+}
+
+func TestPurgeTenantsDeletesInBatches(t *testing.T) {
+	dt := newDeletionTest()
+	deletion := dt.scheduleDeletion(t)
+
+	processed, err := dt.purge.Execute(deletion.PurgeAfter)
+	if err != nil {
+		t.Fatalf("PurgeTenants: %v", err)
+	}
+	if len(processed) != 1 {
+		t.Fatalf("processed %d deletions, want 1", len(processed))
+	}
+
+	// One batch of comments, three of knowledge and the final empty one
+	if len(dt.tenants.batches) != 5 {
+		t.Errorf("purged in %d batches, want 5", len(dt.tenants.batches))
+	}
+	for i, limit := range dt.tenants.batches {
+		if limit != purgeBatchSize {
+			t.Errorf("batch %d: limit = %d, want %d", i, limit, purgeBatchSize)
+		}
+	}
+
+	// Progress is saved with the lease extended after every batch
+	if len(dt.deletions.saved) != 5 {
+		t.Fatalf("saved the deletion %d times, want after each of the 4 batches and once completed", len(dt.deletions.saved))
+	}
+	for i, saved := range dt.deletions.saved[:4] {
+		if saved.Status != model.TenantDeletionStatusPurging || saved.LockedUntil == nil || saved.StartedAt == nil {
+			t.Errorf("update %d: %+v, want it purging, leased and started", i, saved)
+		}
+	}
+	if got := dt.deletions.saved[3].Report["knowledge"]; got != 2*purgeBatchSize+200 {
+		t.Errorf("knowledge purged before the tenant = %d, want %d", got, 2*purgeBatchSize+200)
+	}
+
+	completed := dt.deletions.saved[4]
+	if completed.Status != model.TenantDeletionStatusCompleted || completed.CompletedAt == nil || completed.LockedUntil != nil {
+		t.Errorf("final update: %+v, want it completed and released", completed)
+	}
+	want := map[string]int64{"comments": 30, "knowledge": 2*purgeBatchSize + 200, "tenants": 1}
+	for table, deleted := range want {
+		if completed.Report[table] != deleted {
+			t.Errorf("report[%s] = %d, want %d", table, completed.Report[table], deleted)
+		}
+	}
+
+	// The requester gets the report
+	if len(dt.mailer.messages) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(dt.mailer.messages))
+	}
+	msg := dt.mailer.messages[0]
+	if msg.To != "owner@example.com" || !strings.Contains(msg.Body, fmt.Sprintf("knowledge: %d\n", 2*purgeBatchSize+200)) || !strings.Contains(msg.Body, fmt.Sprintf("total: %d\n", 2*purgeBatchSize+231)) {
+		t.Errorf("report email = %+v", msg)
+	}
+}
+
+func TestPurgeTenantsResumesAfterFailure(t *testing.T) {
+	dt := newDeletionTest()
+	deletion := dt.scheduleDeletion(t)
+	dt.tenants.tables = []string{"knowledge", "comments"}
+	dt.tenants.failOn = "comments"
+
+	// The first run stops at the failing table
+	processed, err := dt.purge.Execute(deletion.PurgeAfter)
+	if err != nil {
+		t.Fatalf("PurgeTenants: %v", err)
+	}
+	if len(processed) != 1 {
+		t.Fatalf("processed %d deletions, want 1", len(processed))
+	}
+	failed := processed[0]
+	if failed.Status != model.TenantDeletionStatusFailed || !strings.Contains(failed.Error, "comments") {
+		t.Errorf("deletion = %+v, want it failed on comments", failed)
+	}
+	if failed.LockedUntil != nil {
+		t.Error("failed deletion is still leased")
+	}
+	if dt.tenants.deleted || len(dt.mailer.messages) != 0 {
+		t.Error("tenant was deleted despite the failure")
+	}
+	startedAt := *failed.StartedAt
+
+	// The worker's next run picks it up again and finishes the remaining tables
+	processed, err = dt.purge.Execute(deletion.PurgeAfter.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("PurgeTenants retry: %v", err)
+	}
+	if len(processed) != 1 || processed[0].Status != model.TenantDeletionStatusCompleted {
+		t.Fatalf("processed = %+v, want the deletion completed", processed)
+	}
+	resumed := processed[0]
+	if !resumed.StartedAt.Equal(startedAt) {
+		t.Errorf("started at = %v, want the first run's %v", resumed.StartedAt, startedAt)
+	}
+	if resumed.Error != "" {
+		t.Errorf("error = %q, want it cleared", resumed.Error)
+	}
+	if resumed.Report["knowledge"] != 2*purgeBatchSize+200 || resumed.Report["comments"] != 30 {
+		t.Errorf("report = %v, want every row counted once", resumed.Report)
+	}
+}
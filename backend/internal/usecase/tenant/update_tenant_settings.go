@@ -2,20 +2,27 @@ package tenant
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/saml"
 )
 
 type updateTenantSettingsUseCase struct {
 	tenantRepository repository.TenantRepository
+	roleRepository   repository.RoleRepository
 }
 
 // NewUpdateTenantSettingsUseCase creates a new instance of UpdateTenantSettingsUseCase
-func NewUpdateTenantSettingsUseCase(tenantRepository repository.TenantRepository) UpdateTenantSettingsUseCase {
+func NewUpdateTenantSettingsUseCase(
+	tenantRepository repository.TenantRepository,
+	roleRepository repository.RoleRepository,
+) UpdateTenantSettingsUseCase {
 	return &updateTenantSettingsUseCase{
 		tenantRepository: tenantRepository,
+		roleRepository:   roleRepository,
 	}
 }
 
@@ -35,6 +42,59 @@ func (uc *updateTenantSettingsUseCase) Execute(input UpdateTenantSettingsInput)
 		return nil, errors.New("tenant not found")
 	}
 
+	// Keep the current registration policy unless a new one is provided
+	if input.Settings.Registration.Mode == "" {
+		input.Settings.Registration = tenant.Settings.Registration
+	}
+
+	// Keep the current security settings unless new ones are provided
+	if input.Security != nil {
+		input.Settings.Security = *input.Security
+	} else {
+		input.Settings.Security = tenant.Settings.Security
+	}
+
+	// Keep the current password policy unless a new one is provided
+	if input.PasswordPolicy != nil {
+		input.Settings.PasswordPolicy = *input.PasswordPolicy
+	} else {
+		input.Settings.PasswordPolicy = tenant.Settings.PasswordPolicy
+	}
+
+	// Keep the current SSO configuration unless a new one is provided
+	if input.SSO != nil {
+		if input.SSO.Enabled && (input.SSO.Issuer == "" || input.SSO.ClientID == "") {
+			return nil, fmt.Errorf("%w: issuer and client ID are required", ErrInvalidSSOConfiguration)
+		}
+		if err := uc.validateRoles(tenant.ID, input.SSO.RoleMapping, input.SSO.DefaultRole); err != nil {
+			return nil, err
+		}
+		if input.SSO.ClientSecret == "" {
+			input.SSO.ClientSecret = tenant.Settings.SSO.ClientSecret
+		}
+		input.Settings.SSO = *input.SSO
+	} else {
+		input.Settings.SSO = tenant.Settings.SSO
+	}
+
+	// Keep the current SAML configuration unless a new one is provided
+	if input.SAML != nil {
+		if input.SAML.Enabled {
+			if input.SAML.IdPEntityID == "" || input.SAML.IdPSSOURL == "" {
+				return nil, fmt.Errorf("%w: identity provider entity ID and SSO URL are required", ErrInvalidSSOConfiguration)
+			}
+			if _, err := saml.ParseCertificate(input.SAML.IdPCertificate); err != nil {
+				return nil, fmt.Errorf("%w: invalid identity provider certificate", ErrInvalidSSOConfiguration)
+			}
+		}
+		if err := uc.validateRoles(tenant.ID, input.SAML.RoleMapping, input.SAML.DefaultRole); err != nil {
+			return nil, err
+		}
+		input.Settings.SAML = *input.SAML
+	} else {
+		input.Settings.SAML = tenant.Settings.SAML
+	}
+
 	// Update settings
 	tenant.Settings = input.Settings
 	tenant.UpdatedAt = time.Now()
@@ -46,4 +106,27 @@ func (uc *updateTenantSettingsUseCase) Execute(input UpdateTenantSettingsInput)
 	}
 
 	return tenant, nil
-}
\ No newline at end of file
+}
+
+// validateRoles checks that the identity provider's values are mapped to
+// roles of the tenant
+func (uc *updateTenantSettingsUseCase) validateRoles(tenantID string, mapping map[string]string, defaultRole string) error {
+	roles, err := uc.roleRepository.FindAll(tenantID)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		names[role.Name] = true
+	}
+
+	if defaultRole != "" && !names[defaultRole] {
+		return fmt.Errorf("%w: unknown default role %q", ErrInvalidSSOConfiguration, defaultRole)
+	}
+	for value, role := range mapping {
+		if !names[role] {
+			return fmt.Errorf("%w: %q is mapped to unknown role %q", ErrInvalidSSOConfiguration, value, role)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package twofactor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/totp"
+)
+
+type activateUseCase struct {
+	userRepository         repository.UserRepository
+	recoveryCodeRepository repository.RecoveryCodeRepository
+	challengeVerifier      challengeVerifier
+	clock                  totp.Clock
+}
+
+// NewActivateUseCase creates a new instance of ActivateUseCase
+func NewActivateUseCase(
+	userRepository repository.UserRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	loginThrottleRepository repository.LoginThrottleRepository,
+	tenantRepository repository.TenantRepository,
+	clock totp.Clock,
+) ActivateUseCase {
+	return &activateUseCase{
+		userRepository:         userRepository,
+		recoveryCodeRepository: recoveryCodeRepository,
+		challengeVerifier: challengeVerifier{
+			loginThrottleRepository: loginThrottleRepository,
+			tenantRepository:        tenantRepository,
+		},
+		clock: clock,
+	}
+}
+
+// Execute enables two-factor authentication once the user proves their
+// authenticator works, and returns a fresh set of recovery codes
+func (uc *activateUseCase) Execute(input ActivateInput) ([]string, error) {
+	// Validate input
+	if input.UserID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrAlreadyEnabled
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, ErrNotEnrolled
+	}
+
+	// Enable two-factor authentication with the first valid code
+	err = uc.challengeVerifier.verify(user, input.ChallengeID, input.IPAddress, uc.clock(), func() error {
+		return verifyTOTP(uc.userRepository, user, input.Code, uc.clock())
+	})
+	if err != nil {
+		return nil, err
+	}
+	user.TwoFactorEnabled = true
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	// Issue recovery codes
+	return generateRecoveryCodes(uc.recoveryCodeRepository, user)
+}
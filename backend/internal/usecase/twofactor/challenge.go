@@ -0,0 +1,52 @@
+package twofactor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/usecase/user"
+)
+
+// challengeVerifier applies the login limits to codes entered for a login challenge
+type challengeVerifier struct {
+	loginThrottleRepository repository.LoginThrottleRepository
+	tenantRepository        repository.TenantRepository
+}
+
+// verify runs check for the second step of a login. Wrong codes count against
+// the challenge as well as the account and the IP address, and a challenge
+// can't be used anymore after too many of them. Without a challenge ID, check
+// is run as is.
+func (v challengeVerifier) verify(u *model.User, challengeID string, ipAddress string, now time.Time, check func() error) error {
+	if challengeID == "" {
+		return check()
+	}
+
+	// Verify tenant exists
+	tenant, err := v.tenantRepository.FindByID(u.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Check for earlier failures before looking at the code
+	throttler := user.NewLoginThrottler(v.loginThrottleRepository, tenant, u.Email, ipAddress)
+	if err := throttler.CheckChallenge(challengeID, now); err != nil {
+		return err
+	}
+
+	// Check code
+	if err := check(); err != nil {
+		if errors.Is(err, ErrInvalidCode) {
+			if err := throttler.RecordChallengeFailure(challengeID, now); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+	return nil
+}
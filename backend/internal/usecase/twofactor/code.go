@@ -0,0 +1,113 @@
+package twofactor
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/totp"
+)
+
+// Issuer is shown next to the account in authenticator apps
+const Issuer = "Knowledge Hub"
+
+// recoveryCodeCount is the number of recovery codes issued at once
+const recoveryCodeCount = 10
+
+// recoveryCodeEncoding renders recovery codes without ambiguous padding
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// verifyTOTP checks a TOTP code and records its time step so that it cannot be used twice
+func verifyTOTP(userRepository repository.UserRepository, user *model.User, code string, now time.Time) error {
+	step, ok := totp.Validate(user.TwoFactorSecret, code, now)
+	if !ok || step <= user.TwoFactorLastStep {
+		return ErrInvalidCode
+	}
+
+	used, err := userRepository.UseTwoFactorStep(user, step)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidCode
+	}
+	return nil
+}
+
+// verifyRecoveryCode redeems one of the user's unused recovery codes
+func verifyRecoveryCode(recoveryCodeRepository repository.RecoveryCodeRepository, user *model.User, code string) error {
+	recoveryCode, err := recoveryCodeRepository.FindByHash(user.ID, user.TenantID, security.HashToken(normalizeRecoveryCode(code)))
+	if err != nil || recoveryCode == nil {
+		return ErrInvalidCode
+	}
+
+	ok, err := recoveryCodeRepository.MarkUsed(recoveryCode)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidCode
+	}
+	return nil
+}
+
+// verifyCode accepts either a TOTP code or a recovery code
+func verifyCode(
+	userRepository repository.UserRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	user *model.User,
+	code string,
+	recoveryCode string,
+	now time.Time,
+) error {
+	if code != "" {
+		return verifyTOTP(userRepository, user, code, now)
+	}
+	if recoveryCode != "" {
+		return verifyRecoveryCode(recoveryCodeRepository, user, recoveryCode)
+	}
+	return ErrInvalidCode
+}
+
+// generateRecoveryCodes replaces the user's recovery codes and returns them in plain text.
+// Only their hashes are stored, so they can't be shown again.
+func generateRecoveryCodes(recoveryCodeRepository repository.RecoveryCodeRepository, user *model.User) ([]string, error) {
+	now := time.Now()
+	plain := make([]string, 0, recoveryCodeCount)
+	codes := make([]*model.RecoveryCode, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		random := make([]byte, 10)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(random))
+		code := encoded[:8] + "-" + encoded[8:]
+
+		plain = append(plain, code)
+		codes = append(codes, &model.RecoveryCode{
+			ID:        uuid.New().String(),
+			UserID:    user.ID,
+			TenantID:  user.TenantID,
+			CodeHash:  security.HashToken(normalizeRecoveryCode(code)),
+			CreatedAt: now,
+		})
+	}
+
+	if err := recoveryCodeRepository.ReplaceForUser(user.ID, user.TenantID, codes); err != nil {
+		return nil, err
+	}
+	return plain, nil
+}
+
+// normalizeRecoveryCode ignores case, spaces and dashes so that codes can be typed loosely
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
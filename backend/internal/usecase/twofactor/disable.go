@@ -0,0 +1,87 @@
+package twofactor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/totp"
+)
+
+type disableUseCase struct {
+	userRepository         repository.UserRepository
+	recoveryCodeRepository repository.RecoveryCodeRepository
+	tenantRepository       repository.TenantRepository
+	clock                  totp.Clock
+}
+
+// NewDisableUseCase creates a new instance of DisableUseCase
+func NewDisableUseCase(
+	userRepository repository.UserRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	tenantRepository repository.TenantRepository,
+	clock totp.Clock,
+) DisableUseCase {
+	return &disableUseCase{
+		userRepository:         userRepository,
+		recoveryCodeRepository: recoveryCodeRepository,
+		tenantRepository:       tenantRepository,
+		clock:                  clock,
+	}
+}
+
+// Execute turns off two-factor authentication after checking a current code
+func (uc *disableUseCase) Execute(input DisableInput) error {
+	// Validate input
+	if input.UserID == "" {
+		return errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+	if tenant.Settings.Security.RequireTwoFactor {
+		return ErrRequiredByTenant
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if !user.TwoFactorEnabled {
+		return ErrNotEnabled
+	}
+
+	// Check code
+	err = verifyCode(uc.userRepository, uc.recoveryCodeRepository, user, input.Code, input.RecoveryCode, uc.clock())
+	if err != nil {
+		return err
+	}
+
+	// Disable two-factor authentication
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+	user.TwoFactorLastStep = 0
+	user.UpdatedAt = time.Now()
+
+	// Save user
+	err = uc.userRepository.Update(user)
+	if err != nil {
+		return err
+	}
+
+	// Recovery codes are useless without a second factor
+	return uc.recoveryCodeRepository.DeleteForUser(user.ID, user.TenantID)
+}
@@ -0,0 +1,64 @@
+package twofactor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/totp"
+)
+
+type enrollUseCase struct {
+	userRepository repository.UserRepository
+}
+
+// NewEnrollUseCase creates a new instance of EnrollUseCase
+func NewEnrollUseCase(userRepository repository.UserRepository) EnrollUseCase {
+	return &enrollUseCase{
+		userRepository: userRepository,
+	}
+}
+
+// Execute generates a new secret for the user. Two-factor authentication is
+// only enabled once a code generated from it has been activated.
+func (uc *enrollUseCase) Execute(input EnrollInput) (*Enrollment, error) {
+	// Validate input
+	if input.UserID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrAlreadyEnabled
+	}
+
+	// Generate secret
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TwoFactorSecret = secret
+	user.TwoFactorLastStep = 0
+	user.UpdatedAt = time.Now()
+
+	// Save user
+	err = uc.userRepository.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Enrollment{
+		Secret: secret,
+		URI:    totp.URI(Issuer, user.Email, secret),
+	}, nil
+}
@@ -0,0 +1,20 @@
+package twofactor
+
+import "errors"
+
+var (
+	// ErrAlreadyEnabled is returned when enrolling a user who already uses two-factor authentication
+	ErrAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+	// ErrNotEnrolled is returned when activating without a pending enrollment
+	ErrNotEnrolled = errors.New("two-factor enrollment has not been started")
+
+	// ErrNotEnabled is returned when an operation requires two-factor authentication to be enabled
+	ErrNotEnabled = errors.New("two-factor authentication is not enabled")
+
+	// ErrInvalidCode is returned when a TOTP or recovery code is wrong or was already used
+	ErrInvalidCode = errors.New("invalid two-factor code")
+
+	// ErrRequiredByTenant is returned when disabling two-factor authentication in a tenant that requires it
+	ErrRequiredByTenant = errors.New("two-factor authentication is required by the tenant")
+)
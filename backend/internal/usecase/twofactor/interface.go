@@ -0,0 +1,75 @@
+package twofactor
+
+import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+
+// Enrollment contains what an authenticator app needs to generate codes
+type Enrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// EnrollUseCase defines the interface for starting a TOTP enrollment
+type EnrollUseCase interface {
+	Execute(input EnrollInput) (*Enrollment, error)
+}
+
+// EnrollInput contains the data needed to start an enrollment
+type EnrollInput struct {
+	UserID   string
+	TenantID string
+}
+
+// ActivateUseCase defines the interface for confirming an enrollment with a first code
+type ActivateUseCase interface {
+	Execute(input ActivateInput) ([]string, error)
+}
+
+// ActivateInput contains the data needed to activate two-factor authentication
+type ActivateInput struct {
+	UserID      string
+	TenantID    string
+	Code        string
+	ChallengeID string // Set when activating during login
+	IPAddress   string
+}
+
+// VerifyUseCase defines the interface for checking the second factor of a login
+type VerifyUseCase interface {
+	Execute(input VerifyInput) (*model.User, error)
+}
+
+// VerifyInput contains the data needed to verify a login. Either a TOTP code
+// or a recovery code must be given.
+type VerifyInput struct {
+	UserID       string
+	TenantID     string
+	Code         string
+	RecoveryCode string
+	ChallengeID  string
+	IPAddress    string
+}
+
+// DisableUseCase defines the interface for turning off two-factor authentication
+type DisableUseCase interface {
+	Execute(input DisableInput) error
+}
+
+// DisableInput contains the data needed to disable two-factor authentication
+type DisableInput struct {
+	UserID       string
+	TenantID     string
+	Code         string
+	RecoveryCode string
+}
+
+// RegenerateRecoveryCodesUseCase defines the interface for replacing a user's recovery codes
+type RegenerateRecoveryCodesUseCase interface {
+	Execute(input RegenerateRecoveryCodesInput) ([]string, error)
+}
+
+// RegenerateRecoveryCodesInput contains the data needed to regenerate recovery codes
+type RegenerateRecoveryCodesInput struct {
+	UserID   string
+	TenantID string
+	Code     string
+}
@@ -0,0 +1,58 @@
+package twofactor
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/totp"
+)
+
+type regenerateRecoveryCodesUseCase struct {
+	userRepository         repository.UserRepository
+	recoveryCodeRepository repository.RecoveryCodeRepository
+	clock                  totp.Clock
+}
+
+// NewRegenerateRecoveryCodesUseCase creates a new instance of RegenerateRecoveryCodesUseCase
+func NewRegenerateRecoveryCodesUseCase(
+	userRepository repository.UserRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	clock totp.Clock,
+) RegenerateRecoveryCodesUseCase {
+	return &regenerateRecoveryCodesUseCase{
+		userRepository:         userRepository,
+		recoveryCodeRepository: recoveryCodeRepository,
+		clock:                  clock,
+	}
+}
+
+// Execute replaces the user's recovery codes after checking a current TOTP code
+func (uc *regenerateRecoveryCodesUseCase) Execute(input RegenerateRecoveryCodesInput) ([]string, error) {
+	// Validate input
+	if input.UserID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if !user.TwoFactorEnabled {
+		return nil, ErrNotEnabled
+	}
+
+	// Check code
+	if err := verifyTOTP(uc.userRepository, user, input.Code, uc.clock()); err != nil {
+		return nil, err
+	}
+
+	// Issue recovery codes
+	return generateRecoveryCodes(uc.recoveryCodeRepository, user)
+}
@@ -0,0 +1,70 @@
+package twofactor
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/totp"
+)
+
+type verifyUseCase struct {
+	userRepository         repository.UserRepository
+	recoveryCodeRepository repository.RecoveryCodeRepository
+	challengeVerifier      challengeVerifier
+	clock                  totp.Clock
+}
+
+// NewVerifyUseCase creates a new instance of VerifyUseCase
+func NewVerifyUseCase(
+	userRepository repository.UserRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	loginThrottleRepository repository.LoginThrottleRepository,
+	tenantRepository repository.TenantRepository,
+	clock totp.Clock,
+) VerifyUseCase {
+	return &verifyUseCase{
+		userRepository:         userRepository,
+		recoveryCodeRepository: recoveryCodeRepository,
+		challengeVerifier: challengeVerifier{
+			loginThrottleRepository: loginThrottleRepository,
+			tenantRepository:        tenantRepository,
+		},
+		clock: clock,
+	}
+}
+
+// Execute checks the second factor of a login and returns the signed-in user
+func (uc *verifyUseCase) Execute(input VerifyInput) (*model.User, error) {
+	// Validate input
+	if input.UserID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.UserID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if !user.TwoFactorEnabled {
+		return nil, ErrNotEnabled
+	}
+
+	// Check code
+	err = uc.challengeVerifier.verify(user, input.ChallengeID, input.IPAddress, uc.clock(), func() error {
+		return verifyCode(uc.userRepository, uc.recoveryCodeRepository, user, input.Code, input.RecoveryCode, uc.clock())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return user without password
+	user.Password = ""
+	return user, nil
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"errors"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -10,22 +11,27 @@ import (
 )
 
 type authenticateUserUseCase struct {
-	userRepository   repository.UserRepository
-	tenantRepository repository.TenantRepository
+	userRepository          repository.UserRepository
+	loginThrottleRepository repository.LoginThrottleRepository
+	tenantRepository        repository.TenantRepository
 }
 
 // NewAuthenticateUserUseCase creates a new instance of AuthenticateUserUseCase
 func NewAuthenticateUserUseCase(
 	userRepository repository.UserRepository,
+	loginThrottleRepository repository.LoginThrottleRepository,
 	tenantRepository repository.TenantRepository,
 ) AuthenticateUserUseCase {
 	return &authenticateUserUseCase{
-		userRepository:   userRepository,
-		tenantRepository: tenantRepository,
+		userRepository:          userRepository,
+		loginThrottleRepository: loginThrottleRepository,
+		tenantRepository:        tenantRepository,
 	}
 }
 
-// Execute authenticates a user
+// Execute authenticates a user. Repeated failures for the same email or from
+// the same IP address are slowed down, and lock the account after the
+// tenant's limit.
 func (uc *authenticateUserUseCase) Execute(input AuthenticateUserInput) (*model.User, error) {
 	// Validate input
 	if input.Email == "" {
@@ -46,20 +52,45 @@ func (uc *authenticateUserUseCase) Execute(input AuthenticateUserInput) (*model.
 	if tenant == nil {
 		return nil, errors.New("tenant not found")
 	}
+	if tenant.IsDisabled() {
+		return nil, ErrTenantDisabled
+	}
+	if tenant.Settings.PasswordLoginDisabled() {
+		return nil, ErrPasswordLoginDisabled
+	}
+
+	// Check for earlier failures before looking at the password
+	now := time.Now()
+	throttler := NewLoginThrottler(uc.loginThrottleRepository, tenant, input.Email, input.IPAddress)
+	if err := throttler.Check(now); err != nil {
+		return nil, err
+	}
 
-	// Find user by email
+	// Find user by email and verify password. Without a password to compare
+	// with, a dummy hash is compared instead so that the response takes as long
+	// and doesn't reveal whether the account exists.
 	user, err := uc.userRepository.FindByEmail(input.Email, input.TenantID)
-	if err != nil {
-		return nil, errors.New("invalid email or password")
+	hasPassword := err == nil && user != nil && user.Password != ""
+	passwordHash := dummyPasswordHash
+	if hasPassword {
+		passwordHash = user.Password
 	}
-	if user == nil {
+	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(input.Password))
+	if err != nil || !hasPassword {
+		if err := throttler.RecordFailure(now); err != nil {
+			return nil, err
+		}
 		return nil, errors.New("invalid email or password")
 	}
 
-	// Verify password
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password))
-	if err != nil {
-		return nil, errors.New("invalid email or password")
+	// Forget earlier failures
+	if err := throttler.RecordSuccess(); err != nil {
+		return nil, err
+	}
+
+	// Deactivated users keep their content but cannot log in
+	if !user.IsActive() {
+		return nil, ErrUserDeactivated
 	}
 
 	// Return user without password
@@ -0,0 +1,116 @@
+package user
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type changePasswordUseCase struct {
+	userRepository            repository.UserRepository
+	passwordResetRepository   repository.PasswordResetRepository
+	passwordHistoryRepository repository.PasswordHistoryRepository
+	sessionRepository         repository.SessionRepository
+	tenantRepository          repository.TenantRepository
+}
+
+// NewChangePasswordUseCase creates a new instance of ChangePasswordUseCase
+func NewChangePasswordUseCase(
+	userRepository repository.UserRepository,
+	passwordResetRepository repository.PasswordResetRepository,
+	passwordHistoryRepository repository.PasswordHistoryRepository,
+	sessionRepository repository.SessionRepository,
+	tenantRepository repository.TenantRepository,
+) ChangePasswordUseCase {
+	return &changePasswordUseCase{
+		userRepository:            userRepository,
+		passwordResetRepository:   passwordResetRepository,
+		passwordHistoryRepository: passwordHistoryRepository,
+		sessionRepository:         sessionRepository,
+		tenantRepository:          tenantRepository,
+	}
+}
+
+// Execute changes a user's password after checking the current one
+func (uc *changePasswordUseCase) Execute(input ChangePasswordInput) (*model.User, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if input.NewPassword == "" {
+		return nil, errors.New("new password is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.ID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	// Verify current password
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.CurrentPassword))
+	if err != nil {
+		return nil, ErrIncorrectPassword
+	}
+
+	// Apply the tenant's password policy
+	policy := tenant.Settings.PasswordPolicy
+	if err := validatePassword(policy, input.NewPassword, user.Email, user.Name); err != nil {
+		return nil, err
+	}
+	if err := checkPasswordReuse(uc.passwordHistoryRepository, policy, user, input.NewPassword); err != nil {
+		return nil, err
+	}
+
+	// Set new password
+	previousHash := user.Password
+	if err := setPassword(user, input.NewPassword); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	err = uc.userRepository.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the replaced password to prevent its reuse
+	err = rememberPassword(uc.passwordHistoryRepository, policy, user, previousHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Outstanding reset links were sent for the old password
+	err = uc.passwordResetRepository.InvalidateForUser(user.ID, user.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign out all sessions, including the current one
+	err = uc.sessionRepository.RevokeAllForUser(user.ID, user.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return user without password
+	user.Password = ""
+	return user, nil
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type deleteUserUseCase struct {
+	userRepository   repository.UserRepository
+	roleRepository   repository.RoleRepository
+	tenantRepository repository.TenantRepository
+}
+
+// NewDeleteUserUseCase creates a new instance of DeleteUserUseCase
+func NewDeleteUserUseCase(
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	tenantRepository repository.TenantRepository,
+) DeleteUserUseCase {
+	return &deleteUserUseCase{
+		userRepository:   userRepository,
+		roleRepository:   roleRepository,
+		tenantRepository: tenantRepository,
+	}
+}
+
+// Execute deletes a user
+func (uc *deleteUserUseCase) Execute(input DeleteUserInput) error {
+	// Validate input
+	if input.ID == "" {
+		return errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.ID, input.TenantID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	// Check the actor may change the user
+	if err := checkCanManage(uc.roleRepository, input.Actor, user); err != nil {
+		return err
+	}
+
+	// Delete user
+	return uc.userRepository.Delete(input.ID, input.TenantID)
+}
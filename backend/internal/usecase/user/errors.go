@@ -0,0 +1,50 @@
+package user
+
+import "errors"
+
+var (
+	// ErrUserDeactivated is returned when a deactivated user tries to log in
+	ErrUserDeactivated = errors.New("user is deactivated")
+
+	// ErrTenantDisabled is returned when signing in to or registering with a tenant that is being deleted
+	ErrTenantDisabled = errors.New("tenant is disabled")
+
+	// ErrRegistrationClosed is returned when the tenant does not accept self-registration
+	ErrRegistrationClosed = errors.New("registration is closed")
+
+	// ErrInvitationRequired is returned when the tenant only accepts invited users
+	ErrInvitationRequired = errors.New("registration requires an invitation")
+
+	// ErrInvitationUsed is returned when the invitation of a user being registered was used concurrently
+	ErrInvitationUsed = errors.New("invitation is no longer pending")
+
+	// ErrEmailDomainNotAllowed is returned when the email domain is not on the tenant's allowlist
+	ErrEmailDomainNotAllowed = errors.New("email domain is not allowed")
+
+	// ErrInvalidResetToken is returned when a password reset token is unknown, used or expired
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+	// ErrIncorrectPassword is returned when the current password does not match
+	ErrIncorrectPassword = errors.New("current password is incorrect")
+
+	// ErrTooManyLoginAttempts is returned while login attempts are slowed down after failures
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+
+	// ErrAccountLocked is returned while an account is locked after too many failed logins
+	ErrAccountLocked = errors.New("account is temporarily locked")
+
+	// ErrChallengeExhausted is returned when too many wrong codes were entered for a login challenge
+	ErrChallengeExhausted = errors.New("too many wrong codes for this login challenge")
+
+	// ErrPasswordPolicy is returned when a new password does not meet the tenant's password policy
+	ErrPasswordPolicy = errors.New("password does not meet the password policy")
+
+	// ErrInvalidRole is returned when the role is not one of the tenant's roles
+	ErrInvalidRole = errors.New("invalid role")
+
+	// ErrEmailChangeNotAllowed is returned when changing the email of another user, which would let the changer take over the account
+	ErrEmailChangeNotAllowed = errors.New("only users can change their own email")
+
+	// ErrPasswordLoginDisabled is returned when the tenant only allows signing in through SSO
+	ErrPasswordLoginDisabled = errors.New("password login is disabled for this tenant")
+)
\ No newline at end of file
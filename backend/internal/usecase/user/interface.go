@@ -1,6 +1,9 @@
 package user
 
-import "github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+import (
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+)
 
 // RegisterUserUseCase defines the interface for registering a user
 type RegisterUserUseCase interface {
@@ -14,6 +17,11 @@ type RegisterUserInput struct {
 	Password string
 	TenantID string
 	Role     string
+	// SelfRegistration applies the tenant's registration mode and gives the
+	// user the viewer role
+	SelfRegistration bool
+	// Invitation, if set, is marked accepted together with the user's creation
+	Invitation *model.Invitation
 }
 
 // AuthenticateUserUseCase defines the interface for authenticating a user
@@ -23,9 +31,10 @@ type AuthenticateUserUseCase interface {
 
 // AuthenticateUserInput contains the data needed to authenticate a user
 type AuthenticateUserInput struct {
-	Email    string
-	Password string
-	TenantID string
+	Email     string
+	Password  string
+	TenantID  string
+	IPAddress string // Used to slow down repeated failures from the same address
 }
 
 // UpdateUserUseCase defines the interface for updating user information
@@ -38,8 +47,79 @@ type UpdateUserInput struct {
 	ID       string
 	Name     string
 	Email    string
-	Password string
 	Avatar   string
 	TenantID string
 	Role     string
-}
\ No newline at end of file
+	Actor    authz.Subject // User making the change, who can't grant permissions they don't have
+}
+
+// SetUserActiveUseCase defines the interface for deactivating or reactivating a user
+type SetUserActiveUseCase interface {
+	Execute(input SetUserActiveInput) (*model.User, error)
+}
+
+// SetUserActiveInput contains the data needed to deactivate or reactivate a user
+type SetUserActiveInput struct {
+	ID       string
+	TenantID string
+	Active   bool
+	Actor    authz.Subject // User making the change, who needs every permission of the user's role
+}
+
+// DeleteUserUseCase defines the interface for deleting a user
+type DeleteUserUseCase interface {
+	Execute(input DeleteUserInput) error
+}
+
+// DeleteUserInput contains the data needed to delete a user
+type DeleteUserInput struct {
+	ID       string
+	TenantID string
+	Actor    authz.Subject // User making the change, who needs every permission of the user's role
+}
+
+// RequestPasswordResetUseCase defines the interface for requesting a password reset email
+type RequestPasswordResetUseCase interface {
+	Execute(input RequestPasswordResetInput) error
+}
+
+// RequestPasswordResetInput contains the data needed to request a password reset
+type RequestPasswordResetInput struct {
+	Email    string
+	TenantID string
+}
+
+// ResetPasswordUseCase defines the interface for setting a new password with a reset token
+type ResetPasswordUseCase interface {
+	Execute(input ResetPasswordInput) error
+}
+
+// ResetPasswordInput contains the data needed to reset a password
+type ResetPasswordInput struct {
+	Token    string
+	Password string
+}
+
+// ChangePasswordUseCase defines the interface for changing a user's password
+type ChangePasswordUseCase interface {
+	Execute(input ChangePasswordInput) (*model.User, error)
+}
+
+// ChangePasswordInput contains the data needed to change a password
+type ChangePasswordInput struct {
+	ID              string
+	TenantID        string
+	CurrentPassword string
+	NewPassword     string
+}
+
+// UnlockUserUseCase defines the interface for lifting a user's login lockout
+type UnlockUserUseCase interface {
+	Execute(input UnlockUserInput) (*model.User, error)
+}
+
+// UnlockUserInput contains the data needed to unlock a user
+type UnlockUserInput struct {
+	ID       string
+	TenantID string
+}
@@ -0,0 +1,184 @@
+package user
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+const (
+	// accountBackoffAfter is the number of failures for an account before attempts are slowed down
+	accountBackoffAfter = 2
+
+	// ipBackoffAfter is the number of failures from an IP address before attempts are slowed down
+	ipBackoffAfter = 10
+
+	// loginBackoffBase is the first delay, doubled with every further failure
+	loginBackoffBase = time.Second
+
+	// loginBackoffMax caps the delay between attempts
+	loginBackoffMax = 15 * time.Minute
+
+	// loginFailureWindow is how long failures are remembered after the last one
+	loginFailureWindow = time.Hour
+
+	// maxChallengeAttempts is the number of wrong second-factor codes after
+	// which a login challenge can't be used anymore
+	maxChallengeAttempts = 5
+)
+
+// LoginThrottledError is returned when a login attempt is blocked. It wraps
+// ErrTooManyLoginAttempts or ErrAccountLocked.
+type LoginThrottledError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// LoginThrottler applies the failed-login limits of a tenant
+type LoginThrottler struct {
+	repository repository.LoginThrottleRepository
+	tenant     *model.Tenant
+	email      string
+	ipAddress  string
+}
+
+// NewLoginThrottler creates a throttler for logins to an account from an IP address
+func NewLoginThrottler(repository repository.LoginThrottleRepository, tenant *model.Tenant, email string, ipAddress string) *LoginThrottler {
+	return &LoginThrottler{
+		repository: repository,
+		tenant:     tenant,
+		email:      strings.ToLower(email),
+		ipAddress:  ipAddress,
+	}
+}
+
+// Check returns a LoginThrottledError if the account or the IP address may not try again yet
+func (t *LoginThrottler) Check(now time.Time) error {
+	account, err := t.repository.Find(t.tenant.ID, model.LoginThrottleKindAccount, t.email)
+	if err != nil {
+		return err
+	}
+	if account != nil && account.IsLocked(now) {
+		return &LoginThrottledError{Err: ErrAccountLocked, RetryAfter: account.RetryAfter(now)}
+	}
+	if account != nil && account.RetryAfter(now) > 0 {
+		return &LoginThrottledError{Err: ErrTooManyLoginAttempts, RetryAfter: account.RetryAfter(now)}
+	}
+
+	if t.ipAddress == "" {
+		return nil
+	}
+	ip, err := t.repository.Find(t.tenant.ID, model.LoginThrottleKindIP, t.ipAddress)
+	if err != nil {
+		return err
+	}
+	if ip != nil && ip.RetryAfter(now) > 0 {
+		return &LoginThrottledError{Err: ErrTooManyLoginAttempts, RetryAfter: ip.RetryAfter(now)}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login for the account and the IP address
+func (t *LoginThrottler) RecordFailure(now time.Time) error {
+	if err := t.record(model.LoginThrottleKindAccount, t.email, accountBackoffAfter, now); err != nil {
+		return err
+	}
+	if t.ipAddress == "" {
+		return nil
+	}
+	return t.record(model.LoginThrottleKindIP, t.ipAddress, ipBackoffAfter, now)
+}
+
+// CheckChallenge returns ErrChallengeExhausted once too many wrong codes were
+// entered for a login challenge, and otherwise works like Check
+func (t *LoginThrottler) CheckChallenge(challengeID string, now time.Time) error {
+	challenge, err := t.repository.Find(t.tenant.ID, model.LoginThrottleKindChallenge, challengeID)
+	if err != nil {
+		return err
+	}
+	if challenge != nil && challenge.Failures >= maxChallengeAttempts {
+		return ErrChallengeExhausted
+	}
+	return t.Check(now)
+}
+
+// RecordChallengeFailure counts a wrong code for a login challenge as well as
+// a failed login for the account and the IP address
+func (t *LoginThrottler) RecordChallengeFailure(challengeID string, now time.Time) error {
+	challenge := &model.LoginThrottle{
+		ID:           uuid.New().String(),
+		TenantID:     t.tenant.ID,
+		Kind:         model.LoginThrottleKindChallenge,
+		Key:          challengeID,
+		LastFailedAt: now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := t.repository.Increment(challenge, loginFailureWindow); err != nil {
+		return err
+	}
+	return t.RecordFailure(now)
+}
+
+// RecordSuccess forgets the account's failures. Failures from the IP address
+// are kept so that one valid account can't be used to reset them.
+func (t *LoginThrottler) RecordSuccess() error {
+	return t.repository.Delete(t.tenant.ID, model.LoginThrottleKindAccount, t.email)
+}
+
+func (t *LoginThrottler) record(kind string, key string, backoffAfter int, now time.Time) error {
+	// Count the failure, starting over once the previous failures are old enough
+	throttle := &model.LoginThrottle{
+		ID:           uuid.New().String(),
+		TenantID:     t.tenant.ID,
+		Kind:         kind,
+		Key:          key,
+		LastFailedAt: now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := t.repository.Increment(throttle, loginFailureWindow); err != nil {
+		return err
+	}
+
+	// Slow down further attempts exponentially
+	if delay := loginBackoff(throttle.Failures, backoffAfter); delay > 0 {
+		blockedUntil := now.Add(delay)
+		throttle.BlockedUntil = &blockedUntil
+	}
+
+	// Lock the account once the tenant's limit is reached
+	if kind == model.LoginThrottleKindAccount && throttle.Failures >= t.tenant.Settings.Security.FailedLoginLimit() {
+		lockedUntil := now.Add(t.tenant.Settings.Security.LockoutDuration())
+		throttle.LockedUntil = &lockedUntil
+	}
+
+	return t.repository.Block(throttle)
+}
+
+// loginBackoff returns the delay after the given number of failures
+func loginBackoff(failures int, backoffAfter int) time.Duration {
+	if failures <= backoffAfter {
+		return 0
+	}
+	delay := loginBackoffBase
+	for i := backoffAfter + 1; i < failures; i++ {
+		delay *= 2
+		if delay >= loginBackoffMax {
+			return loginBackoffMax
+		}
+	}
+	return delay
+}
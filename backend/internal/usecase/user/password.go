@@ -0,0 +1,177 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/commonpasswords"
+)
+
+// minPersonalInfoLength is the shortest part of an email or name that a password may not contain
+const minPersonalInfoLength = 3
+
+// PasswordPolicyError lists the rules of the tenant's password policy that a
+// new password breaks. It wraps ErrPasswordPolicy.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrPasswordPolicy, strings.Join(e.Violations, ", "))
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicy
+}
+
+// dummyPasswordHash is a bcrypt hash with the cost of hashPassword, compared
+// with when a login has no password to check
+const dummyPasswordHash = "$2a$10$i6wTWVllUZoGMQIl.ngQeOq04bUKCDcYu/1tTbZqHpAUgztES9frS"
+
+// hashPassword hashes a password for storage
+func hashPassword(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedPassword), nil
+}
+
+// setPassword replaces the user's password. Callers revoke the user's
+// sessions afterwards so that every device has to sign in again.
+func setPassword(user *model.User, password string) error {
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.Password = hashedPassword
+	user.PasswordChangedAt = &now
+	user.UpdatedAt = now
+	return nil
+}
+
+// validatePassword checks a new password against the tenant's policy
+func validatePassword(policy model.PasswordPolicy, password string, email string, name string) error {
+	var violations []string
+
+	if len([]rune(password)) < policy.RequiredLength() {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", policy.RequiredLength()))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if policy.RequireUppercase && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if policy.RequireLowercase && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if policy.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	if policy.DisallowPersonalInfo && containsPersonalInfo(password, email, name) {
+		violations = append(violations, "must not contain your email address or name")
+	}
+	if policy.DisallowCommon && commonpasswords.Contains(password) {
+		violations = append(violations, "is too common")
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// containsPersonalInfo reports whether the password contains the local part
+// of the email address or any part of the name
+func containsPersonalInfo(password string, email string, name string) bool {
+	password = strings.ToLower(password)
+
+	parts := strings.Fields(strings.ToLower(name))
+	if at := strings.LastIndex(email, "@"); at > 0 {
+		parts = append(parts, strings.ToLower(email[:at]))
+	}
+
+	for _, part := range parts {
+		if len([]rune(part)) >= minPersonalInfoLength && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPasswordReuse rejects the user's current password and, depending on
+// the policy, their recent previous passwords
+func checkPasswordReuse(
+	passwordHistoryRepository repository.PasswordHistoryRepository,
+	policy model.PasswordPolicy,
+	user *model.User,
+	password string,
+) error {
+	hashes := []string{user.Password}
+	if policy.HistorySize > 0 {
+		history, err := passwordHistoryRepository.FindRecentByUserID(user.ID, user.TenantID, policy.HistorySize)
+		if err != nil {
+			return err
+		}
+		for _, entry := range history {
+			hashes = append(hashes, entry.PasswordHash)
+		}
+	}
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return &PasswordPolicyError{Violations: []string{"must not be one of your recent passwords"}}
+		}
+	}
+	return nil
+}
+
+// rememberPassword adds a replaced password to the user's history and drops
+// entries the policy no longer needs
+func rememberPassword(
+	passwordHistoryRepository repository.PasswordHistoryRepository,
+	policy model.PasswordPolicy,
+	user *model.User,
+	previousHash string,
+) error {
+	if policy.HistorySize <= 0 {
+		return passwordHistoryRepository.Prune(user.ID, user.TenantID, 0)
+	}
+
+	err := passwordHistoryRepository.Create(&model.PasswordHistory{
+		ID:           uuid.New().String(),
+		UserID:       user.ID,
+		TenantID:     user.TenantID,
+		PasswordHash: previousHash,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return passwordHistoryRepository.Prune(user.ID, user.TenantID, policy.HistorySize)
+}
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
@@ -13,16 +12,19 @@ import (
 
 type registerUserUseCase struct {
 	userRepository   repository.UserRepository
+	roleRepository   repository.RoleRepository
 	tenantRepository repository.TenantRepository
 }
 
 // NewRegisterUserUseCase creates a new instance of RegisterUserUseCase
 func NewRegisterUserUseCase(
 	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
 	tenantRepository repository.TenantRepository,
 ) RegisterUserUseCase {
 	return &registerUserUseCase{
 		userRepository:   userRepository,
+		roleRepository:   roleRepository,
 		tenantRepository: tenantRepository,
 	}
 }
@@ -43,12 +45,6 @@ func (uc *registerUserUseCase) Execute(input RegisterUserInput) (*model.User, er
 		return nil, errors.New("tenant ID is required")
 	}
 
-	// Validate role
-	role := model.Role(input.Role)
-	if !role.IsValid() {
-		return nil, errors.New("invalid role")
-	}
-
 	// Verify tenant exists
 	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
 	if err != nil {
@@ -57,6 +53,23 @@ func (uc *registerUserUseCase) Execute(input RegisterUserInput) (*model.User, er
 	if tenant == nil {
 		return nil, errors.New("tenant not found")
 	}
+	if tenant.IsDisabled() {
+		return nil, ErrTenantDisabled
+	}
+
+	// Apply the tenant's registration policy
+	role := model.Role(input.Role)
+	if input.SelfRegistration {
+		role, err = uc.selfRegistrationRole(tenant, input.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate role
+	if _, err := findRole(uc.roleRepository, role.String(), input.TenantID); err != nil {
+		return nil, err
+	}
 
 	// Check if email already exists for this tenant
 	existingUser, err := uc.userRepository.FindByEmail(input.Email, input.TenantID)
@@ -64,8 +77,13 @@ func (uc *registerUserUseCase) Execute(input RegisterUserInput) (*model.User, er
 		return nil, errors.New("email already registered for this tenant")
 	}
 
+	// Apply the tenant's password policy
+	if err := validatePassword(tenant.Settings.PasswordPolicy, input.Password, input.Email, input.Name); err != nil {
+		return nil, err
+	}
+
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(input.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -76,18 +94,52 @@ func (uc *registerUserUseCase) Execute(input RegisterUserInput) (*model.User, er
 		ID:        uuid.New().String(),
 		Name:      input.Name,
 		Email:     input.Email,
-		Password:  string(hashedPassword),
+		Password:  hashedPassword,
 		TenantID:  input.TenantID,
 		Role:      role.String(),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
-	// Save user
-	err = uc.userRepository.Create(user)
+	// Save user, consuming the invitation in the same transaction
+	if input.Invitation == nil {
+		if err := uc.userRepository.Create(user); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	input.Invitation.Status = model.InvitationStatusAccepted
+	input.Invitation.AcceptedAt = &now
+	input.Invitation.UpdatedAt = now
+	created, err := uc.userRepository.CreateInvited(user, input.Invitation)
 	if err != nil {
 		return nil, err
 	}
+	if !created {
+		return nil, ErrInvitationUsed
+	}
 
 	return user, nil
-}
\ No newline at end of file
+}
+
+// selfRegistrationRole checks the tenant's registration mode and returns the
+// role of a self-registered user
+func (uc *registerUserUseCase) selfRegistrationRole(tenant *model.Tenant, email string) (model.Role, error) {
+	// Tenants signing in only through SSO provision users on their first login
+	if tenant.Settings.PasswordLoginDisabled() {
+		return "", ErrPasswordLoginDisabled
+	}
+
+	registration := tenant.Settings.Registration
+	switch registration.Mode {
+	case model.RegistrationModeOpen:
+		if !registration.AllowsEmail(email) {
+			return "", ErrEmailDomainNotAllowed
+		}
+		return model.RoleViewer, nil
+	case model.RegistrationModeInviteOnly:
+		return "", ErrInvitationRequired
+	default:
+		return "", ErrRegistrationClosed
+	}
+}
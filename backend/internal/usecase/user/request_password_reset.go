@@ -0,0 +1,118 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/mail"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+// PasswordResetTokenPurpose identifies password reset tokens when they are signed
+const PasswordResetTokenPurpose = "password_reset"
+
+// passwordResetTTL is how long a password reset token can be used
+const passwordResetTTL = time.Hour
+
+type requestPasswordResetUseCase struct {
+	userRepository          repository.UserRepository
+	passwordResetRepository repository.PasswordResetRepository
+	tenantRepository        repository.TenantRepository
+	mailer                  mail.Mailer
+	signer                  *security.TokenSigner
+	resetURL                string
+}
+
+// NewRequestPasswordResetUseCase creates a new instance of RequestPasswordResetUseCase.
+// The token is appended to resetURL in the password reset email.
+func NewRequestPasswordResetUseCase(
+	userRepository repository.UserRepository,
+	passwordResetRepository repository.PasswordResetRepository,
+	tenantRepository repository.TenantRepository,
+	mailer mail.Mailer,
+	signer *security.TokenSigner,
+	resetURL string,
+) RequestPasswordResetUseCase {
+	return &requestPasswordResetUseCase{
+		userRepository:          userRepository,
+		passwordResetRepository: passwordResetRepository,
+		tenantRepository:        tenantRepository,
+		mailer:                  mailer,
+		signer:                  signer,
+		resetURL:                resetURL,
+	}
+}
+
+// Execute emails a password reset link to the user. Unknown and deactivated
+// accounts are ignored so that the response does not reveal which emails exist.
+func (uc *requestPasswordResetUseCase) Execute(input RequestPasswordResetInput) error {
+	// Validate input
+	if input.Email == "" {
+		return errors.New("email is required")
+	}
+	if input.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Find user by email
+	user, err := uc.userRepository.FindByEmail(input.Email, input.TenantID)
+	if err != nil || user == nil || !user.IsActive() {
+		return nil
+	}
+
+	// Generate token
+	token, err := uc.signer.Generate()
+	if err != nil {
+		return err
+	}
+
+	// Create reset token
+	now := time.Now()
+	resetToken := &model.PasswordResetToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TenantID:  user.TenantID,
+		TokenHash: security.HashToken(token),
+		ExpiresAt: now.Add(passwordResetTTL),
+		CreatedAt: now,
+	}
+
+	// Save reset token
+	err = uc.passwordResetRepository.Create(resetToken)
+	if err != nil {
+		return err
+	}
+
+	// Send reset email
+	err = uc.mailer.Send(mail.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Reset your %s password", tenant.Name),
+		Body: fmt.Sprintf(
+			"A password reset was requested for your account on %s.\n\nSet a new password here:\n%s?token=%s\n\nThis link expires in %d minutes. If you did not request a reset, you can ignore this email.\n",
+			tenant.Name,
+			uc.resetURL,
+			url.QueryEscape(token),
+			int(passwordResetTTL.Minutes()),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	return nil
+}
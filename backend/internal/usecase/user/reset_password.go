@@ -0,0 +1,113 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/infrastructure/security"
+)
+
+type resetPasswordUseCase struct {
+	userRepository            repository.UserRepository
+	passwordResetRepository   repository.PasswordResetRepository
+	passwordHistoryRepository repository.PasswordHistoryRepository
+	sessionRepository         repository.SessionRepository
+	tenantRepository          repository.TenantRepository
+	signer                    *security.TokenSigner
+}
+
+// NewResetPasswordUseCase creates a new instance of ResetPasswordUseCase
+func NewResetPasswordUseCase(
+	userRepository repository.UserRepository,
+	passwordResetRepository repository.PasswordResetRepository,
+	passwordHistoryRepository repository.PasswordHistoryRepository,
+	sessionRepository repository.SessionRepository,
+	tenantRepository repository.TenantRepository,
+	signer *security.TokenSigner,
+) ResetPasswordUseCase {
+	return &resetPasswordUseCase{
+		userRepository:            userRepository,
+		passwordResetRepository:   passwordResetRepository,
+		passwordHistoryRepository: passwordHistoryRepository,
+		sessionRepository:         sessionRepository,
+		tenantRepository:          tenantRepository,
+		signer:                    signer,
+	}
+}
+
+// Execute sets a new password using a reset token
+func (uc *resetPasswordUseCase) Execute(input ResetPasswordInput) error {
+	// Validate input
+	if input.Token == "" {
+		return errors.New("token is required")
+	}
+	if input.Password == "" {
+		return errors.New("password is required")
+	}
+
+	// Reject forged tokens before looking them up
+	if err := uc.signer.Verify(input.Token); err != nil {
+		return ErrInvalidResetToken
+	}
+
+	// Get reset token
+	resetToken, err := uc.passwordResetRepository.FindByTokenHash(security.HashToken(input.Token))
+	if err != nil || resetToken == nil || !resetToken.IsUsable() {
+		return ErrInvalidResetToken
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(resetToken.UserID, resetToken.TenantID)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive() {
+		return ErrInvalidResetToken
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(user.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New("tenant not found")
+	}
+
+	// Apply the tenant's password policy before the token is consumed, so
+	// that the user can try another password with the same link
+	policy := tenant.Settings.PasswordPolicy
+	if err := validatePassword(policy, input.Password, user.Email, user.Name); err != nil {
+		return err
+	}
+	if err := checkPasswordReuse(uc.passwordHistoryRepository, policy, user, input.Password); err != nil {
+		return err
+	}
+
+	// Consume this and any other outstanding reset tokens
+	err = uc.passwordResetRepository.InvalidateForUser(user.ID, user.TenantID)
+	if err != nil {
+		return err
+	}
+
+	// Set new password
+	previousHash := user.Password
+	if err := setPassword(user, input.Password); err != nil {
+		return err
+	}
+
+	// Save user
+	err = uc.userRepository.Update(user)
+	if err != nil {
+		return err
+	}
+
+	// Keep the replaced password to prevent its reuse
+	err = rememberPassword(uc.passwordHistoryRepository, policy, user, previousHash)
+	if err != nil {
+		return err
+	}
+
+	// Sign out all sessions
+	return uc.sessionRepository.RevokeAllForUser(user.ID, user.TenantID)
+}
@@ -0,0 +1,38 @@
+package user
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+// findRole returns the tenant's role with the name, or ErrInvalidRole when
+// the tenant has no such role
+func findRole(roleRepository repository.RoleRepository, name string, tenantID string) (*model.TenantRole, error) {
+	role, err := roleRepository.FindByName(name, tenantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRole
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+// checkCanManage returns authz.ErrForbidden unless the actor may change the
+// user. Changing another user needs every permission of their current role,
+// so that nobody can lock out or take over users with more rights.
+func checkCanManage(roleRepository repository.RoleRepository, actor authz.Subject, user *model.User) error {
+	if actor.UserID != "" && actor.UserID == user.ID {
+		return nil
+	}
+	role, err := findRole(roleRepository, user.Role, user.TenantID)
+	if err != nil {
+		return err
+	}
+	return authz.CanGrant(actor, role)
+}
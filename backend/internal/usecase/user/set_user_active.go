@@ -0,0 +1,92 @@
+package user
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type setUserActiveUseCase struct {
+	userRepository    repository.UserRepository
+	roleRepository    repository.RoleRepository
+	sessionRepository repository.SessionRepository
+	tenantRepository  repository.TenantRepository
+}
+
+// NewSetUserActiveUseCase creates a new instance of SetUserActiveUseCase
+func NewSetUserActiveUseCase(
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	sessionRepository repository.SessionRepository,
+	tenantRepository repository.TenantRepository,
+) SetUserActiveUseCase {
+	return &setUserActiveUseCase{
+		userRepository:    userRepository,
+		roleRepository:    roleRepository,
+		sessionRepository: sessionRepository,
+		tenantRepository:  tenantRepository,
+	}
+}
+
+// Execute deactivates or reactivates a user. Authored content is kept either way.
+func (uc *setUserActiveUseCase) Execute(input SetUserActiveInput) (*model.User, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.ID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	// Check the actor may change the user
+	if err := checkCanManage(uc.roleRepository, input.Actor, user); err != nil {
+		return nil, err
+	}
+
+	// Update activation state
+	now := time.Now()
+	if input.Active {
+		user.DeactivatedAt = nil
+	} else if user.IsActive() {
+		user.DeactivatedAt = &now
+	}
+	user.UpdatedAt = now
+
+	// Save user
+	err = uc.userRepository.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deactivated users are signed out immediately
+	if !input.Active {
+		err = uc.sessionRepository.RevokeAllForUser(user.ID, user.TenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return user without password
+	user.Password = ""
+	return user, nil
+}
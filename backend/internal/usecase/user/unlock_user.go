@@ -0,0 +1,67 @@
+package user
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
+)
+
+type unlockUserUseCase struct {
+	userRepository          repository.UserRepository
+	loginThrottleRepository repository.LoginThrottleRepository
+	tenantRepository        repository.TenantRepository
+}
+
+// NewUnlockUserUseCase creates a new instance of UnlockUserUseCase
+func NewUnlockUserUseCase(
+	userRepository repository.UserRepository,
+	loginThrottleRepository repository.LoginThrottleRepository,
+	tenantRepository repository.TenantRepository,
+) UnlockUserUseCase {
+	return &unlockUserUseCase{
+		userRepository:          userRepository,
+		loginThrottleRepository: loginThrottleRepository,
+		tenantRepository:        tenantRepository,
+	}
+}
+
+// Execute clears a user's failed logins so that they can log in again right away
+func (uc *unlockUserUseCase) Execute(input UnlockUserInput) (*model.User, error) {
+	// Validate input
+	if input.ID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if input.TenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify tenant exists
+	tenant, err := uc.tenantRepository.FindByID(input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.New("tenant not found")
+	}
+
+	// Find user
+	user, err := uc.userRepository.FindByID(input.ID, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	// Clear failed logins
+	err = uc.loginThrottleRepository.Delete(user.TenantID, model.LoginThrottleKindAccount, strings.ToLower(user.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return user without password
+	user.Password = ""
+	return user, nil
+}
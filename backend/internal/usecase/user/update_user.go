@@ -4,24 +4,26 @@ import (
 	"errors"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
-
+	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/authz"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/model"
 	"github.com/hyorimitsu/knowledge-hub/backend/internal/domain/repository"
 )
 
 type updateUserUseCase struct {
 	userRepository   repository.UserRepository
+	roleRepository   repository.RoleRepository
 	tenantRepository repository.TenantRepository
 }
 
 // NewUpdateUserUseCase creates a new instance of UpdateUserUseCase
 func NewUpdateUserUseCase(
 	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
 	tenantRepository repository.TenantRepository,
 ) UpdateUserUseCase {
 	return &updateUserUseCase{
 		userRepository:   userRepository,
+		roleRepository:   roleRepository,
 		tenantRepository: tenantRepository,
 	}
 }
@@ -54,8 +56,17 @@ func (uc *updateUserUseCase) Execute(input UpdateUserInput) (*model.User, error)
 		return nil, errors.New("user not found")
 	}
 
-	// Check if email is being changed and if it's already in use
+	// Check the actor may change the user
+	if err := checkCanManage(uc.roleRepository, input.Actor, user); err != nil {
+		return nil, err
+	}
+
+	// Check if email is being changed and if it's already in use. Only the
+	// user may change it, as the new address receives password resets.
 	if input.Email != "" && input.Email != user.Email {
+		if input.Actor.UserID != user.ID {
+			return nil, ErrEmailChangeNotAllowed
+		}
 		existingUser, err := uc.userRepository.FindByEmail(input.Email, input.TenantID)
 		if err == nil && existingUser != nil && existingUser.ID != input.ID {
 			return nil, errors.New("email already in use")
@@ -67,22 +78,18 @@ func (uc *updateUserUseCase) Execute(input UpdateUserInput) (*model.User, error)
 	if input.Name != "" {
 		user.Name = input.Name
 	}
-	if input.Password != "" {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
-		if err != nil {
-			return nil, err
-		}
-		user.Password = string(hashedPassword)
-	}
 	if input.Avatar != "" {
 		user.Avatar = input.Avatar
 	}
-	if input.Role != "" {
-		role := model.Role(input.Role)
-		if !role.IsValid() {
-			return nil, errors.New("invalid role")
+	if input.Role != "" && input.Role != user.Role {
+		role, err := findRole(uc.roleRepository, input.Role, input.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		if err := authz.CanGrant(input.Actor, role); err != nil {
+			return nil, err
 		}
-		user.Role = role.String()
+		user.Role = role.Name
 	}
 
 	user.UpdatedAt = time.Now()
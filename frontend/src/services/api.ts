@@ -124,6 +124,19 @@ export class ApiClient {
     return headers
   }
 
+  // Get headers naming the tenant of a login or registration
+  private getTenantHeaders(tenantId: string): HeadersInit {
+    const headers: HeadersInit = {
+      'Content-Type': 'application/json',
+    }
+
+    if (tenantId) {
+      headers['X-Tenant'] = tenantId
+    }
+
+    return headers
+  }
+
   // Handle response
   private async handleResponse<T>(response: Response): Promise<T> {
     const data = await response.json()
@@ -140,7 +153,7 @@ export class ApiClient {
   async login(data: LoginRequest): Promise<TokenResponse> {
     const response = await fetch(`${this.baseUrl}${API_ENDPOINTS.AUTH.LOGIN}`, {
       method: 'POST',
-      headers: this.getHeaders(false),
+      headers: this.getTenantHeaders(data.tenant_id),
       body: JSON.stringify(data),
     })
 
@@ -152,7 +165,7 @@ export class ApiClient {
   async register(data: RegisterRequest): Promise<TokenResponse> {
     const response = await fetch(`${this.baseUrl}${API_ENDPOINTS.AUTH.REGISTER}`, {
       method: 'POST',
-      headers: this.getHeaders(false),
+      headers: this.getTenantHeaders(data.tenant_id),
       body: JSON.stringify(data),
     })
 